@@ -1,6 +1,10 @@
 package main
 
-import "github.com/JesusG2000/hexsatisfaction/internal/app"
+import (
+	"os"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/app"
+)
 
 // @title Hexsatisfaction API
 // @version 1.0
@@ -14,5 +18,9 @@ import "github.com/JesusG2000/hexsatisfaction/internal/app"
 // @name Authorization
 
 func main() {
-	app.Run()
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(app.Migrate(os.Args[2:]))
+	}
+
+	os.Exit(app.Run(app.WithSchemaCheck()))
 }
@@ -2,11 +2,8 @@ package app
 
 import (
 	"context"
-	"log"
 	"net"
 	"net/http"
-	"os"
-	"os/signal"
 	"time"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/config"
@@ -15,74 +12,251 @@ import (
 	"github.com/JesusG2000/hexsatisfaction/internal/server"
 	"github.com/JesusG2000/hexsatisfaction/internal/service"
 	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/database/migrate"
 	"github.com/JesusG2000/hexsatisfaction/pkg/database/pg"
 	"github.com/JesusG2000/hexsatisfaction/pkg/grpc/api"
+	"github.com/JesusG2000/hexsatisfaction/pkg/hash"
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
+	"github.com/JesusG2000/hexsatisfaction/pkg/metrics"
+	"github.com/JesusG2000/hexsatisfaction/pkg/migrations"
+	"github.com/JesusG2000/hexsatisfaction/pkg/probe"
+	"github.com/JesusG2000/hexsatisfaction/pkg/ratelimit"
+	"github.com/JesusG2000/hexsatisfaction/pkg/tracing"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
 )
 
-// Run runs hexsatisfaction service
-func Run() {
+const (
+	// probeTimeout bounds every check of the readiness probe.
+	probeTimeout = 2 * time.Second
+	// tracingShutdownTimeout bounds flushing of the spans left on exit.
+	tracingShutdownTimeout = 5 * time.Second
+)
+
+// Option configures Run.
+type Option func(*options)
+
+type options struct {
+	checkSchema bool
+}
+
+// WithSchemaCheck makes Run refuse to start when some migrations aren't applied.
+func WithSchemaCheck() Option {
+	return func(o *options) {
+		o.checkSchema = true
+	}
+}
+
+// Run runs hexsatisfaction service until SIGINT or SIGTERM and returns the exit code.
+func Run(opts ...Option) int {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	ctx := context.Background()
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
 
 	cfg, err := config.Init()
 	if err != nil {
-		log.Fatal("Init config error: ", err)
+		logger.Default().Error("init config", "error", err)
+		return ExitConfig
 	}
 
+	log, err := logger.New(cfg.Log)
+	if err != nil {
+		logger.Default().Error("init logger", "error", err)
+		return ExitConfig
+	}
+	logger.SetDefault(log)
+
+	shutdownTracing, err := tracing.Init(ctx, cfg.Trace)
+	if err != nil {
+		log.Error("init tracing", "error", err)
+		return ExitConfig
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			log.Error("failed to flush traces", "error", err)
+		}
+	}()
+
 	db, err := pg.NewPg(cfg.Pg)
 	if err != nil {
-		log.Fatal("Init db error: ", err)
+		log.Error("init db", "error", err)
+		return ExitUnavailable
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Error("failed to close db", "error", err)
+		}
+	}()
+
+	if err := metrics.RegisterDB(db, cfg.Pg.DatabaseName); err != nil {
+		log.Error("init db metrics", "error", err)
+		return ExitConfig
+	}
+
+	migrator, err := migrate.NewMigrator(db, migrations.FS)
+	if err != nil {
+		log.Error("init migrations", "error", err)
+		return ExitConfig
+	}
+
+	if o.checkSchema {
+		if err := checkSchema(ctx, migrator); err != nil {
+			log.Error("check schema", "error", err)
+			return ExitUnavailable
+		}
+	}
+
+	repos := repository.NewRepositories(db, cfg.Pg.QueryTimeout)
+
+	tokenManager, err := auth.NewManager(cfg.Auth.SigningKey, cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL, repos.User)
+	if err != nil {
+		log.Error("init jwt-token", "error", err)
+		return ExitConfig
 	}
 
-	tokenManager, err := auth.NewManager(cfg.Auth.SigningKey)
+	hasher, err := hash.NewHasher(cfg.Hash)
 	if err != nil {
-		log.Fatal("Init jwt-token error: ", err)
+		log.Error("init password hasher", "error", err)
+		return ExitConfig
+	}
+
+	authLimiter, err := ratelimit.New(cfg.Limit, db, "auth", ratelimit.PerMinute(cfg.Limit.IPPerMinute, cfg.Limit.IPBurst))
+	if err != nil {
+		log.Error("init auth rate limiter", "error", err)
+		return ExitConfig
+	}
+
+	clientLimiter, err := ratelimit.New(cfg.Limit, db, "client", ratelimit.PerMinute(cfg.Limit.ClientPerMinute, cfg.Limit.ClientBurst))
+	if err != nil {
+		log.Error("init client rate limiter", "error", err)
+		return ExitConfig
+	}
+
+	limiterKeys, err := ratelimit.NewKeys(cfg.Limit.TrustedProxies)
+	if err != nil {
+		log.Error("init rate limiter keys", "error", err)
+		return ExitConfig
 	}
 
-	repos := repository.NewRepositories(db)
 	grpcExistanceChecker := api.NewExistChecker(*repos)
 	services := service.NewServices(service.Deps{
-		Repos:        repos,
-		TokenManager: tokenManager,
+		Repos:          repos,
+		TokenManager:   tokenManager,
+		PasswordHasher: hasher,
+		Lockout: service.Lockout{
+			MaxFailures: cfg.Limit.LoginMaxFailures,
+			Backoff:     cfg.Limit.LoginBackoff,
+			Duration:    cfg.Limit.LoginLockout,
+		},
+		Logger: log,
 	})
 
-	router := handler.NewHandler(services, tokenManager)
+	permissions, err := services.UserRole.FindPermissions(ctx)
+	if err != nil {
+		log.Error("init permissions", "error", err)
+		return ExitUnavailable
+	}
+
+	router := handler.NewHandler(services, tokenManager, auth.NewAuthorizer(permissions), handler.Limiters{
+		Auth:   authLimiter,
+		Client: clientLimiter,
+		Keys:   limiterKeys,
+	}, log)
 
 	routeSwagger(router)
 
-	srv := server.NewServer(cfg, router)
-	go startService(ctx, srv)
+	guard, err := api.NewGuard(tokenManager, cfg.GRPC.ServiceTokens, cfg.GRPC.AllowedMethods)
+	if err != nil {
+		log.Error("init grpc guard", "error", err)
+		return ExitConfig
+	}
 
+	rateLimit := api.NewRateLimit(clientLimiter)
+	health := api.NewHealth(db.PingContext, cfg.GRPC.HealthInterval, log)
+	grpcServices := api.Services{
+		Existance:  grpcExistanceChecker,
+		Directory:  api.NewDirectory(services),
+		Health:     health,
+		Reflection: cfg.GRPC.Reflection,
+	}
+	grpcServer := api.NewGrpcServer(grpcServices,
+		grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor(), metrics.UnaryServerInterceptor(), guard.Unary(), rateLimit.Unary()),
+		grpc.ChainStreamInterceptor(tracing.StreamServerInterceptor(), metrics.StreamServerInterceptor(), guard.Stream(), rateLimit.Stream()),
+	)
 	addr := net.JoinHostPort(cfg.GRPC.Host, cfg.GRPC.Port)
-	_, errChan := api.NewGrpcServer(addr, grpcExistanceChecker)
 
-	log.Printf("server started")
+	prober := probe.NewProber(probeTimeout)
+	prober.Add("database", db.PingContext)
+	prober.Add("migrations", func(ctx context.Context) error {
+		return checkSchema(ctx, migrator)
+	})
+	prober.Add("grpc", health.Serving)
 
-	err = <-errChan
-	if err != nil {
-		log.Panic(err)
-	}
+	routeProbes(router, prober)
 
-	<-stop
+	srv := server.NewServer(cfg, router)
+	adminSrv := server.NewAdminServer(cfg, adminHandler(prober))
 
 	const timeout = 5 * time.Second
 
-	ctx, shutdown := context.WithTimeout(context.Background(), timeout)
-	defer shutdown()
-
-	if err := srv.Stop(ctx); err != nil {
-		log.Printf("failed to stop server: %v", err)
-	}
+	lc := newLifecycle(log)
+	lc.add("health monitor", 0, func(ctx context.Context) error {
+		health.Monitor(ctx)
+		return nil
+	}, nil)
+	lc.add("rate limit sweeper", 0, func(ctx context.Context) error {
+		sweepLimiters(ctx, cfg.Limit.SweepInterval, log, authLimiter, clientLimiter)
+		return nil
+	}, nil)
+	lc.add("purger", 0, func(ctx context.Context) error {
+		purgeDeleted(ctx, cfg.Purge, repos, log)
+		return nil
+	}, nil)
+	lc.add("admin server", timeout, func(context.Context) error {
+		if err := adminSrv.Run(); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}, adminSrv.Stop)
+	lc.add("grpc server", timeout, func(context.Context) error {
+		return api.Serve(grpcServer, addr)
+	}, func(ctx context.Context) error {
+		health.Shutdown()
+		api.Stop(ctx, grpcServer)
+		return nil
+	})
+	lc.add("http server", timeout, func(context.Context) error {
+		if err := srv.Run(); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}, srv.Stop)
+	lc.add("readiness", cfg.HTTP.DrainDelay, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, func(ctx context.Context) error {
+		// The context of stop lasts the drain delay.
+		prober.Drain()
+		<-ctx.Done()
+		return nil
+	})
 
-	log.Printf("shutting down server...")
-}
+	log.Info("server started", "http_port", cfg.HTTP.Port, "admin_port", cfg.HTTP.AdminPort, "grpc_address", addr)
 
-func startService(ctx context.Context, coreService *server.Server) {
-	if err := coreService.Run(); err != nil {
-		log.Fatal(ctx, "service shutdown: ", err.Error())
+	if err := lc.run(ctx); err != nil {
+		log.Error("service shutdown", "error", err)
+		return ExitFailure
 	}
+
+	return ExitOK
 }
 
 func routeSwagger(router *handler.API) {
@@ -92,3 +266,71 @@ func routeSwagger(router *handler.API) {
 	router.Handle("/docs", sh)
 	router.Handle("/swagger.yaml", http.FileServer(http.Dir("./docs/")))
 }
+
+// routeProbes routes the liveness and readiness probes, which tell nothing about components.
+func routeProbes(router *handler.API, prober *probe.Prober) {
+	router.HandleFunc(handler.LivePath, prober.Live).Methods(http.MethodGet)
+	router.HandleFunc(handler.ReadyPath, prober.Ready).Methods(http.MethodGet)
+}
+
+// adminHandler serves metrics and health details with errors of components,
+// they are kept off the public port.
+func adminHandler(prober *probe.Prober) http.Handler {
+	router := mux.NewRouter()
+	router.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet)
+	router.HandleFunc("/health", prober.Details).Methods(http.MethodGet)
+
+	return router
+}
+
+// sweepLimiters removes full buckets of the limiters every interval until ctx is done.
+func sweepLimiters(ctx context.Context, interval time.Duration, log logger.Logger, limiters ...ratelimit.Limiter) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, limiter := range limiters {
+			if err := limiter.Sweep(ctx); err != nil {
+				log.Warn("rate limiter sweep failed", "error", err)
+			}
+		}
+	}
+}
+
+// purgeDeleted hard deletes authors and then users deleted longer than the retention ago
+// every interval until ctx is done.
+func purgeDeleted(ctx context.Context, cfg config.PurgeConfig, repos *repository.Repositories, log logger.Logger) {
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		before := time.Now().Add(-cfg.Retention)
+		authors, err := repos.Author.Purge(ctx, before)
+		if err != nil {
+			log.Warn("authors purge failed", "error", err)
+			continue
+		}
+
+		users, err := repos.User.Purge(ctx, before)
+		if err != nil {
+			log.Warn("users purge failed", "error", err)
+			continue
+		}
+
+		if authors > 0 || users > 0 {
+			log.Info("purged deleted rows", "authors", authors, "users", users)
+		}
+	}
+}
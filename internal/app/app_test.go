@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/handler"
+	"github.com/JesusG2000/hexsatisfaction/internal/service"
+	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
+	"github.com/JesusG2000/hexsatisfaction/pkg/probe"
+	"github.com/JesusG2000/hexsatisfaction/pkg/ratelimit"
+	"github.com/pkg/errors"
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteProbes(t *testing.T) {
+	assert := testAssert.New(t)
+	prober := probe.NewProber(time.Second)
+	prober.Add("database", func(context.Context) error {
+		return errors.New("dial tcp 10.0.0.5:5432: connection refused")
+	})
+
+	tokenManager, err := auth.NewManager("key", time.Minute, time.Hour, nil)
+	require.NoError(t, err)
+	public := handler.NewHandler(&service.Services{}, tokenManager, auth.NewAuthorizer(nil), handler.Limiters{Keys: &ratelimit.Keys{}}, logger.Nop())
+	routeProbes(public, prober)
+	admin := adminHandler(prober)
+
+	tt := []struct {
+		name    string
+		handler http.Handler
+		path    string
+		expCode int
+	}{
+		{
+			name:    "public liveness",
+			handler: public,
+			path:    "/healthz",
+			expCode: http.StatusOK,
+		},
+		{
+			name:    "public readiness",
+			handler: public,
+			path:    "/readyz",
+			expCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:    "no public health details",
+			handler: public,
+			path:    "/health",
+			expCode: http.StatusNotFound,
+		},
+		{
+			name:    "no public metrics",
+			handler: public,
+			path:    "/metrics",
+			expCode: http.StatusNotFound,
+		},
+		{
+			name:    "admin health details",
+			handler: admin,
+			path:    "/health",
+			expCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:    "admin metrics",
+			handler: admin,
+			path:    "/metrics",
+			expCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tc.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			assert.Equal(tc.expCode, w.Code)
+			if tc.handler == public {
+				assert.False(strings.Contains(w.Body.String(), "connection refused"), "public responses hide errors")
+			}
+		})
+	}
+}
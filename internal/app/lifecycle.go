@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// Exit codes returned by Run.
+const (
+	// ExitOK means the service was stopped by a signal.
+	ExitOK = 0
+	// ExitFailure means a server or a worker failed while running.
+	ExitFailure = 1
+	// ExitUnavailable means the database can't be reached or its schema isn't up to date.
+	ExitUnavailable = 69
+	// ExitConfig means the service is misconfigured.
+	ExitConfig = 78
+)
+
+// component is a server or a background worker run by lifecycle.
+type component struct {
+	name string
+	// run blocks until the component fails or is stopped, it returns nil when stopped.
+	run func(ctx context.Context) error
+	// stop makes run return, it may be nil if run returns when ctx is done.
+	stop func(ctx context.Context) error
+	// timeout limits stop, so a stuck component doesn't take the time of the next ones.
+	timeout time.Duration
+}
+
+// lifecycle runs components under one errgroup until a signal or a failure of any of them,
+// then stops them in the reverse order of adding.
+type lifecycle struct {
+	components []component
+	log        logger.Logger
+}
+
+func newLifecycle(log logger.Logger) *lifecycle {
+	return &lifecycle{log: log}
+}
+
+// add adds a component started in the order of adding, stop gets a context done after the timeout.
+func (l *lifecycle) add(name string, timeout time.Duration, run, stop func(ctx context.Context) error) {
+	l.components = append(l.components, component{name: name, run: run, stop: stop, timeout: timeout})
+}
+
+// run runs components until SIGINT, SIGTERM or the first failure and returns the failure.
+func (l *lifecycle) run(ctx context.Context) error {
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	for _, c := range l.components {
+		c := c
+		group.Go(func() error {
+			return errors.Wrapf(c.run(groupCtx), "%s failed", c.name)
+		})
+	}
+
+	<-groupCtx.Done()
+	l.log.Info("shutting down server")
+
+	for i := len(l.components) - 1; i >= 0; i-- {
+		c := l.components[i]
+		if c.stop == nil {
+			continue
+		}
+
+		if err := l.stop(c); err != nil {
+			l.log.Error("failed to stop", "component", c.name, "error", err)
+		}
+	}
+
+	return group.Wait()
+}
+
+func (l *lifecycle) stop(c component) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	return c.stop(ctx)
+}
@@ -0,0 +1,163 @@
+package app
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
+	"github.com/pkg/errors"
+	testAssert "github.com/stretchr/testify/assert"
+)
+
+// recorder records names of stopped components in the order of stopping.
+type recorder struct {
+	mu      sync.Mutex
+	stopped []string
+}
+
+func (r *recorder) stop(name string) func(context.Context) error {
+	return func(context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.stopped = append(r.stopped, name)
+		return nil
+	}
+}
+
+// blocking returns a run func which blocks until stop is called and a stop func recorded by r.
+func (r *recorder) blocking(name string) (run, stop func(context.Context) error) {
+	done := make(chan struct{})
+	var once sync.Once
+	run = func(context.Context) error {
+		<-done
+		return nil
+	}
+	stop = func(ctx context.Context) error {
+		once.Do(func() {
+			close(done)
+		})
+		return r.stop(name)(ctx)
+	}
+
+	return run, stop
+}
+
+func TestLifecycle_Run(t *testing.T) {
+	assert := testAssert.New(t)
+	rec := &recorder{}
+	lc := newLifecycle(logger.Nop())
+
+	firstRun, firstStop := rec.blocking("first")
+	lc.add("first", time.Second, firstRun, firstStop)
+	lc.add("worker", 0, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, nil)
+	lc.add("failing", time.Second, func(context.Context) error {
+		return errors.New("boom")
+	}, rec.stop("failing"))
+	lastRun, lastStop := rec.blocking("last")
+	lc.add("last", time.Second, lastRun, lastStop)
+
+	err := lc.run(context.Background())
+	assert.EqualError(err, "failing failed: boom")
+	assert.Equal([]string{"last", "failing", "first"}, rec.stopped)
+}
+
+func TestLifecycle_RunStopped(t *testing.T) {
+	assert := testAssert.New(t)
+	rec := &recorder{}
+	lc := newLifecycle(logger.Nop())
+
+	run, stop := rec.blocking("server")
+	lc.add("server", time.Second, run, stop)
+	lc.add("worker", 0, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Nil(lc.run(ctx))
+	assert.Equal([]string{"server"}, rec.stopped)
+}
+
+func TestLifecycle_StopTimeouts(t *testing.T) {
+	assert := testAssert.New(t)
+	lc := newLifecycle(logger.Nop())
+
+	var slowErr, nextErr error
+	lc.add("next", time.Second, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, func(ctx context.Context) error {
+		nextErr = ctx.Err()
+		return nil
+	})
+	lc.add("slow", 10*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, func(ctx context.Context) error {
+		<-ctx.Done()
+		slowErr = ctx.Err()
+		return slowErr
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Nil(lc.run(ctx))
+	assert.ErrorIs(slowErr, context.DeadlineExceeded)
+	assert.Nil(nextErr, "a slow component doesn't take the time of the next one")
+}
+
+func TestExitCodes(t *testing.T) {
+	assert := testAssert.New(t)
+	prev, ok := os.LookupEnv("HTTP_PORT")
+	assert.Nil(os.Setenv("HTTP_PORT", "not a port"))
+	defer func() {
+		if ok {
+			_ = os.Setenv("HTTP_PORT", prev)
+		} else {
+			_ = os.Unsetenv("HTTP_PORT")
+		}
+	}()
+
+	assert.Equal(ExitConfig, Run())
+	assert.Equal(ExitConfig, Migrate(nil))
+	assert.Equal(ExitConfig, Migrate([]string{"sideways"}))
+	assert.Equal(ExitConfig, Migrate([]string{"to", "-1"}))
+	assert.Equal(ExitConfig, Migrate([]string{"up"}))
+}
+
+func TestMigrateCommand(t *testing.T) {
+	assert := testAssert.New(t)
+	tt := []struct {
+		name string
+		args []string
+		isOk bool
+	}{
+		{name: "no args"},
+		{name: "unknown command", args: []string{"sideways"}},
+		{name: "up with version", args: []string{"up", "1"}},
+		{name: "to without version", args: []string{"to"}},
+		{name: "to not a number", args: []string{"to", "one"}},
+		{name: "up", args: []string{"up"}, isOk: true},
+		{name: "down", args: []string{"down"}, isOk: true},
+		{name: "status", args: []string{"status"}, isOk: true},
+		{name: "to", args: []string{"to", "3"}, isOk: true},
+		{name: "to zero", args: []string{"to", "0"}, isOk: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			run, ok := migrateCommand(tc.args)
+			assert.Equal(tc.isOk, ok)
+			assert.Equal(tc.isOk, run != nil)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/config"
+	"github.com/JesusG2000/hexsatisfaction/pkg/database/migrate"
+	"github.com/JesusG2000/hexsatisfaction/pkg/database/pg"
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
+	"github.com/JesusG2000/hexsatisfaction/pkg/migrations"
+)
+
+const migrateUsage = "usage: migrate up|down|status|to N"
+
+// Migrate runs the migrate subcommand with args, which are up, down, status or to N,
+// and returns the exit code.
+func Migrate(args []string) int {
+	log := logger.Default()
+
+	run, ok := migrateCommand(args)
+	if !ok {
+		log.Error(migrateUsage)
+		return ExitConfig
+	}
+
+	cfg, err := config.Init()
+	if err != nil {
+		log.Error("init config", "error", err)
+		return ExitConfig
+	}
+
+	db, err := pg.NewPg(cfg.Pg)
+	if err != nil {
+		log.Error("init db", "error", err)
+		return ExitUnavailable
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Error("failed to close db", "error", err)
+		}
+	}()
+
+	migrator, err := migrate.NewMigrator(db, migrations.FS)
+	if err != nil {
+		log.Error("init migrations", "error", err)
+		return ExitConfig
+	}
+
+	ctx := context.Background()
+	if err := run(ctx, migrator); err != nil {
+		log.Error("migrate", "error", err)
+		return ExitFailure
+	}
+
+	if args[0] != "status" {
+		if err := printStatus(ctx, migrator); err != nil {
+			log.Error("migrate status", "error", err)
+			return ExitFailure
+		}
+	}
+
+	return ExitOK
+}
+
+// migrateCommand parses args of the migrate subcommand, it returns false if they aren't valid.
+func migrateCommand(args []string) (func(context.Context, *migrate.Migrator) error, bool) {
+	switch {
+	case len(args) == 1 && args[0] == "up":
+		return func(ctx context.Context, migrator *migrate.Migrator) error {
+			return migrator.Up(ctx)
+		}, true
+	case len(args) == 1 && args[0] == "down":
+		return func(ctx context.Context, migrator *migrate.Migrator) error {
+			return migrator.Down(ctx)
+		}, true
+	case len(args) == 1 && args[0] == "status":
+		return printStatus, true
+	case len(args) == 2 && args[0] == "to":
+		version, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return nil, false
+		}
+		return func(ctx context.Context, migrator *migrate.Migrator) error {
+			return migrator.To(ctx, uint(version))
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+func printStatus(ctx context.Context, migrator *migrate.Migrator) error {
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	dirty := ""
+	if status.Dirty {
+		dirty = " (dirty)"
+	}
+	fmt.Printf("version %d of %d%s\n", status.Current, status.Latest, dirty)
+
+	for _, m := range status.Migrations {
+		state := "pending"
+		if m.Version <= status.Current {
+			state = "applied"
+		}
+		fmt.Printf("%6d %-40s %s\n", m.Version, m.Name, state)
+	}
+
+	return nil
+}
+
+// checkSchema fails when some migrations aren't applied to the database.
+func checkSchema(ctx context.Context, migrator *migrate.Migrator) error {
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	if status.Dirty {
+		return fmt.Errorf("schema is dirty at version %d", status.Current)
+	}
+
+	if status.Behind() {
+		return fmt.Errorf("schema version %d is behind %d, run migrate up", status.Current, status.Latest)
+	}
+
+	return nil
+}
@@ -10,46 +10,120 @@ import (
 type (
 	// Config represents a structure with configs for this microservice.
 	Config struct {
-		Pg   PgConfig
-		Auth JWTConfig
-		HTTP HTTPConfig
-		GRPC GRPCConfig
+		Pg    PgConfig
+		Auth  JWTConfig
+		HTTP  HTTPConfig
+		GRPC  GRPCConfig
+		Hash  HashConfig
+		Trace TraceConfig
+		Log   LogConfig
+		Limit RateLimitConfig
+		Purge PurgeConfig
 	}
 	// PgConfig represents a structure with configs for pg database.
 	PgConfig struct {
 		URI             string
-		User            string `required:"true"`
-		Password        string `required:"true"`
-		Host            string `required:"true"`
-		Port            int    `required:"true"`
-		DatabaseName    string `split_words:"true" required:"true"`
-		DatabaseSslMode string `split_words:"true" required:"true"`
-		DatabaseDialect string `split_words:"true" required:"true"`
+		User            string        `required:"true"`
+		Password        string        `required:"true"`
+		Host            string        `required:"true"`
+		Port            int           `required:"true"`
+		DatabaseName    string        `split_words:"true" required:"true"`
+		DatabaseSslMode string        `split_words:"true" required:"true"`
+		DatabaseDialect string        `split_words:"true" required:"true"`
+		QueryTimeout    time.Duration `split_words:"true" default:"5s"`
 	}
 	// JWTConfig represents a structure with configs for jwt-token.
 	JWTConfig struct {
-		SigningKey string `split_words:"true" required:"true"`
+		SigningKey      string        `split_words:"true" required:"true"`
+		AccessTokenTTL  time.Duration `split_words:"true" default:"15m"`
+		RefreshTokenTTL time.Duration `split_words:"true" default:"720h"`
+	}
+	// HashConfig represents a structure with configs for password hashing.
+	HashConfig struct {
+		Algorithm    string `default:"bcrypt"`
+		BcryptCost   int    `split_words:"true" default:"10"`
+		ArgonTime    uint32 `split_words:"true" default:"1"`
+		ArgonMemory  uint32 `split_words:"true" default:"65536"`
+		ArgonThreads uint8  `split_words:"true" default:"4"`
+		ArgonKeyLen  uint32 `split_words:"true" default:"32"`
 	}
 	// HTTPConfig represents a structure with configs for http server.
+	// DrainDelay is how long the readiness probe fails before the shutdown, so the traffic drains.
+	// AdminPort serves metrics and health details, it mustn't be exposed to clients.
 	HTTPConfig struct {
 		Host           string        `required:"true"`
 		Port           int           `required:"true"`
+		AdminPort      int           `split_words:"true" default:"8081"`
 		MaxHeaderBytes int           `split_words:"true" required:"true"`
 		ReadTimeout    time.Duration `split_words:"true" required:"true"`
 		WriteTimeout   time.Duration `split_words:"true" required:"true"`
+		DrainDelay     time.Duration `split_words:"true" default:"0s"`
+	}
+	// TraceConfig represents a structure with configs for tracing.
+	// Exporter is otlp, stdout or none, Endpoint is the address of the OTLP collector,
+	// SampleRatio is the share of traces started by the service which are sampled.
+	TraceConfig struct {
+		Exporter    string  `default:"none"`
+		Endpoint    string  `default:"localhost:4317"`
+		Insecure    bool    `default:"true"`
+		SampleRatio float64 `split_words:"true" default:"1"`
+	}
+	// LogConfig represents a structure with configs for logging.
+	// Level is debug, info, warn or error, Format is json or text.
+	LogConfig struct {
+		Level  string `default:"info"`
+		Format string `default:"json"`
+	}
+	// RateLimitConfig represents a structure with configs for rate limiting.
+	// Backend is memory or pg, the pg one holds limits across replicas.
+	// IP limits are applied to unauthenticated requests per client address,
+	// client limits are applied per user or per service calling grpc.
+	// Every failed login of a user delays the next one by LoginBackoff doubled per failure,
+	// after LoginMaxFailures the user is locked for LoginLockout.
+	// Behind TrustedProxies, CIDRs separated by commas, client addresses are taken from X-Forwarded-For.
+	RateLimitConfig struct {
+		Backend          string        `default:"memory"`
+		IPPerMinute      int           `split_words:"true" default:"30"`
+		IPBurst          int           `split_words:"true" default:"10"`
+		ClientPerMinute  int           `split_words:"true" default:"600"`
+		ClientBurst      int           `split_words:"true" default:"100"`
+		SweepInterval    time.Duration `split_words:"true" default:"1m"`
+		LoginMaxFailures int           `split_words:"true" default:"5"`
+		LoginBackoff     time.Duration `split_words:"true" default:"1s"`
+		LoginLockout     time.Duration `split_words:"true" default:"15m"`
+		TrustedProxies   []string      `split_words:"true"`
+	}
+	// PurgeConfig represents a structure with configs for purging deleted rows.
+	// Deleted users and authors are hard deleted every Interval once they are older than Retention.
+	PurgeConfig struct {
+		Retention time.Duration `default:"720h"`
+		Interval  time.Duration `default:"1h"`
 	}
 	// GRPCConfig represents a structure with configs for grpc.
+	// ServiceTokens map names of services to their tokens like orders:token,
+	// AllowedMethods map names of callers to full methods separated by spaces like orders:/grpc.Existance/*,
+	// users authenticated by a JWT token are the user caller.
+	// HealthInterval is the period of database pings reported by the health service.
 	GRPCConfig struct {
-		Host string `required:"true"`
-		Port string `required:"true"`
+		Host           string            `required:"true"`
+		Port           string            `required:"true"`
+		ServiceTokens  map[string]string `split_words:"true"`
+		AllowedMethods map[string]string `split_words:"true"`
+		Reflection     bool              `default:"false"`
+		HealthInterval time.Duration     `split_words:"true" default:"10s"`
 	}
 )
 
 const (
-	PG   = "PG"
-	JWT  = "JWT"
-	HTTP = "HTTP"
-	GRPC = "GRPC"
+	PG    = "PG"
+	JWT   = "JWT"
+	HTTP  = "HTTP"
+	GRPC  = "GRPC"
+	HASH  = "HASH"
+	TRACE = "TRACE"
+	LOG   = "LOG"
+	LIMIT = "RATE_LIMIT"
+	PURGE = "PURGE"
 )
 
 // Init populates Config struct with values.
@@ -72,5 +146,53 @@ func Init() (*Config, error) {
 		return nil, errors.Wrap(err, "couldn't process grpc")
 	}
 
+	if err := envconfig.Process(HASH, &cfg.Hash); err != nil {
+		return nil, errors.Wrap(err, "couldn't process hash")
+	}
+
+	if err := envconfig.Process(TRACE, &cfg.Trace); err != nil {
+		return nil, errors.Wrap(err, "couldn't process trace")
+	}
+
+	if err := envconfig.Process(LOG, &cfg.Log); err != nil {
+		return nil, errors.Wrap(err, "couldn't process log")
+	}
+
+	if err := envconfig.Process(LIMIT, &cfg.Limit); err != nil {
+		return nil, errors.Wrap(err, "couldn't process rate limit")
+	}
+
+	if err := envconfig.Process(PURGE, &cfg.Purge); err != nil {
+		return nil, errors.Wrap(err, "couldn't process purge")
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
+
+// validate rejects values envconfig accepts but the service can't run with.
+func (c *Config) validate() error {
+	durations := []struct {
+		name  string
+		value time.Duration
+	}{
+		{PG + "_QUERY_TIMEOUT", c.Pg.QueryTimeout},
+		{GRPC + "_HEALTH_INTERVAL", c.GRPC.HealthInterval},
+		{LIMIT + "_SWEEP_INTERVAL", c.Limit.SweepInterval},
+		{LIMIT + "_LOGIN_BACKOFF", c.Limit.LoginBackoff},
+		{LIMIT + "_LOGIN_LOCKOUT", c.Limit.LoginLockout},
+		{PURGE + "_INTERVAL", c.Purge.Interval},
+		{PURGE + "_RETENTION", c.Purge.Retention},
+	}
+
+	for _, d := range durations {
+		if d.value <= 0 {
+			return errors.Errorf("%s must be positive, got %s", d.name, d.value)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	testAssert "github.com/stretchr/testify/assert"
+)
+
+func validConfig() Config {
+	return Config{
+		Pg:   PgConfig{QueryTimeout: 5 * time.Second},
+		GRPC: GRPCConfig{HealthInterval: 10 * time.Second},
+		Limit: RateLimitConfig{
+			SweepInterval: time.Minute,
+			LoginBackoff:  time.Second,
+			LoginLockout:  15 * time.Minute,
+		},
+		Purge: PurgeConfig{Retention: 720 * time.Hour, Interval: time.Hour},
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert := testAssert.New(t)
+	tt := []struct {
+		name   string
+		fn     func(cfg *Config)
+		expErr string
+	}{
+		{
+			name: "zero query timeout",
+			fn: func(cfg *Config) {
+				cfg.Pg.QueryTimeout = 0
+			},
+			expErr: "PG_QUERY_TIMEOUT must be positive, got 0s",
+		},
+		{
+			name: "negative query timeout",
+			fn: func(cfg *Config) {
+				cfg.Pg.QueryTimeout = -time.Second
+			},
+			expErr: "PG_QUERY_TIMEOUT must be positive, got -1s",
+		},
+		{
+			name: "zero health interval",
+			fn: func(cfg *Config) {
+				cfg.GRPC.HealthInterval = 0
+			},
+			expErr: "GRPC_HEALTH_INTERVAL must be positive, got 0s",
+		},
+		{
+			name: "zero sweep interval",
+			fn: func(cfg *Config) {
+				cfg.Limit.SweepInterval = 0
+			},
+			expErr: "RATE_LIMIT_SWEEP_INTERVAL must be positive, got 0s",
+		},
+		{
+			name: "zero login lockout",
+			fn: func(cfg *Config) {
+				cfg.Limit.LoginLockout = 0
+			},
+			expErr: "RATE_LIMIT_LOGIN_LOCKOUT must be positive, got 0s",
+		},
+		{
+			name: "zero purge interval",
+			fn: func(cfg *Config) {
+				cfg.Purge.Interval = 0
+			},
+			expErr: "PURGE_INTERVAL must be positive, got 0s",
+		},
+		{
+			name: "negative purge retention",
+			fn: func(cfg *Config) {
+				cfg.Purge.Retention = -time.Hour
+			},
+			expErr: "PURGE_RETENTION must be positive, got -1h0m0s",
+		},
+		{
+			name: "all ok",
+			fn:   func(cfg *Config) {},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validConfig()
+			tc.fn(&cfg)
+
+			err := cfg.validate()
+			if tc.expErr == "" {
+				assert.Nil(err)
+				return
+			}
+			assert.EqualError(err, tc.expErr)
+		})
+	}
+}
@@ -1,61 +1,87 @@
 package handler
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
-	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
+	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
 	"github.com/JesusG2000/hexsatisfaction/internal/service"
 	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/JesusG2000/hexsatisfaction/pkg/metrics"
 	"github.com/JesusG2000/hexsatisfaction/pkg/middleware"
+	"github.com/JesusG2000/hexsatisfaction/pkg/ratelimit"
+	"github.com/JesusG2000/hexsatisfaction/pkg/tracing"
 	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
 )
 
 type authorRouter struct {
 	*mux.Router
 	services     *service.Services
 	tokenManager auth.TokenManager
+	authorizer   *auth.Authorizer
 }
 
-func newAuthor(services *service.Services, tokenManager auth.TokenManager) authorRouter {
+func newAuthor(services *service.Services, tokenManager auth.TokenManager, authorizer *auth.Authorizer, limiters Limiters) authorRouter {
 	router := mux.NewRouter().PathPrefix(authorPath).Subrouter()
 	handler := authorRouter{
 		router,
 		services,
 		tokenManager,
+		authorizer,
 	}
+	router.Use(metrics.Route, tracing.Route, middleware.Route)
+
+	canWrite := handler.authorizer.RequirePermission(dto.AuthorWrite)
+	byIP := ratelimit.Middleware(limiters.Auth, limiters.Keys.ByIP)
+
+	router.Path("/search").
+		Methods(http.MethodGet).
+		Handler(byIP(http.HandlerFunc(handler.searchAuthor)))
 
 	router.Path("/{name}").
 		Methods(http.MethodGet).
-		HandlerFunc(handler.findByNameAuthor)
+		Handler(byIP(http.HandlerFunc(handler.findByNameAuthor)))
 
 	router.Path("/").
 		Methods(http.MethodGet).
-		HandlerFunc(handler.findAllAuthor)
+		Handler(byIP(http.HandlerFunc(handler.findAllAuthor)))
 
 	secure := router.PathPrefix("/api").Subrouter()
-	secure.Use(handler.tokenManager.UserIdentity)
+	secure.Use(handler.tokenManager.UserIdentity, ratelimit.Middleware(limiters.Client, limiters.Keys.ByUser))
 
 	secure.Path("/").
 		Methods(http.MethodPost).
-		HandlerFunc(handler.createAuthor)
+		Handler(canWrite(http.HandlerFunc(handler.createAuthor)))
 
 	secure.Path("/{id}").
 		Methods(http.MethodPut).
-		HandlerFunc(handler.updateAuthor)
+		Handler(canWrite(http.HandlerFunc(handler.updateAuthor)))
 
 	secure.Path("/{id}").
 		Methods(http.MethodDelete).
-		HandlerFunc(handler.deleteAuthor)
+		Handler(canWrite(http.HandlerFunc(handler.deleteAuthor)))
 
 	secure.Path("/{id}").
 		Methods(http.MethodGet).
 		HandlerFunc(handler.findByIDAuthor)
 
+	secure.Path("/{id}/restore").
+		Methods(http.MethodPost).
+		Handler(handler.authorizer.RequirePermission(dto.AuthorWriteAny)(http.HandlerFunc(handler.restoreAuthor)))
+
+	secure.Path("/{id}/primary").
+		Methods(http.MethodPut).
+		Handler(canWrite(http.HandlerFunc(handler.setPrimaryAuthor)))
+
+	secure.Path("/{id}/owner").
+		Methods(http.MethodPut).
+		Handler(canWrite(http.HandlerFunc(handler.transferAuthor)))
+
 	secure.Path("/user/{id}").
 		Methods(http.MethodGet).
 		HandlerFunc(handler.findByUserIDAuthor)
@@ -63,41 +89,30 @@ func newAuthor(services *service.Services, tokenManager auth.TokenManager) autho
 	return handler
 }
 
-type createAuthorRequest struct {
-	model.CreateAuthorRequest
+var (
+	errNoPrincipal = errors.New("no authenticated user")
+	errNotOwner    = domain.Errorf(domain.ErrForbidden, "author belongs to another user")
+)
+
+// canManage checks if the principal may manage author rows of the user.
+func (a *authorRouter) canManage(principal *auth.Principal, userID int) bool {
+	return principal.UserID == userID || a.authorizer.HasPermission(principal.Role, dto.AuthorWriteAny)
 }
 
-// Build builds request for create author.
-func (req *createAuthorRequest) Build(r *http.Request) error {
-	err := json.NewDecoder(r.Body).Decode(&req.CreateAuthorRequest)
+// checkOwner writes an error response and returns false if the author is missing or belongs to another user.
+func (a *authorRouter) checkOwner(w http.ResponseWriter, r *http.Request, principal *auth.Principal, id int) bool {
+	author, err := a.services.Author.FindByID(r.Context(), model.IDAuthorRequest{ID: id})
 	if err != nil {
-		return err
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return false
 	}
 
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			log.Printf("%v", err)
-		}
-	}(r.Body)
-
-	return nil
-}
-
-// Validate validates request for create author.
-func (req *createAuthorRequest) Validate() error {
-	switch {
-	case req.UserID < 1:
-		return fmt.Errorf("not correct user id")
-	case req.Age < 1:
-		return fmt.Errorf("not correct age")
-	case req.Name == "":
-		return fmt.Errorf("name is required")
-	case req.Description == "":
-		return fmt.Errorf("description is required")
-	default:
-		return nil
+	if !a.canManage(principal, author.UserID) {
+		middleware.JSONError(w, errNotOwner, http.StatusForbidden)
+		return false
 	}
+
+	return true
 }
 
 // @Summary Create
@@ -109,74 +124,38 @@ func (req *createAuthorRequest) Validate() error {
 // @Param comment body model.CreateAuthorRequest true "Author"
 // @Success 200 {string} string id
 // @Failure 400 {object} middleware.SwagError
+// @Failure 401 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 413 {object} middleware.SwagError
+// @Failure 429 {object} middleware.SwagError
 // @Failure 500 {object} middleware.SwagError
 // @Router /author/api/ [post]
 func (a *authorRouter) createAuthor(w http.ResponseWriter, r *http.Request) {
-	var req createAuthorRequest
+	var req model.CreateAuthorRequest
 	err := middleware.ParseRequest(r, &req)
 	if err != nil {
 		middleware.JSONError(w, err, http.StatusBadRequest)
 		return
 	}
 
-	id, err := a.services.Author.Create(req.CreateAuthorRequest)
-	if err != nil {
-		middleware.JSONError(w, err, http.StatusInternalServerError)
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		middleware.JSONError(w, errNoPrincipal, http.StatusUnauthorized)
 		return
 	}
 
-	middleware.JSONReturn(w, http.StatusOK, strconv.Itoa(id))
-}
-
-type updateAuthorRequest struct {
-	model.UpdateAuthorRequest
-}
-
-// Build builds request for update author.
-func (req *updateAuthorRequest) Build(r *http.Request) error {
-	err := json.NewDecoder(r.Body).Decode(&req.UpdateAuthorRequest)
-	if err != nil {
-		return err
-	}
-
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			log.Printf("%v", err)
-		}
-	}(r.Body)
-
-	vID, ok := mux.Vars(r)["id"]
-	if !ok {
-		return fmt.Errorf("no id")
+	if !a.canManage(principal, req.UserID) {
+		middleware.JSONError(w, errNotOwner, http.StatusForbidden)
+		return
 	}
 
-	id, err := strconv.Atoi(vID)
+	id, err := a.services.Author.Create(r.Context(), req)
 	if err != nil {
-		return err
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
 	}
 
-	req.ID = id
-
-	return nil
-}
-
-// Validate validates request for update author.
-func (req *updateAuthorRequest) Validate() error {
-	switch {
-	case req.ID < 1:
-		return fmt.Errorf("not correct id")
-	case req.UserID < 1:
-		return fmt.Errorf("not correct user id")
-	case req.Age < 1:
-		return fmt.Errorf("not correct age")
-	case req.Name == "":
-		return fmt.Errorf("name is required")
-	case req.Description == "":
-		return fmt.Errorf("description is required")
-	default:
-		return nil
-	}
+	middleware.JSONReturn(w, http.StatusOK, strconv.Itoa(id))
 }
 
 // @Summary Update
@@ -189,125 +168,117 @@ func (req *updateAuthorRequest) Validate() error {
 // @Param comment body model.UpdateAuthorRequest true "Author"
 // @Success 200 {string} string id
 // @Failure 400 {object} middleware.SwagError
-// @Failure 404 {object} middleware.SwagEmptyError "No author"
+// @Failure 401 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagError "No author"
+// @Failure 413 {object} middleware.SwagError
+// @Failure 429 {object} middleware.SwagError
 // @Failure 500 {object} middleware.SwagError
 // @Router /author/api/{id} [put]
 func (a *authorRouter) updateAuthor(w http.ResponseWriter, r *http.Request) {
-	var req updateAuthorRequest
+	var req model.UpdateAuthorRequest
 	err := middleware.ParseRequest(r, &req)
 	if err != nil {
 		middleware.JSONError(w, err, http.StatusBadRequest)
 		return
 	}
 
-	id, err := a.services.Author.Update(req.UpdateAuthorRequest)
-	if err != nil {
-		middleware.JSONError(w, err, http.StatusInternalServerError)
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		middleware.JSONError(w, errNoPrincipal, http.StatusUnauthorized)
 		return
 	}
 
-	if id < 1 {
-		middleware.Empty(w, http.StatusNotFound)
+	if !a.canManage(principal, req.UserID) {
+		middleware.JSONError(w, errNotOwner, http.StatusForbidden)
 		return
 	}
 
-	middleware.JSONReturn(w, http.StatusOK, strconv.Itoa(id))
-}
-
-type deleteAuthorRequest struct {
-	model.DeleteAuthorRequest
-}
-
-// Build builds request for delete author.
-func (req *deleteAuthorRequest) Build(r *http.Request) error {
-	vID, ok := mux.Vars(r)["id"]
-	if !ok {
-		return fmt.Errorf("no id")
+	if !a.checkOwner(w, r, principal, req.ID) {
+		return
 	}
 
-	id, err := strconv.Atoi(vID)
+	id, err := a.services.Author.Update(r.Context(), req)
 	if err != nil {
-		return err
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
 	}
 
-	req.ID = id
-
-	return nil
-}
-
-// Validate validates request for delete author.
-func (req *deleteAuthorRequest) Validate() error {
-	switch {
-	case req.ID < 1:
-		return fmt.Errorf("not correct id")
-	default:
-		return nil
-	}
+	middleware.JSONReturn(w, http.StatusOK, strconv.Itoa(id))
 }
 
 // @Summary Delete
 // @Security ApiKeyAuth
 // @Tags author
-// @Description Delete author
+// @Description Soft delete author
 // @Accept  json
 // @Produce  json
 // @Param id path int true "Author id"
 // @Success 200 {string} string id
 // @Failure 400 {object} middleware.SwagError
-// @Failure 404 {object} middleware.SwagEmptyError "No author"
+// @Failure 401 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagError "No author"
+// @Failure 429 {object} middleware.SwagError
 // @Failure 500 {object} middleware.SwagError
 // @Router /author/api/{id} [delete]
 func (a *authorRouter) deleteAuthor(w http.ResponseWriter, r *http.Request) {
-	var req deleteAuthorRequest
+	var req model.DeleteAuthorRequest
 	err := middleware.ParseRequest(r, &req)
 	if err != nil {
 		middleware.JSONError(w, err, http.StatusBadRequest)
 		return
 	}
 
-	id, err := a.services.Author.Delete(req.DeleteAuthorRequest)
-	if err != nil {
-		middleware.JSONError(w, err, http.StatusInternalServerError)
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		middleware.JSONError(w, errNoPrincipal, http.StatusUnauthorized)
 		return
 	}
 
-	if id < 1 {
-		middleware.Empty(w, http.StatusNotFound)
+	if !a.checkOwner(w, r, principal, req.ID) {
 		return
 	}
 
-	middleware.JSONReturn(w, http.StatusOK, strconv.Itoa(id))
-}
+	id, err := a.services.Author.Delete(r.Context(), req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
+	}
 
-type idAuthorRequest struct {
-	model.IDAuthorRequest
+	middleware.JSONReturn(w, http.StatusOK, strconv.Itoa(id))
 }
 
-// Build builds request to find author by id.
-func (req *idAuthorRequest) Build(r *http.Request) error {
-	vID, ok := mux.Vars(r)["id"]
-	if !ok {
-		return fmt.Errorf("no id")
+// @Summary Restore
+// @Security ApiKeyAuth
+// @Tags author
+// @Description Restore deleted author, authors of deleted users can't be restored
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Author id"
+// @Success 200 {string} string id
+// @Failure 400 {object} middleware.SwagError
+// @Failure 401 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagError "No deleted author"
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /author/api/{id}/restore [post]
+func (a *authorRouter) restoreAuthor(w http.ResponseWriter, r *http.Request) {
+	var req model.IDAuthorRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
+		return
 	}
 
-	id, err := strconv.Atoi(vID)
+	id, err := a.services.Author.Restore(r.Context(), req)
 	if err != nil {
-		return err
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
 	}
 
-	req.ID = id
-
-	return nil
-}
-
-// Validate validates request to find author by id.
-func (req *idAuthorRequest) Validate() error {
-	switch {
-	case req.ID < 1:
-		return fmt.Errorf("not correct id")
-	default:
-		return nil
-	}
+	middleware.JSONReturn(w, http.StatusOK, strconv.Itoa(id))
 }
 
 // @Summary FindByID
@@ -319,72 +290,139 @@ func (req *idAuthorRequest) Validate() error {
 // @Param id path int true "Author id"
 // @Success 200 {object} model.Author
 // @Failure 400 {object} middleware.SwagError
-// @Failure 404 {object} middleware.SwagEmptyError "No author"
+// @Failure 404 {object} middleware.SwagError "No author"
+// @Failure 429 {object} middleware.SwagError
 // @Failure 500 {object} middleware.SwagError
 // @Router /author/api/{id} [get]
 func (a *authorRouter) findByIDAuthor(w http.ResponseWriter, r *http.Request) {
-	var req idAuthorRequest
+	var req model.IDAuthorRequest
 	err := middleware.ParseRequest(r, &req)
 	if err != nil {
 		middleware.JSONError(w, err, http.StatusBadRequest)
 		return
 	}
 
-	author, err := a.services.Author.FindByID(req.IDAuthorRequest)
+	author, err := a.services.Author.FindByID(r.Context(), req)
 	if err != nil {
 		middleware.JSONError(w, err, http.StatusInternalServerError)
 		return
 	}
 
-	if author.ID < 1 {
-		middleware.Empty(w, http.StatusNotFound)
+	middleware.JSONReturn(w, http.StatusOK, author)
+}
+
+// @Summary SetPrimary
+// @Security ApiKeyAuth
+// @Tags author
+// @Description Mark author as primary for its user, the previous primary author is unmarked
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Author id"
+// @Success 200 {string} string id
+// @Failure 400 {object} middleware.SwagError
+// @Failure 401 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagError "No author"
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /author/api/{id}/primary [put]
+func (a *authorRouter) setPrimaryAuthor(w http.ResponseWriter, r *http.Request) {
+	var req model.SetPrimaryAuthorRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
 		return
 	}
 
-	middleware.JSONReturn(w, http.StatusOK, author)
-}
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		middleware.JSONError(w, errNoPrincipal, http.StatusUnauthorized)
+		return
+	}
 
-type userIDAuthorRequest struct {
-	model.UserIDAuthorRequest
+	if !a.checkOwner(w, r, principal, req.ID) {
+		return
+	}
+
+	id, err := a.services.Author.SetPrimary(r.Context(), req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	middleware.JSONReturn(w, http.StatusOK, strconv.Itoa(id))
 }
 
-// Build builds request to find author by user id.
-func (req *userIDAuthorRequest) Build(r *http.Request) error {
-	vID, ok := mux.Vars(r)["id"]
+// @Summary Transfer
+// @Security ApiKeyAuth
+// @Tags author
+// @Description Transfer author to another user, the author stops being primary
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Author id"
+// @Param comment body model.TransferAuthorRequest true "New owner"
+// @Success 200 {string} string id
+// @Failure 400 {object} middleware.SwagError
+// @Failure 401 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagError "No author"
+// @Failure 413 {object} middleware.SwagError
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /author/api/{id}/owner [put]
+func (a *authorRouter) transferAuthor(w http.ResponseWriter, r *http.Request) {
+	var req model.TransferAuthorRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	principal, ok := auth.PrincipalFromContext(r.Context())
 	if !ok {
-		return fmt.Errorf("no id")
+		middleware.JSONError(w, errNoPrincipal, http.StatusUnauthorized)
+		return
+	}
+
+	if !a.checkOwner(w, r, principal, req.ID) {
+		return
 	}
 
-	id, err := strconv.Atoi(vID)
+	id, err := a.services.Author.Transfer(r.Context(), req)
 	if err != nil {
-		return err
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
 	}
 
-	req.ID = id
+	middleware.JSONReturn(w, http.StatusOK, strconv.Itoa(id))
+}
 
-	return nil
+type userIDAuthorRequest struct {
+	model.UserIDAuthorRequest
 }
 
-// Validate validates request to find author by user id.
+// Validate validates request to find a page of authors by user id.
 func (req *userIDAuthorRequest) Validate() error {
-	switch {
-	case req.ID < 1:
-		return fmt.Errorf("not correct id")
-	default:
-		return nil
-	}
+	return listAuthorsErrors(req.ListAuthorsRequest).Err()
 }
 
 // @Summary FindByUserID
 // @Security ApiKeyAuth
 // @Tags author
-// @Description Find author by user id
+// @Description Find a page of authors owned by the user
 // @Accept  json
 // @Produce  json
 // @Param id path int true "User id"
-// @Success 200 {object} model.Author
+// @Param limit query int false "Page size"
+// @Param after query string false "Cursor of the next page"
+// @Param sort query string false "Sort field" Enums(id, name, age)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Param minAge query int false "Min age"
+// @Param maxAge query int false "Max age"
+// @Success 200 {object} model.AuthorPage
 // @Failure 400 {object} middleware.SwagError
-// @Failure 404 {object} middleware.SwagEmptyError "No author"
+// @Failure 404 {object} middleware.SwagEmptyError "No authors"
+// @Failure 429 {object} middleware.SwagError
 // @Failure 500 {object} middleware.SwagError
 // @Router /author/api/user/{id} [get]
 func (a *authorRouter) findByUserIDAuthor(w http.ResponseWriter, r *http.Request) {
@@ -395,55 +433,117 @@ func (a *authorRouter) findByUserIDAuthor(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	author, err := a.services.Author.FindByUserID(req.UserIDAuthorRequest)
+	page, err := a.services.Author.FindByUserID(r.Context(), req.UserIDAuthorRequest)
 	if err != nil {
 		middleware.JSONError(w, err, http.StatusInternalServerError)
 		return
 	}
 
-	if author.ID < 1 {
+	if page.Total == 0 {
 		middleware.Empty(w, http.StatusNotFound)
 		return
 	}
 
-	middleware.JSONReturn(w, http.StatusOK, author)
+	middleware.JSONReturn(w, http.StatusOK, page)
 }
 
-type nameAuthorRequest struct {
-	model.NameAuthorRequest
+// listAuthorsErrors collects errors of paging, sorting and filtering options.
+func listAuthorsErrors(req model.ListAuthorsRequest) domain.FieldErrors {
+	errs := make(domain.FieldErrors)
+	errs.Check(req.Limit >= 1 && req.Limit <= maxPageLimit, "limit", fmt.Sprintf("limit must be between 1 and %d", maxPageLimit))
+	errs.Check(req.SortBy == model.AuthorSortID || req.SortBy == model.AuthorSortName || req.SortBy == model.AuthorSortAge, "sort", "not correct sort")
+	errs.Check(req.Order == model.OrderAsc || req.Order == model.OrderDesc, "order", "not correct order")
+	errs.Check(req.MinAge >= 0, "minAge", "not correct age")
+	errs.Check(req.MaxAge >= 0, "maxAge", "not correct age")
+	errs.Check(req.MaxAge == 0 || req.MinAge <= req.MaxAge, "minAge", "min age is greater than max age")
+	errs.Check(req.UserID >= 0, "userID", "not correct user id")
+	errs.Check(req.After == nil || (req.After.SortBy == req.SortBy && req.After.Desc == req.Descending()), "after", "cursor doesn't match sort order")
+
+	return errs
 }
 
-// Build builds request to find authors by name.
-func (req *nameAuthorRequest) Build(r *http.Request) error {
-	name, ok := mux.Vars(r)["name"]
-	if !ok {
-		return fmt.Errorf("no id")
+type listAuthorsRequest struct {
+	model.ListAuthorsRequest
+}
+
+// Validate validates request to find a page of authors.
+func (req *listAuthorsRequest) Validate() error {
+	return listAuthorsErrors(req.ListAuthorsRequest).Err()
+}
+
+type searchAuthorRequest struct {
+	model.SearchAuthorRequest
+}
+
+// Build builds request to search authors.
+func (req *searchAuthorRequest) Build(r *http.Request) error {
+	err := middleware.Bind(r, &req.SearchAuthorRequest)
+	req.Query = strings.TrimSpace(req.Query)
+
+	return err
+}
+
+// @Summary Search
+// @Tags author
+// @Description Search authors by name and description, names with typos are matched too
+// @Accept  json
+// @Produce  json
+// @Param q query string true "Search query"
+// @Param limit query int false "Max number of authors"
+// @Success 200 {array} model.AuthorMatch
+// @Failure 400 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagEmptyError "No authors"
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /author/search [get]
+func (a *authorRouter) searchAuthor(w http.ResponseWriter, r *http.Request) {
+	var req searchAuthorRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	matches, err := a.services.Author.Search(r.Context(), req.SearchAuthorRequest)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
 	}
 
-	req.Name = name
+	if len(matches) == 0 {
+		middleware.Empty(w, http.StatusNotFound)
+		return
+	}
 
-	return nil
+	middleware.JSONReturn(w, http.StatusOK, matches)
+}
+
+type nameAuthorRequest struct {
+	model.NameAuthorRequest
 }
 
 // Validate validates request to find authors by name.
 func (req *nameAuthorRequest) Validate() error {
-	switch {
-	case req.Name == "":
-		return fmt.Errorf("name is required")
-	default:
-		return nil
-	}
+	return listAuthorsErrors(req.ListAuthorsRequest).Err()
 }
 
 // @Summary FindByName
 // @Tags author
-// @Description Find authors by name
+// @Description Find a page of authors by name
 // @Accept  json
 // @Produce  json
 // @Param name path string true "Author name"
-// @Success 200 {array} model.Author
+// @Param limit query int false "Page size"
+// @Param after query string false "Cursor of the next page"
+// @Param sort query string false "Sort field" Enums(id, name, age)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Param minAge query int false "Min age"
+// @Param maxAge query int false "Max age"
+// @Param userID query int false "User id"
+// @Success 200 {object} model.AuthorPage
 // @Failure 400 {object} middleware.SwagError
 // @Failure 404 {object} middleware.SwagEmptyError "No authors"
+// @Failure 429 {object} middleware.SwagError
 // @Failure 500 {object} middleware.SwagError
 // @Router /author/{name} [get]
 func (a *authorRouter) findByNameAuthor(w http.ResponseWriter, r *http.Request) {
@@ -454,41 +554,56 @@ func (a *authorRouter) findByNameAuthor(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	authors, err := a.services.Author.FindByName(req.NameAuthorRequest)
+	page, err := a.services.Author.FindByName(r.Context(), req.NameAuthorRequest)
 	if err != nil {
 		middleware.JSONError(w, err, http.StatusInternalServerError)
 		return
 	}
 
-	if len(authors) == 0 {
+	if page.Total == 0 {
 		middleware.Empty(w, http.StatusNotFound)
 		return
 	}
 
-	middleware.JSONReturn(w, http.StatusOK, authors)
+	middleware.JSONReturn(w, http.StatusOK, page)
 }
 
 // @Summary FindAll
 // @Tags author
-// @Description Find authors
+// @Description Find a page of authors
 // @Accept  json
 // @Produce  json
-// @Success 200 {array} model.Author
+// @Param limit query int false "Page size"
+// @Param after query string false "Cursor of the next page"
+// @Param sort query string false "Sort field" Enums(id, name, age)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Param minAge query int false "Min age"
+// @Param maxAge query int false "Max age"
+// @Param userID query int false "User id"
+// @Success 200 {object} model.AuthorPage
 // @Failure 400 {object} middleware.SwagError
 // @Failure 404 {object} middleware.SwagEmptyError "No authors"
+// @Failure 429 {object} middleware.SwagError
 // @Failure 500 {object} middleware.SwagError
 // @Router /author/ [get]
 func (a *authorRouter) findAllAuthor(w http.ResponseWriter, r *http.Request) {
-	authors, err := a.services.Author.FindAll()
+	var req listAuthorsRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	page, err := a.services.Author.FindAll(r.Context(), req.ListAuthorsRequest)
 	if err != nil {
 		middleware.JSONError(w, err, http.StatusInternalServerError)
 		return
 	}
 
-	if len(authors) == 0 {
+	if page.Total == 0 {
 		middleware.Empty(w, http.StatusNotFound)
 		return
 	}
 
-	middleware.JSONReturn(w, http.StatusOK, authors)
+	middleware.JSONReturn(w, http.StatusOK, page)
 }
@@ -6,24 +6,32 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
 	m "github.com/JesusG2000/hexsatisfaction/internal/handler/mock"
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
+	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
 	"github.com/JesusG2000/hexsatisfaction/internal/service"
+	"github.com/JesusG2000/hexsatisfaction/pkg/cursor"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/JesusG2000/hexsatisfaction/pkg/ratelimit"
 	"github.com/pkg/errors"
 	testAssert "github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
-const author = "author"
+const (
+	author           = "author"
+	defaultPageLimit = 20
+)
 
 func TestAuthor_Create(t *testing.T) {
 	assert := testAssert.New(t)
 	testAPI, err := service.InitTest4Mock()
 	require.NoError(t, err)
-	token, err := testAPI.TokenManager.NewJWT(mock.Anything)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.USER, 0)
 	require.NoError(t, err)
 
 	type test struct {
@@ -48,11 +56,45 @@ func TestAuthor_Create(t *testing.T) {
 				UserID:      0,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("Create", data.req).
+				authorService.On("Create", mock.Anything, data.req).
 					Return(0, nil)
 			},
 			expCode: http.StatusBadRequest,
-			expBody: "not correct user id",
+			expBody: "userID must be at least 1",
+		},
+		{
+			name:    "invalid fields",
+			path:    slash + author + slash + api + slash,
+			method:  http.MethodPost,
+			req:     model.CreateAuthorRequest{},
+			expCode: http.StatusBadRequest,
+			expBody: "age must be at least 1; description is required; name is required; userID must be at least 1",
+		},
+		{
+			name:   "too large body",
+			path:   slash + author + slash + api + slash,
+			method: http.MethodPost,
+			req: model.CreateAuthorRequest{
+				Name:        "some",
+				Age:         1,
+				Description: strings.Repeat("some", 1<<18),
+				UserID:      1,
+			},
+			expCode: http.StatusRequestEntityTooLarge,
+			expBody: "request body is too large",
+		},
+		{
+			name:   "another user",
+			path:   slash + author + slash + api + slash,
+			method: http.MethodPost,
+			req: model.CreateAuthorRequest{
+				Name:        "some",
+				Age:         1,
+				Description: "some",
+				UserID:      2,
+			},
+			expCode: http.StatusForbidden,
+			expBody: "author belongs to another user",
 		},
 		{
 			name:   "create err",
@@ -65,10 +107,11 @@ func TestAuthor_Create(t *testing.T) {
 				UserID:      1,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("Create", data.req).
+				authorService.On("Create", mock.Anything, data.req).
 					Return(0, errors.New(""))
 			},
 			expCode: http.StatusInternalServerError,
+			expBody: http.StatusText(http.StatusInternalServerError),
 		},
 		{
 			name:   "all ok",
@@ -81,7 +124,7 @@ func TestAuthor_Create(t *testing.T) {
 				UserID:      1,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("Create", data.req).
+				authorService.On("Create", mock.Anything, data.req).
 					Return(15, nil)
 			},
 			expCode: http.StatusOK,
@@ -93,7 +136,7 @@ func TestAuthor_Create(t *testing.T) {
 			var r string
 			author := new(m.Author)
 			testAPI.Services.Author = author
-			router := newAuthor(testAPI.Services, testAPI.TokenManager)
+			router := newAuthor(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
 			if tc.fn != nil {
 				tc.fn(author, tc)
 			}
@@ -111,7 +154,7 @@ func TestAuthor_Create(t *testing.T) {
 			router.ServeHTTP(res, req)
 			assert.Equal(tc.expCode, res.Code)
 
-			err = json.NewDecoder(res.Body).Decode(&r)
+			r, err = decodeBody(res)
 			assert.Nil(err)
 			assert.Equal(tc.expBody, r)
 		})
@@ -122,7 +165,7 @@ func TestAuthor_Update(t *testing.T) {
 	assert := testAssert.New(t)
 	testAPI, err := service.InitTest4Mock()
 	require.NoError(t, err)
-	token, err := testAPI.TokenManager.NewJWT(mock.Anything)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.USER, 0)
 	require.NoError(t, err)
 
 	type test struct {
@@ -150,11 +193,11 @@ func TestAuthor_Update(t *testing.T) {
 				UserID:      1,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("Update", data.req).
+				authorService.On("Update", mock.Anything, data.req).
 					Return(0, nil)
 			},
 			expCode: http.StatusBadRequest,
-			expBody: "not correct id",
+			expBody: "id must be at least 1",
 		},
 		{
 			name:    "update err",
@@ -169,10 +212,13 @@ func TestAuthor_Update(t *testing.T) {
 				UserID:      1,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("Update", data.req).
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(&model.Author{ID: data.req.ID, UserID: 1}, nil)
+				authorService.On("Update", mock.Anything, data.req).
 					Return(0, errors.New(""))
 			},
 			expCode: http.StatusInternalServerError,
+			expBody: http.StatusText(http.StatusInternalServerError),
 		},
 		{
 			name:   "not found",
@@ -186,11 +232,45 @@ func TestAuthor_Update(t *testing.T) {
 				UserID:      1,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("Update", data.req).
-					Return(0, nil)
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(nil, domain.Errorf(domain.ErrNotFound, "author not found"))
 			},
 			expCode: http.StatusNotFound,
 		},
+		{
+			name:    "another user in body",
+			path:    slash + author + slash + api + slash,
+			method:  http.MethodPut,
+			isOkRes: true,
+			req: model.UpdateAuthorRequest{
+				ID:          1,
+				Name:        "some",
+				Age:         1,
+				Description: "some",
+				UserID:      2,
+			},
+			expCode: http.StatusForbidden,
+			expBody: "author belongs to another user",
+		},
+		{
+			name:    "author of another user",
+			path:    slash + author + slash + api + slash,
+			method:  http.MethodPut,
+			isOkRes: true,
+			req: model.UpdateAuthorRequest{
+				ID:          1,
+				Name:        "some",
+				Age:         1,
+				Description: "some",
+				UserID:      1,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(&model.Author{ID: data.req.ID, UserID: 2}, nil)
+			},
+			expCode: http.StatusForbidden,
+			expBody: "author belongs to another user",
+		},
 		{
 			name:    "all ok",
 			path:    slash + author + slash + api + slash,
@@ -204,7 +284,9 @@ func TestAuthor_Update(t *testing.T) {
 				UserID:      1,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("Update", data.req).
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(&model.Author{ID: data.req.ID, UserID: 1}, nil)
+				authorService.On("Update", mock.Anything, data.req).
 					Return(data.req.ID, nil)
 			},
 			expCode: http.StatusOK,
@@ -216,7 +298,7 @@ func TestAuthor_Update(t *testing.T) {
 			var r string
 			author := new(m.Author)
 			testAPI.Services.Author = author
-			router := newAuthor(testAPI.Services, testAPI.TokenManager)
+			router := newAuthor(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
 			if tc.fn != nil {
 				tc.fn(author, tc)
 			}
@@ -235,7 +317,7 @@ func TestAuthor_Update(t *testing.T) {
 			assert.Equal(tc.expCode, res.Code)
 
 			if tc.isOkRes {
-				err = json.NewDecoder(res.Body).Decode(&r)
+				r, err = decodeBody(res)
 				assert.Nil(err)
 			}
 			assert.Equal(tc.expBody, r)
@@ -247,7 +329,9 @@ func TestAuthor_Delete(t *testing.T) {
 	assert := testAssert.New(t)
 	testAPI, err := service.InitTest4Mock()
 	require.NoError(t, err)
-	token, err := testAPI.TokenManager.NewJWT(mock.Anything)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.USER, 0)
+	require.NoError(t, err)
+	adminToken, err := testAPI.TokenManager.NewJWT(2, dto.ADMIN, 0)
 	require.NoError(t, err)
 
 	type test struct {
@@ -255,6 +339,7 @@ func TestAuthor_Delete(t *testing.T) {
 		path    string
 		method  string
 		isOkRes bool
+		isAdmin bool
 		req     model.DeleteAuthorRequest
 		fn      func(authorService *m.Author, data test)
 		expCode int
@@ -271,11 +356,11 @@ func TestAuthor_Delete(t *testing.T) {
 				ID: 0,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("Delete", data.req).
+				authorService.On("Delete", mock.Anything, data.req).
 					Return(0, nil)
 			},
 			expCode: http.StatusBadRequest,
-			expBody: "not correct id",
+			expBody: "id must be at least 1",
 		},
 		{
 			name:    "delete err",
@@ -286,10 +371,13 @@ func TestAuthor_Delete(t *testing.T) {
 				ID: 1,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("Delete", data.req).
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(&model.Author{ID: data.req.ID, UserID: 1}, nil)
+				authorService.On("Delete", mock.Anything, data.req).
 					Return(0, errors.New(""))
 			},
 			expCode: http.StatusInternalServerError,
+			expBody: http.StatusText(http.StatusInternalServerError),
 		},
 		{
 			name:   "not found",
@@ -299,11 +387,44 @@ func TestAuthor_Delete(t *testing.T) {
 				ID: 1,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("Delete", data.req).
-					Return(0, nil)
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(nil, domain.Errorf(domain.ErrNotFound, "author not found"))
 			},
 			expCode: http.StatusNotFound,
 		},
+		{
+			name:    "author of another user",
+			path:    slash + author + slash + api + slash,
+			method:  http.MethodDelete,
+			isOkRes: true,
+			req: model.DeleteAuthorRequest{
+				ID: 1,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(&model.Author{ID: data.req.ID, UserID: 2}, nil)
+			},
+			expCode: http.StatusForbidden,
+			expBody: "author belongs to another user",
+		},
+		{
+			name:    "admin deletes author of another user",
+			path:    slash + author + slash + api + slash,
+			method:  http.MethodDelete,
+			isOkRes: true,
+			isAdmin: true,
+			req: model.DeleteAuthorRequest{
+				ID: 15,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(&model.Author{ID: data.req.ID, UserID: 1}, nil)
+				authorService.On("Delete", mock.Anything, data.req).
+					Return(data.req.ID, nil)
+			},
+			expCode: http.StatusOK,
+			expBody: strconv.Itoa(15),
+		},
 		{
 			name:    "all ok",
 			path:    slash + author + slash + api + slash,
@@ -313,7 +434,9 @@ func TestAuthor_Delete(t *testing.T) {
 				ID: 15,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("Delete", data.req).
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(&model.Author{ID: data.req.ID, UserID: 1}, nil)
+				authorService.On("Delete", mock.Anything, data.req).
 					Return(data.req.ID, nil)
 			},
 			expCode: http.StatusOK,
@@ -325,7 +448,7 @@ func TestAuthor_Delete(t *testing.T) {
 			var r string
 			author := new(m.Author)
 			testAPI.Services.Author = author
-			router := newAuthor(testAPI.Services, testAPI.TokenManager)
+			router := newAuthor(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
 			if tc.fn != nil {
 				tc.fn(author, tc)
 			}
@@ -333,14 +456,113 @@ func TestAuthor_Delete(t *testing.T) {
 			req, err := http.NewRequest(tc.method, tc.path+strconv.Itoa(tc.req.ID), nil)
 			assert.Nil(err)
 
-			req.Header.Set(authorizationHeader, "Bearer "+token)
+			if tc.isAdmin {
+				req.Header.Set(authorizationHeader, "Bearer "+adminToken)
+			} else {
+				req.Header.Set(authorizationHeader, "Bearer "+token)
+			}
 
 			res := httptest.NewRecorder()
 			router.ServeHTTP(res, req)
 			assert.Equal(tc.expCode, res.Code)
 
 			if tc.isOkRes {
-				err = json.NewDecoder(res.Body).Decode(&r)
+				r, err = decodeBody(res)
+				assert.Nil(err)
+			}
+			assert.Equal(tc.expBody, r)
+		})
+	}
+}
+
+func TestAuthor_Restore(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.USER, 0)
+	require.NoError(t, err)
+	adminToken, err := testAPI.TokenManager.NewJWT(2, dto.ADMIN, 0)
+	require.NoError(t, err)
+
+	type test struct {
+		name    string
+		isAdmin bool
+		req     model.IDAuthorRequest
+		fn      func(authorService *m.Author, data test)
+		expCode int
+		expBody string
+	}
+
+	tt := []test{
+		{
+			name: "not admin",
+			req: model.IDAuthorRequest{
+				ID: 15,
+			},
+			expCode: http.StatusForbidden,
+			expBody: "permission denied",
+		},
+		{
+			name:    "invalid author id",
+			isAdmin: true,
+			req: model.IDAuthorRequest{
+				ID: 0,
+			},
+			expCode: http.StatusBadRequest,
+			expBody: "id must be at least 1",
+		},
+		{
+			name:    "not found",
+			isAdmin: true,
+			req: model.IDAuthorRequest{
+				ID: 15,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("Restore", mock.Anything, data.req).
+					Return(0, domain.Errorf(domain.ErrNotFound, "author not found"))
+			},
+			expCode: http.StatusNotFound,
+		},
+		{
+			name:    "all ok",
+			isAdmin: true,
+			req: model.IDAuthorRequest{
+				ID: 15,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("Restore", mock.Anything, data.req).
+					Return(data.req.ID, nil)
+			},
+			expCode: http.StatusOK,
+			expBody: strconv.Itoa(15),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var r string
+			authorService := new(m.Author)
+			testAPI.Services.Author = authorService
+			router := newAuthor(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(authorService, tc)
+			}
+
+			path := slash + author + slash + api + slash + strconv.Itoa(tc.req.ID) + slash + "restore"
+			req, err := http.NewRequest(http.MethodPost, path, nil)
+			assert.Nil(err)
+
+			if tc.isAdmin {
+				req.Header.Set(authorizationHeader, "Bearer "+adminToken)
+			} else {
+				req.Header.Set(authorizationHeader, "Bearer "+token)
+			}
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+
+			if tc.expCode != http.StatusNotFound {
+				r, err = decodeBody(res)
 				assert.Nil(err)
 			}
 			assert.Equal(tc.expBody, r)
@@ -352,7 +574,7 @@ func TestAuthor_FindByID(t *testing.T) {
 	assert := testAssert.New(t)
 	testAPI, err := service.InitTest4Mock()
 	require.NoError(t, err)
-	token, err := testAPI.TokenManager.NewJWT(mock.Anything)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.USER, 0)
 	require.NoError(t, err)
 
 	type test struct {
@@ -378,11 +600,11 @@ func TestAuthor_FindByID(t *testing.T) {
 				ID: 0,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("FindByID", data.req).
+				authorService.On("FindByID", mock.Anything, data.req).
 					Return(&data.expRes, nil)
 			},
 			expCode: http.StatusBadRequest,
-			message: "not correct id",
+			message: "id must be at least 1",
 		},
 		{
 			name:        "find err",
@@ -393,10 +615,11 @@ func TestAuthor_FindByID(t *testing.T) {
 				ID: 1,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("FindByID", data.req).
+				authorService.On("FindByID", mock.Anything, data.req).
 					Return(&data.expRes, errors.New(""))
 			},
 			expCode: http.StatusInternalServerError,
+			message: http.StatusText(http.StatusInternalServerError),
 		},
 		{
 			name:   "not found",
@@ -406,8 +629,8 @@ func TestAuthor_FindByID(t *testing.T) {
 				ID: 1,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("FindByID", data.req).
-					Return(&data.expRes, nil)
+				authorService.On("FindByID", mock.Anything, data.req).
+					Return(nil, domain.Errorf(domain.ErrNotFound, "author not found"))
 			},
 			expCode: http.StatusNotFound,
 		},
@@ -420,7 +643,7 @@ func TestAuthor_FindByID(t *testing.T) {
 				ID: 15,
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("FindByID", data.req).
+				authorService.On("FindByID", mock.Anything, data.req).
 					Return(&data.expRes, nil)
 			},
 			expCode: http.StatusOK,
@@ -439,7 +662,7 @@ func TestAuthor_FindByID(t *testing.T) {
 			var a model.Author
 			author := new(m.Author)
 			testAPI.Services.Author = author
-			router := newAuthor(testAPI.Services, testAPI.TokenManager)
+			router := newAuthor(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
 			if tc.fn != nil {
 				tc.fn(author, tc)
 			}
@@ -455,7 +678,7 @@ func TestAuthor_FindByID(t *testing.T) {
 
 			switch {
 			case tc.isOkMessage:
-				err = json.NewDecoder(res.Body).Decode(&r)
+				r, err = decodeBody(res)
 				assert.Nil(err)
 				assert.Equal(tc.message, r)
 			case tc.isOkRes:
@@ -469,103 +692,366 @@ func TestAuthor_FindByID(t *testing.T) {
 	}
 }
 
+func TestAuthor_SetPrimary(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.USER, 0)
+	require.NoError(t, err)
+	adminToken, err := testAPI.TokenManager.NewJWT(2, dto.ADMIN, 0)
+	require.NoError(t, err)
+
+	type test struct {
+		name    string
+		isOkRes bool
+		isAdmin bool
+		req     model.SetPrimaryAuthorRequest
+		fn      func(authorService *m.Author, data test)
+		expCode int
+		expBody string
+	}
+
+	tt := []test{
+		{
+			name:    "invalid author id",
+			isOkRes: true,
+			req: model.SetPrimaryAuthorRequest{
+				ID: 0,
+			},
+			expCode: http.StatusBadRequest,
+			expBody: "id must be at least 1",
+		},
+		{
+			name: "not found",
+			req: model.SetPrimaryAuthorRequest{
+				ID: 1,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(nil, domain.Errorf(domain.ErrNotFound, "author not found"))
+			},
+			expCode: http.StatusNotFound,
+		},
+		{
+			name:    "author of another user",
+			isOkRes: true,
+			req: model.SetPrimaryAuthorRequest{
+				ID: 1,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(&model.Author{ID: data.req.ID, UserID: 2}, nil)
+			},
+			expCode: http.StatusForbidden,
+			expBody: "author belongs to another user",
+		},
+		{
+			name:    "set primary err",
+			isOkRes: true,
+			req: model.SetPrimaryAuthorRequest{
+				ID: 1,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(&model.Author{ID: data.req.ID, UserID: 1}, nil)
+				authorService.On("SetPrimary", mock.Anything, data.req).
+					Return(0, errors.New(""))
+			},
+			expCode: http.StatusInternalServerError,
+			expBody: http.StatusText(http.StatusInternalServerError),
+		},
+		{
+			name:    "admin sets primary author of another user",
+			isOkRes: true,
+			isAdmin: true,
+			req: model.SetPrimaryAuthorRequest{
+				ID: 15,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(&model.Author{ID: data.req.ID, UserID: 1}, nil)
+				authorService.On("SetPrimary", mock.Anything, data.req).
+					Return(data.req.ID, nil)
+			},
+			expCode: http.StatusOK,
+			expBody: strconv.Itoa(15),
+		},
+		{
+			name:    "all ok",
+			isOkRes: true,
+			req: model.SetPrimaryAuthorRequest{
+				ID: 15,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(&model.Author{ID: data.req.ID, UserID: 1}, nil)
+				authorService.On("SetPrimary", mock.Anything, data.req).
+					Return(data.req.ID, nil)
+			},
+			expCode: http.StatusOK,
+			expBody: strconv.Itoa(15),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var r string
+			authorService := new(m.Author)
+			testAPI.Services.Author = authorService
+			router := newAuthor(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(authorService, tc)
+			}
+
+			path := slash + author + slash + api + slash + strconv.Itoa(tc.req.ID) + slash + "primary"
+			req, err := http.NewRequest(http.MethodPut, path, nil)
+			assert.Nil(err)
+
+			if tc.isAdmin {
+				req.Header.Set(authorizationHeader, "Bearer "+adminToken)
+			} else {
+				req.Header.Set(authorizationHeader, "Bearer "+token)
+			}
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+
+			if tc.isOkRes {
+				r, err = decodeBody(res)
+				assert.Nil(err)
+			}
+			assert.Equal(tc.expBody, r)
+		})
+	}
+}
+
+func TestAuthor_Transfer(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.USER, 0)
+	require.NoError(t, err)
+
+	type test struct {
+		name    string
+		isOkRes bool
+		req     model.TransferAuthorRequest
+		fn      func(authorService *m.Author, data test)
+		expCode int
+		expBody string
+	}
+
+	tt := []test{
+		{
+			name:    "invalid new owner",
+			isOkRes: true,
+			req: model.TransferAuthorRequest{
+				ID:     1,
+				UserID: 0,
+			},
+			expCode: http.StatusBadRequest,
+			expBody: "userID must be at least 1",
+		},
+		{
+			name:    "author of another user",
+			isOkRes: true,
+			req: model.TransferAuthorRequest{
+				ID:     1,
+				UserID: 3,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(&model.Author{ID: data.req.ID, UserID: 2}, nil)
+			},
+			expCode: http.StatusForbidden,
+			expBody: "author belongs to another user",
+		},
+		{
+			name:    "missing new owner",
+			isOkRes: true,
+			req: model.TransferAuthorRequest{
+				ID:     1,
+				UserID: 3,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(&model.Author{ID: data.req.ID, UserID: 1}, nil)
+				authorService.On("Transfer", mock.Anything, data.req).
+					Return(0, domain.Errorf(domain.ErrValidation, "author refers to a missing record"))
+			},
+			expCode: http.StatusBadRequest,
+			expBody: "author refers to a missing record",
+		},
+		{
+			name:    "all ok",
+			isOkRes: true,
+			req: model.TransferAuthorRequest{
+				ID:     15,
+				UserID: 3,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: data.req.ID}).
+					Return(&model.Author{ID: data.req.ID, UserID: 1}, nil)
+				authorService.On("Transfer", mock.Anything, data.req).
+					Return(data.req.ID, nil)
+			},
+			expCode: http.StatusOK,
+			expBody: strconv.Itoa(15),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var r string
+			authorService := new(m.Author)
+			testAPI.Services.Author = authorService
+			router := newAuthor(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(authorService, tc)
+			}
+
+			body, err := json.Marshal(tc.req)
+			assert.Nil(err)
+
+			path := slash + author + slash + api + slash + strconv.Itoa(tc.req.ID) + slash + "owner"
+			req, err := http.NewRequest(http.MethodPut, path, bytes.NewBuffer(body))
+			assert.Nil(err)
+
+			req.Header.Set(authorizationHeader, "Bearer "+token)
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+
+			if tc.isOkRes {
+				r, err = decodeBody(res)
+				assert.Nil(err)
+			}
+			assert.Equal(tc.expBody, r)
+		})
+	}
+}
+
 func TestAuthor_FindByUserID(t *testing.T) {
 	assert := testAssert.New(t)
 	testAPI, err := service.InitTest4Mock()
 	require.NoError(t, err)
-	token, err := testAPI.TokenManager.NewJWT(mock.Anything)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.USER, 0)
 	require.NoError(t, err)
 
 	type test struct {
 		name        string
 		path        string
-		method      string
 		isOkRes     bool
 		isOkMessage bool
 		req         model.UserIDAuthorRequest
 		fn          func(authorService *m.Author, data test)
 		expCode     int
-		expRes      model.Author
+		expRes      *model.AuthorPage
 		message     string
 	}
 
 	tt := []test{
 		{
 			name:        "invalid user id",
-			path:        slash + author + slash + api + slash + user + slash,
-			method:      http.MethodGet,
+			path:        "0",
 			isOkMessage: true,
-			req: model.UserIDAuthorRequest{
-				ID: 0,
-			},
-			fn: func(authorService *m.Author, data test) {
-				authorService.On("FindByUserID", data.req).
-					Return(&data.expRes, nil)
-			},
-			expCode: http.StatusBadRequest,
-			message: "not correct id",
+			expCode:     http.StatusBadRequest,
+			message:     "id must be at least 1",
+		},
+		{
+			name:        "invalid limit",
+			path:        "15?limit=0",
+			isOkMessage: true,
+			expCode:     http.StatusBadRequest,
+			message:     "limit must be between 1 and 100",
 		},
 		{
 			name:        "find err",
-			path:        slash + author + slash + api + slash + user + slash,
-			method:      http.MethodGet,
+			path:        "15",
 			isOkMessage: true,
 			req: model.UserIDAuthorRequest{
-				ID: 1,
+				ID: 15,
+				ListAuthorsRequest: model.ListAuthorsRequest{
+					Limit:  defaultPageLimit,
+					SortBy: model.AuthorSortID,
+					Order:  model.OrderAsc,
+				},
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("FindByUserID", data.req).
-					Return(&data.expRes, errors.New(""))
+				authorService.On("FindByUserID", mock.Anything, data.req).
+					Return(data.expRes, errors.New(""))
 			},
 			expCode: http.StatusInternalServerError,
+			message: http.StatusText(http.StatusInternalServerError),
 		},
 		{
-			name:   "not found",
-			path:   slash + author + slash + api + slash + user + slash,
-			method: http.MethodGet,
+			name: "not found",
+			path: "15",
 			req: model.UserIDAuthorRequest{
-				ID: 1,
+				ID: 15,
+				ListAuthorsRequest: model.ListAuthorsRequest{
+					Limit:  defaultPageLimit,
+					SortBy: model.AuthorSortID,
+					Order:  model.OrderAsc,
+				},
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("FindByUserID", data.req).
-					Return(&data.expRes, nil)
+				authorService.On("FindByUserID", mock.Anything, data.req).
+					Return(&model.AuthorPage{}, nil)
 			},
 			expCode: http.StatusNotFound,
 		},
 		{
 			name:    "all ok",
-			path:    slash + author + slash + api + slash + user + slash,
-			method:  http.MethodGet,
+			path:    "15?limit=2&sort=name",
 			isOkRes: true,
 			req: model.UserIDAuthorRequest{
 				ID: 15,
+				ListAuthorsRequest: model.ListAuthorsRequest{
+					Limit:  2,
+					SortBy: model.AuthorSortName,
+					Order:  model.OrderAsc,
+				},
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("FindByUserID", data.req).
-					Return(&data.expRes, nil)
+				authorService.On("FindByUserID", mock.Anything, data.req).
+					Return(data.expRes, nil)
 			},
 			expCode: http.StatusOK,
-			expRes: model.Author{
-				ID:          1,
-				Name:        "some",
-				Age:         1,
-				Description: "some",
-				UserID:      15,
+			expRes: &model.AuthorPage{
+				Items: []model.Author{
+					{
+						ID:          1,
+						Name:        "other",
+						Age:         1,
+						Description: "some",
+						UserID:      15,
+					},
+					{
+						ID:          2,
+						Name:        "some",
+						Age:         1,
+						Description: "some",
+						UserID:      15,
+						Primary:     true,
+					},
+				},
+				Total: 2,
 			},
 		},
 	}
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			var r string
-			var a model.Author
-			author := new(m.Author)
-			testAPI.Services.Author = author
-			router := newAuthor(testAPI.Services, testAPI.TokenManager)
+			var a model.AuthorPage
+			authorService := new(m.Author)
+			testAPI.Services.Author = authorService
+			router := newAuthor(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
 			if tc.fn != nil {
-				tc.fn(author, tc)
+				tc.fn(authorService, tc)
 			}
 
-			req, err := http.NewRequest(tc.method, tc.path+strconv.Itoa(tc.req.ID), nil)
+			req, err := http.NewRequest(http.MethodGet, slash+author+slash+api+slash+user+slash+tc.path, nil)
 			assert.Nil(err)
 
 			req.Header.Set(authorizationHeader, "Bearer "+token)
@@ -576,13 +1062,13 @@ func TestAuthor_FindByUserID(t *testing.T) {
 
 			switch {
 			case tc.isOkMessage:
-				err = json.NewDecoder(res.Body).Decode(&r)
+				r, err = decodeBody(res)
 				assert.Nil(err)
 				assert.Equal(tc.message, r)
 			case tc.isOkRes:
 				err = json.NewDecoder(res.Body).Decode(&a)
 				assert.Nil(err)
-				assert.Equal(tc.expRes, a)
+				assert.Equal(*tc.expRes, a)
 			default:
 				assert.Equal(tc.message, r)
 			}
@@ -598,17 +1084,39 @@ func TestAuthor_FindByName(t *testing.T) {
 	type test struct {
 		name        string
 		path        string
+		query       string
 		method      string
 		isOkRes     bool
 		isOkMessage bool
 		req         model.NameAuthorRequest
 		fn          func(authorService *m.Author, data test)
 		expCode     int
-		expRes      []model.Author
+		expRes      *model.AuthorPage
 		message     string
 	}
 
 	tt := []test{
+		{
+			name:        "invalid sort",
+			path:        slash + author + slash,
+			query:       "?sort=description",
+			method:      http.MethodGet,
+			isOkMessage: true,
+			req: model.NameAuthorRequest{
+				Name: "some",
+			},
+			expCode: http.StatusBadRequest,
+			message: "not correct sort",
+		},
+		{
+			name:        "invalid min age",
+			path:        slash + author + slash,
+			query:       "?minAge=one",
+			method:      http.MethodGet,
+			isOkMessage: true,
+			expCode:     http.StatusBadRequest,
+			message:     "not correct minAge",
+		},
 		{
 			name:        "find err",
 			path:        slash + author + slash,
@@ -616,12 +1124,18 @@ func TestAuthor_FindByName(t *testing.T) {
 			isOkMessage: true,
 			req: model.NameAuthorRequest{
 				Name: "some",
+				ListAuthorsRequest: model.ListAuthorsRequest{
+					Limit:  defaultPageLimit,
+					SortBy: model.AuthorSortID,
+					Order:  model.OrderAsc,
+				},
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("FindByName", data.req).
+				authorService.On("FindByName", mock.Anything, data.req).
 					Return(data.expRes, errors.New(""))
 			},
 			expCode: http.StatusInternalServerError,
+			message: http.StatusText(http.StatusInternalServerError),
 		},
 		{
 			name:   "not found",
@@ -629,49 +1143,67 @@ func TestAuthor_FindByName(t *testing.T) {
 			method: http.MethodGet,
 			req: model.NameAuthorRequest{
 				Name: "some",
+				ListAuthorsRequest: model.ListAuthorsRequest{
+					Limit:  defaultPageLimit,
+					SortBy: model.AuthorSortID,
+					Order:  model.OrderAsc,
+				},
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("FindByName", data.req).
-					Return(data.expRes, nil)
+				authorService.On("FindByName", mock.Anything, data.req).
+					Return(&model.AuthorPage{}, nil)
 			},
 			expCode: http.StatusNotFound,
 		},
 		{
 			name:    "all ok",
 			path:    slash + author + slash,
+			query:   "?limit=1&sort=age&order=desc&minAge=1&maxAge=10&userID=15",
 			method:  http.MethodGet,
 			isOkRes: true,
 			req: model.NameAuthorRequest{
 				Name: "some",
+				ListAuthorsRequest: model.ListAuthorsRequest{
+					Limit:  1,
+					SortBy: model.AuthorSortAge,
+					Order:  model.OrderDesc,
+					MinAge: 1,
+					MaxAge: 10,
+					UserID: 15,
+				},
 			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("FindByName", data.req).
+				authorService.On("FindByName", mock.Anything, data.req).
 					Return(data.expRes, nil)
 			},
 			expCode: http.StatusOK,
-			expRes: []model.Author{
-				{
-					ID:          1,
-					Name:        "some",
-					Age:         1,
-					Description: "some",
-					UserID:      15,
+			expRes: &model.AuthorPage{
+				Items: []model.Author{
+					{
+						ID:          1,
+						Name:        "some",
+						Age:         1,
+						Description: "some",
+						UserID:      15,
+					},
 				},
+				NextCursor: "next",
+				Total:      2,
 			},
 		},
 	}
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			var r string
-			var a []model.Author
+			var a model.AuthorPage
 			author := new(m.Author)
 			testAPI.Services.Author = author
-			router := newAuthor(testAPI.Services, testAPI.TokenManager)
+			router := newAuthor(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
 			if tc.fn != nil {
 				tc.fn(author, tc)
 			}
 
-			req, err := http.NewRequest(tc.method, tc.path+tc.req.Name, nil)
+			req, err := http.NewRequest(tc.method, tc.path+tc.req.Name+tc.query, nil)
 			assert.Nil(err)
 
 			res := httptest.NewRecorder()
@@ -680,13 +1212,13 @@ func TestAuthor_FindByName(t *testing.T) {
 
 			switch {
 			case tc.isOkMessage:
-				err = json.NewDecoder(res.Body).Decode(&r)
+				r, err = decodeBody(res)
 				assert.Nil(err)
 				assert.Equal(tc.message, r)
 			case tc.isOkRes:
 				err = json.NewDecoder(res.Body).Decode(&a)
 				assert.Nil(err)
-				assert.Equal(tc.expRes, a)
+				assert.Equal(*tc.expRes, a)
 			default:
 				assert.Equal(tc.message, r)
 			}
@@ -698,6 +1230,12 @@ func TestAuthor_FindAll(t *testing.T) {
 	assert := testAssert.New(t)
 	testAPI, err := service.InitTest4Mock()
 	require.NoError(t, err)
+	after, err := cursor.Encode(model.AuthorCursor{
+		ID:     5,
+		Name:   "some",
+		SortBy: model.AuthorSortName,
+	})
+	require.NoError(t, err)
 
 	type test struct {
 		name        string
@@ -705,51 +1243,229 @@ func TestAuthor_FindAll(t *testing.T) {
 		method      string
 		isOkRes     bool
 		isOkMessage bool
+		req         model.ListAuthorsRequest
 		fn          func(authorService *m.Author, data test)
 		expCode     int
-		expRes      []model.Author
+		expRes      *model.AuthorPage
 		message     string
 	}
 
 	tt := []test{
+		{
+			name:        "invalid limit",
+			path:        slash + author + slash + "?limit=0",
+			method:      http.MethodGet,
+			isOkMessage: true,
+			expCode:     http.StatusBadRequest,
+			message:     "limit must be between 1 and 100",
+		},
+		{
+			name:        "invalid cursor",
+			path:        slash + author + slash + "?after=%25",
+			method:      http.MethodGet,
+			isOkMessage: true,
+			expCode:     http.StatusBadRequest,
+			message:     "not correct after",
+		},
+		{
+			name:        "invalid order",
+			path:        slash + author + slash + "?order=random",
+			method:      http.MethodGet,
+			isOkMessage: true,
+			expCode:     http.StatusBadRequest,
+			message:     "not correct order",
+		},
+		{
+			name:        "cursor of another sort",
+			path:        slash + author + slash + "?sort=age&after=" + after,
+			method:      http.MethodGet,
+			isOkMessage: true,
+			expCode:     http.StatusBadRequest,
+			message:     "cursor doesn't match sort order",
+		},
 		{
 			name:        "find err",
 			path:        slash + author + slash,
 			method:      http.MethodGet,
 			isOkMessage: true,
+			req: model.ListAuthorsRequest{
+				Limit:  defaultPageLimit,
+				SortBy: model.AuthorSortID,
+				Order:  model.OrderAsc,
+			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("FindAll").
+				authorService.On("FindAll", mock.Anything, data.req).
 					Return(data.expRes, errors.New(""))
 			},
 			expCode: http.StatusInternalServerError,
+			message: http.StatusText(http.StatusInternalServerError),
 		},
 		{
 			name:   "not found",
 			path:   slash + author + slash,
 			method: http.MethodGet,
+			req: model.ListAuthorsRequest{
+				Limit:  defaultPageLimit,
+				SortBy: model.AuthorSortID,
+				Order:  model.OrderAsc,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindAll", mock.Anything, data.req).
+					Return(&model.AuthorPage{}, nil)
+			},
+			expCode: http.StatusNotFound,
+		},
+		{
+			name:    "all ok",
+			path:    slash + author + slash + "?limit=1&sort=name&after=" + after,
+			method:  http.MethodGet,
+			isOkRes: true,
+			req: model.ListAuthorsRequest{
+				Limit:  1,
+				SortBy: model.AuthorSortName,
+				Order:  model.OrderAsc,
+				After: &model.AuthorCursor{
+					ID:     5,
+					Name:   "some",
+					SortBy: model.AuthorSortName,
+				},
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindAll", mock.Anything, data.req).
+					Return(data.expRes, nil)
+			},
+			expCode: http.StatusOK,
+			expRes: &model.AuthorPage{
+				Items: []model.Author{
+					{
+						ID:          6,
+						Name:        "some",
+						Age:         1,
+						Description: "some",
+						UserID:      15,
+					},
+				},
+				Total: 6,
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var r string
+			var a model.AuthorPage
+			author := new(m.Author)
+			testAPI.Services.Author = author
+			router := newAuthor(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(author, tc)
+			}
+
+			req, err := http.NewRequest(tc.method, tc.path, nil)
+			assert.Nil(err)
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+
+			switch {
+			case tc.isOkMessage:
+				r, err = decodeBody(res)
+				assert.Nil(err)
+				assert.Equal(tc.message, r)
+			case tc.isOkRes:
+				err = json.NewDecoder(res.Body).Decode(&a)
+				assert.Nil(err)
+				assert.Equal(*tc.expRes, a)
+			default:
+				assert.Equal(tc.message, r)
+			}
+		})
+	}
+}
+
+func TestAuthor_Search(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+
+	type test struct {
+		name        string
+		path        string
+		method      string
+		isOkRes     bool
+		isOkMessage bool
+		req         model.SearchAuthorRequest
+		fn          func(authorService *m.Author, data test)
+		expCode     int
+		expRes      []model.AuthorMatch
+		message     string
+	}
+
+	tt := []test{
+		{
+			name:        "empty query",
+			path:        slash + author + slash + "search?q=%20",
+			method:      http.MethodGet,
+			isOkMessage: true,
+			expCode:     http.StatusBadRequest,
+			message:     "q is required",
+		},
+		{
+			name:        "search err",
+			path:        slash + author + slash + "search?q=tolst",
+			method:      http.MethodGet,
+			isOkMessage: true,
+			req: model.SearchAuthorRequest{
+				Query: "tolst",
+				Limit: defaultPageLimit,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("Search", mock.Anything, data.req).
+					Return(data.expRes, errors.New(""))
+			},
+			expCode: http.StatusInternalServerError,
+			message: http.StatusText(http.StatusInternalServerError),
+		},
+		{
+			name:   "not found",
+			path:   slash + author + slash + "search?q=tolst",
+			method: http.MethodGet,
+			req: model.SearchAuthorRequest{
+				Query: "tolst",
+				Limit: defaultPageLimit,
+			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("FindAll").
+				authorService.On("Search", mock.Anything, data.req).
 					Return(data.expRes, nil)
 			},
 			expCode: http.StatusNotFound,
 		},
 		{
 			name:    "all ok",
-			path:    slash + author + slash,
+			path:    slash + author + slash + "search?q=tolst&limit=5",
 			method:  http.MethodGet,
 			isOkRes: true,
+			req: model.SearchAuthorRequest{
+				Query: "tolst",
+				Limit: 5,
+			},
 			fn: func(authorService *m.Author, data test) {
-				authorService.On("FindAll").
+				authorService.On("Search", mock.Anything, data.req).
 					Return(data.expRes, nil)
 			},
 			expCode: http.StatusOK,
-			expRes: []model.Author{
+			expRes: []model.AuthorMatch{
 				{
-					ID:          1,
-					Name:        "some",
-					Age:         1,
-					Description: "some",
-					UserID:      15,
+					Author: model.Author{
+						ID:          1,
+						Name:        "Leo Tolstoy",
+						Age:         82,
+						Description: "some",
+						UserID:      15,
+					},
+					Rank:                 0.5,
+					NameHighlight:        "Leo <mark>Tolstoy</mark>",
+					DescriptionHighlight: "some",
 				},
 			},
 		},
@@ -757,10 +1473,10 @@ func TestAuthor_FindAll(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			var r string
-			var a []model.Author
+			var a []model.AuthorMatch
 			author := new(m.Author)
 			testAPI.Services.Author = author
-			router := newAuthor(testAPI.Services, testAPI.TokenManager)
+			router := newAuthor(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
 			if tc.fn != nil {
 				tc.fn(author, tc)
 			}
@@ -774,7 +1490,7 @@ func TestAuthor_FindAll(t *testing.T) {
 
 			switch {
 			case tc.isOkMessage:
-				err = json.NewDecoder(res.Body).Decode(&r)
+				r, err = decodeBody(res)
 				assert.Nil(err)
 				assert.Equal(tc.message, r)
 			case tc.isOkRes:
@@ -787,3 +1503,35 @@ func TestAuthor_FindAll(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthor_PublicRateLimit(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+
+	authorService := new(m.Author)
+	authorService.On("Search", mock.Anything, model.SearchAuthorRequest{Query: "tolst", Limit: defaultPageLimit}).
+		Return(nil, nil).Once()
+	testAPI.Services.Author = authorService
+	limiters := Limiters{
+		Auth:   ratelimit.NewMemory(ratelimit.PerMinute(1, 1)),
+		Client: testLimiters.Client,
+		Keys:   testLimiters.Keys,
+	}
+	router := newAuthor(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, limiters)
+
+	paths := []string{"search?q=tolst", "tolstoy", ""}
+	codes := make([]int, 0, len(paths))
+	for _, path := range paths {
+		r, err := http.NewRequest(http.MethodGet, slash+author+slash+path, nil)
+		require.NoError(t, err)
+		r.RemoteAddr = "10.0.0.1:1234"
+
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, r)
+		codes = append(codes, res.Code)
+	}
+
+	assert.Equal([]int{http.StatusNotFound, http.StatusTooManyRequests, http.StatusTooManyRequests}, codes)
+	authorService.AssertExpectations(t)
+}
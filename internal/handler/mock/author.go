@@ -3,6 +3,8 @@
 package mock
 
 import (
+	context "context"
+
 	model "github.com/JesusG2000/hexsatisfaction/internal/model"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,20 +14,20 @@ type Author struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: request
-func (_m *Author) Create(request model.CreateAuthorRequest) (int, error) {
-	ret := _m.Called(request)
+// Create provides a mock function with given fields: ctx, request
+func (_m *Author) Create(ctx context.Context, request model.CreateAuthorRequest) (int, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(model.CreateAuthorRequest) int); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, model.CreateAuthorRequest) int); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(model.CreateAuthorRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, model.CreateAuthorRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,20 +35,20 @@ func (_m *Author) Create(request model.CreateAuthorRequest) (int, error) {
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: request
-func (_m *Author) Delete(request model.DeleteAuthorRequest) (int, error) {
-	ret := _m.Called(request)
+// Delete provides a mock function with given fields: ctx, request
+func (_m *Author) Delete(ctx context.Context, request model.DeleteAuthorRequest) (int, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(model.DeleteAuthorRequest) int); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, model.DeleteAuthorRequest) int); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(model.DeleteAuthorRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, model.DeleteAuthorRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -54,22 +56,22 @@ func (_m *Author) Delete(request model.DeleteAuthorRequest) (int, error) {
 	return r0, r1
 }
 
-// FindAll provides a mock function with given fields:
-func (_m *Author) FindAll() ([]model.Author, error) {
-	ret := _m.Called()
+// FindAll provides a mock function with given fields: ctx, request
+func (_m *Author) FindAll(ctx context.Context, request model.ListAuthorsRequest) (*model.AuthorPage, error) {
+	ret := _m.Called(ctx, request)
 
-	var r0 []model.Author
-	if rf, ok := ret.Get(0).(func() []model.Author); ok {
-		r0 = rf()
+	var r0 *model.AuthorPage
+	if rf, ok := ret.Get(0).(func(context.Context, model.ListAuthorsRequest) *model.AuthorPage); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.Author)
+			r0 = ret.Get(0).(*model.AuthorPage)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, model.ListAuthorsRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -77,13 +79,13 @@ func (_m *Author) FindAll() ([]model.Author, error) {
 	return r0, r1
 }
 
-// FindByID provides a mock function with given fields: request
-func (_m *Author) FindByID(request model.IDAuthorRequest) (*model.Author, error) {
-	ret := _m.Called(request)
+// FindByID provides a mock function with given fields: ctx, request
+func (_m *Author) FindByID(ctx context.Context, request model.IDAuthorRequest) (*model.Author, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *model.Author
-	if rf, ok := ret.Get(0).(func(model.IDAuthorRequest) *model.Author); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, model.IDAuthorRequest) *model.Author); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Author)
@@ -91,8 +93,31 @@ func (_m *Author) FindByID(request model.IDAuthorRequest) (*model.Author, error)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(model.IDAuthorRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, model.IDAuthorRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByName provides a mock function with given fields: ctx, request
+func (_m *Author) FindByName(ctx context.Context, request model.NameAuthorRequest) (*model.AuthorPage, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *model.AuthorPage
+	if rf, ok := ret.Get(0).(func(context.Context, model.NameAuthorRequest) *model.AuthorPage); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AuthorPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.NameAuthorRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -100,22 +125,22 @@ func (_m *Author) FindByID(request model.IDAuthorRequest) (*model.Author, error)
 	return r0, r1
 }
 
-// FindByName provides a mock function with given fields: request
-func (_m *Author) FindByName(request model.NameAuthorRequest) ([]model.Author, error) {
-	ret := _m.Called(request)
+// FindByUserID provides a mock function with given fields: ctx, request
+func (_m *Author) FindByUserID(ctx context.Context, request model.UserIDAuthorRequest) (*model.AuthorPage, error) {
+	ret := _m.Called(ctx, request)
 
-	var r0 []model.Author
-	if rf, ok := ret.Get(0).(func(model.NameAuthorRequest) []model.Author); ok {
-		r0 = rf(request)
+	var r0 *model.AuthorPage
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserIDAuthorRequest) *model.AuthorPage); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.Author)
+			r0 = ret.Get(0).(*model.AuthorPage)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(model.NameAuthorRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, model.UserIDAuthorRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -123,13 +148,13 @@ func (_m *Author) FindByName(request model.NameAuthorRequest) ([]model.Author, e
 	return r0, r1
 }
 
-// FindByUserID provides a mock function with given fields: request
-func (_m *Author) FindByUserID(request model.UserIDAuthorRequest) (*model.Author, error) {
-	ret := _m.Called(request)
+// FindPrimary provides a mock function with given fields: ctx, request
+func (_m *Author) FindPrimary(ctx context.Context, request model.PrimaryAuthorRequest) (*model.Author, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 *model.Author
-	if rf, ok := ret.Get(0).(func(model.UserIDAuthorRequest) *model.Author); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, model.PrimaryAuthorRequest) *model.Author); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Author)
@@ -137,8 +162,94 @@ func (_m *Author) FindByUserID(request model.UserIDAuthorRequest) (*model.Author
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(model.UserIDAuthorRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, model.PrimaryAuthorRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, request
+func (_m *Author) Restore(ctx context.Context, request model.IDAuthorRequest) (int, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, model.IDAuthorRequest) int); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.IDAuthorRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, request
+func (_m *Author) Search(ctx context.Context, request model.SearchAuthorRequest) ([]model.AuthorMatch, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 []model.AuthorMatch
+	if rf, ok := ret.Get(0).(func(context.Context, model.SearchAuthorRequest) []model.AuthorMatch); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.AuthorMatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.SearchAuthorRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetPrimary provides a mock function with given fields: ctx, request
+func (_m *Author) SetPrimary(ctx context.Context, request model.SetPrimaryAuthorRequest) (int, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, model.SetPrimaryAuthorRequest) int); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.SetPrimaryAuthorRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Transfer provides a mock function with given fields: ctx, request
+func (_m *Author) Transfer(ctx context.Context, request model.TransferAuthorRequest) (int, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, model.TransferAuthorRequest) int); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.TransferAuthorRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -146,20 +257,20 @@ func (_m *Author) FindByUserID(request model.UserIDAuthorRequest) (*model.Author
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: request
-func (_m *Author) Update(request model.UpdateAuthorRequest) (int, error) {
-	ret := _m.Called(request)
+// Update provides a mock function with given fields: ctx, request
+func (_m *Author) Update(ctx context.Context, request model.UpdateAuthorRequest) (int, error) {
+	ret := _m.Called(ctx, request)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(model.UpdateAuthorRequest) int); ok {
-		r0 = rf(request)
+	if rf, ok := ret.Get(0).(func(context.Context, model.UpdateAuthorRequest) int); ok {
+		r0 = rf(ctx, request)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(model.UpdateAuthorRequest) error); ok {
-		r1 = rf(request)
+	if rf, ok := ret.Get(1).(func(context.Context, model.UpdateAuthorRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,6 +3,8 @@
 package mock
 
 import (
+	context "context"
+
 	model "github.com/JesusG2000/hexsatisfaction/internal/model"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,20 +14,78 @@ type User struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: req
-func (_m *User) Create(req model.RegisterUserRequest) (int, error) {
-	ret := _m.Called(req)
+// ChangePassword provides a mock function with given fields: ctx, req
+func (_m *User) ChangePassword(ctx context.Context, req model.ChangePasswordRequest) error {
+	ret := _m.Called(ctx, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.ChangePasswordRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *User) Create(ctx context.Context, req model.RegisterUserRequest) (int, error) {
+	ret := _m.Called(ctx, req)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(model.RegisterUserRequest) int); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, model.RegisterUserRequest) int); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(model.RegisterUserRequest) error); ok {
-		r1 = rf(req)
+	if rf, ok := ret.Get(1).(func(context.Context, model.RegisterUserRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, req
+func (_m *User) Delete(ctx context.Context, req model.DeleteUserRequest) (int, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, model.DeleteUserRequest) int); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.DeleteUserRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindAll provides a mock function with given fields: ctx, req
+func (_m *User) FindAll(ctx context.Context, req model.ListUsersRequest) (*model.UserPage, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.UserPage
+	if rf, ok := ret.Get(0).(func(context.Context, model.ListUsersRequest) *model.UserPage); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.ListUsersRequest) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,20 +93,22 @@ func (_m *User) Create(req model.RegisterUserRequest) (int, error) {
 	return r0, r1
 }
 
-// FindByCredentials provides a mock function with given fields: req
-func (_m *User) FindByCredentials(req model.LoginUserRequest) (string, error) {
-	ret := _m.Called(req)
+// FindByCredentials provides a mock function with given fields: ctx, req
+func (_m *User) FindByCredentials(ctx context.Context, req model.LoginUserRequest) (*model.Tokens, error) {
+	ret := _m.Called(ctx, req)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(model.LoginUserRequest) string); ok {
-		r0 = rf(req)
+	var r0 *model.Tokens
+	if rf, ok := ret.Get(0).(func(context.Context, model.LoginUserRequest) *model.Tokens); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Tokens)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(model.LoginUserRequest) error); ok {
-		r1 = rf(req)
+	if rf, ok := ret.Get(1).(func(context.Context, model.LoginUserRequest) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -54,13 +116,13 @@ func (_m *User) FindByCredentials(req model.LoginUserRequest) (string, error) {
 	return r0, r1
 }
 
-// FindByLogin provides a mock function with given fields: login
-func (_m *User) FindByLogin(login string) (*model.User, error) {
-	ret := _m.Called(login)
+// FindByID provides a mock function with given fields: ctx, req
+func (_m *User) FindByID(ctx context.Context, req model.IDUserRequest) (*model.User, error) {
+	ret := _m.Called(ctx, req)
 
 	var r0 *model.User
-	if rf, ok := ret.Get(0).(func(string) *model.User); ok {
-		r0 = rf(login)
+	if rf, ok := ret.Get(0).(func(context.Context, model.IDUserRequest) *model.User); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.User)
@@ -68,8 +130,8 @@ func (_m *User) FindByLogin(login string) (*model.User, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(login)
+	if rf, ok := ret.Get(1).(func(context.Context, model.IDUserRequest) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -77,20 +139,145 @@ func (_m *User) FindByLogin(login string) (*model.User, error) {
 	return r0, r1
 }
 
-// IsExist provides a mock function with given fields: login
-func (_m *User) IsExist(login string) (bool, error) {
-	ret := _m.Called(login)
+// FindByLogin provides a mock function with given fields: ctx, login
+func (_m *User) FindByLogin(ctx context.Context, login string) (*model.User, error) {
+	ret := _m.Called(ctx, login)
+
+	var r0 *model.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, login)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsExist provides a mock function with given fields: ctx, login
+func (_m *User) IsExist(ctx context.Context, login string) (bool, error) {
+	ret := _m.Called(ctx, login)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(login)
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, login)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(login)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx, req
+func (_m *User) Logout(ctx context.Context, req model.LogoutUserRequest) error {
+	ret := _m.Called(ctx, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.LogoutUserRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Refresh provides a mock function with given fields: ctx, req
+func (_m *User) Refresh(ctx context.Context, req model.RefreshTokenRequest) (*model.Tokens, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.Tokens
+	if rf, ok := ret.Get(0).(func(context.Context, model.RefreshTokenRequest) *model.Tokens); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Tokens)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.RefreshTokenRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetPassword provides a mock function with given fields: ctx, req
+func (_m *User) ResetPassword(ctx context.Context, req model.IDUserRequest) (*model.TemporaryPassword, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *model.TemporaryPassword
+	if rf, ok := ret.Get(0).(func(context.Context, model.IDUserRequest) *model.TemporaryPassword); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TemporaryPassword)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.IDUserRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, req
+func (_m *User) Restore(ctx context.Context, req model.IDUserRequest) (int, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, model.IDUserRequest) int); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.IDUserRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetDisabled provides a mock function with given fields: ctx, req
+func (_m *User) SetDisabled(ctx context.Context, req model.DisableUserRequest) (int, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, model.DisableUserRequest) int); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.DisableUserRequest) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,6 +3,8 @@
 package mock
 
 import (
+	context "context"
+
 	model "github.com/JesusG2000/hexsatisfaction/internal/model"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,13 +14,13 @@ type UserRole struct {
 	mock.Mock
 }
 
-// FindAllUser provides a mock function with given fields:
-func (_m *UserRole) FindAllUser() ([]model.User, error) {
-	ret := _m.Called()
+// FindAllUser provides a mock function with given fields: ctx
+func (_m *UserRole) FindAllUser(ctx context.Context) ([]model.User, error) {
+	ret := _m.Called(ctx)
 
 	var r0 []model.User
-	if rf, ok := ret.Get(0).(func() []model.User); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []model.User); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.User)
@@ -26,8 +28,52 @@ func (_m *UserRole) FindAllUser() ([]model.User, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPermissions provides a mock function with given fields: ctx
+func (_m *UserRole) FindPermissions(ctx context.Context) (map[int][]string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 map[int][]string
+	if rf, ok := ret.Get(0).(func(context.Context) map[int][]string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int][]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRole provides a mock function with given fields: ctx, req
+func (_m *UserRole) UpdateRole(ctx context.Context, req model.UpdateUserRoleRequest) (int, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, model.UpdateUserRoleRequest) int); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.UpdateUserRoleRequest) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,8 +1,15 @@
 package handler
 
 import (
+	"net/http"
+
 	"github.com/JesusG2000/hexsatisfaction/internal/service"
 	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
+	"github.com/JesusG2000/hexsatisfaction/pkg/metrics"
+	"github.com/JesusG2000/hexsatisfaction/pkg/middleware"
+	"github.com/JesusG2000/hexsatisfaction/pkg/ratelimit"
+	"github.com/JesusG2000/hexsatisfaction/pkg/tracing"
 	"github.com/gorilla/mux"
 )
 
@@ -11,18 +18,44 @@ const (
 	authorPath = "/author"
 )
 
+// Paths of the liveness and readiness probes, which are polled too often to be access logged.
+const (
+	LivePath  = "/healthz"
+	ReadyPath = "/readyz"
+)
+
+// maxPageLimit is the max size of a page, the default one is given by the tag of model.ListAuthorsRequest.
+const maxPageLimit = 100
+
 // API represents a structure with APIs.
+// Routes are added to the router, requests are served by the handler wrapping it.
 type API struct {
 	*mux.Router
+	handler http.Handler
 }
 
-// NewHandler creates and serves endpoints of API.
-func NewHandler(services *service.Services, tokenManager auth.TokenManager) *API {
-	api := API{
-		mux.NewRouter(),
-	}
-	api.PathPrefix(userPath).Handler(newUser(services, tokenManager))
-	api.PathPrefix(authorPath).Handler(newAuthor(services, tokenManager))
+// Limiters throttle requests, Auth limits logins, registrations and public author reads by client address
+// and Client limits authenticated requests by user, Keys tell clients apart.
+type Limiters struct {
+	Auth   ratelimit.Limiter
+	Client ratelimit.Limiter
+	Keys   *ratelimit.Keys
+}
+
+// NewHandler creates and serves endpoints of API, every request except probes is logged by log.
+// Requests are traced, logged and measured before routing, so unmatched routes and methods are seen too.
+func NewHandler(services *service.Services, tokenManager auth.TokenManager, authorizer *auth.Authorizer, limiters Limiters, log logger.Logger) *API {
+	router := mux.NewRouter()
+	router.Use(tracing.Route, metrics.Route, middleware.Route)
+	router.PathPrefix(userPath).Handler(newUser(services, tokenManager, authorizer, limiters))
+	router.PathPrefix(authorPath).Handler(newAuthor(services, tokenManager, authorizer, limiters))
+
+	handler := tracing.HTTP()(middleware.RequestID(middleware.AccessLog(log, LivePath, ReadyPath)(metrics.HTTP(router))))
+
+	return &API{Router: router, handler: handler}
+}
 
-	return &api
+// ServeHTTP serves the request by the routes of the API.
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.handler.ServeHTTP(w, r)
 }
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/service"
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusLogger records statuses of logged requests.
+type statusLogger struct {
+	statuses *[]interface{}
+}
+
+func (l statusLogger) Debug(string, ...interface{}) {}
+
+func (l statusLogger) Info(_ string, args ...interface{}) {
+	l.record(args)
+}
+
+func (l statusLogger) Warn(string, ...interface{}) {}
+
+func (l statusLogger) Error(_ string, args ...interface{}) {
+	l.record(args)
+}
+
+func (l statusLogger) With(...interface{}) logger.Logger {
+	return l
+}
+
+func (l statusLogger) WithContext(context.Context) logger.Logger {
+	return l
+}
+
+func (l statusLogger) record(args []interface{}) {
+	for i := 0; i+1 < len(args); i += 2 {
+		if args[i] == "status" {
+			*l.statuses = append(*l.statuses, args[i+1])
+		}
+	}
+}
+
+func TestNewHandler(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+
+	var statuses []interface{}
+	api := NewHandler(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters, statusLogger{&statuses})
+	ok := func(http.ResponseWriter, *http.Request) {}
+	api.HandleFunc(LivePath, ok).Methods(http.MethodGet)
+	api.HandleFunc(ReadyPath, ok).Methods(http.MethodGet)
+	api.HandleFunc("/docs", ok).Methods(http.MethodGet)
+
+	tt := []struct {
+		name      string
+		method    string
+		path      string
+		expCode   int
+		isSkipped bool
+	}{
+		{
+			name:      "liveness probe",
+			method:    http.MethodGet,
+			path:      LivePath,
+			expCode:   http.StatusOK,
+			isSkipped: true,
+		},
+		{
+			name:      "readiness probe",
+			method:    http.MethodGet,
+			path:      ReadyPath,
+			expCode:   http.StatusOK,
+			isSkipped: true,
+		},
+		{
+			name:    "matched route",
+			method:  http.MethodGet,
+			path:    "/docs",
+			expCode: http.StatusOK,
+		},
+		{
+			name:    "unmatched route",
+			method:  http.MethodGet,
+			path:    "/unknown",
+			expCode: http.StatusNotFound,
+		},
+		{
+			name:    "unmatched method",
+			method:  http.MethodPost,
+			path:    "/docs",
+			expCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			statuses = nil
+			w := httptest.NewRecorder()
+			api.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+
+			assert.Equal(tc.expCode, w.Code)
+			if tc.isSkipped {
+				assert.Empty(statuses)
+			} else {
+				assert.Equal([]interface{}{tc.expCode}, statuses)
+			}
+		})
+	}
+}
@@ -1,17 +1,18 @@
 package handler
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
-	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
+	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
 	"github.com/JesusG2000/hexsatisfaction/internal/service"
 	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/metrics"
 	"github.com/JesusG2000/hexsatisfaction/pkg/middleware"
+	"github.com/JesusG2000/hexsatisfaction/pkg/ratelimit"
+	"github.com/JesusG2000/hexsatisfaction/pkg/tracing"
 	"github.com/gorilla/mux"
 )
 
@@ -19,66 +20,94 @@ type userRouter struct {
 	*mux.Router
 	services     *service.Services
 	tokenManager auth.TokenManager
+	authorizer   *auth.Authorizer
 }
 
-func newUser(services *service.Services, tokenManager auth.TokenManager) userRouter {
+func newUser(services *service.Services, tokenManager auth.TokenManager, authorizer *auth.Authorizer, limiters Limiters) userRouter {
 	router := mux.NewRouter().PathPrefix(userPath).Subrouter()
 	handler := userRouter{
 		router,
 		services,
 		tokenManager,
+		authorizer,
 	}
+	router.Use(metrics.Route, tracing.Route, middleware.Route)
+
+	byIP := ratelimit.Middleware(limiters.Auth, limiters.Keys.ByIP)
 
 	router.Path("/login").
 		Methods(http.MethodPost).
-		HandlerFunc(handler.loginUser)
+		Handler(byIP(http.HandlerFunc(handler.loginUser)))
+
+	router.Path("/refresh").
+		Methods(http.MethodPost).
+		Handler(byIP(http.HandlerFunc(handler.refreshUser)))
+
+	router.Path("/logout").
+		Methods(http.MethodPost).
+		Handler(byIP(http.HandlerFunc(handler.logoutUser)))
 
 	router.Path("/registration").
 		Methods(http.MethodPost).
-		HandlerFunc(handler.registerUser)
+		Handler(byIP(http.HandlerFunc(handler.registerUser)))
 
 	secure := router.PathPrefix("/api").Subrouter()
-	secure.Use(handler.tokenManager.UserIdentity)
+	secure.Use(handler.tokenManager.UserIdentity, ratelimit.Middleware(limiters.Client, limiters.Keys.ByUser))
 
 	secure.Path("/getAll").
 		Methods(http.MethodGet).
-		HandlerFunc(handler.getAllUser)
+		Handler(handler.authorizer.RequirePermission(dto.UserList)(http.HandlerFunc(handler.getAllUser)))
 
-	return handler
+	secure.Path("/me").
+		Methods(http.MethodGet).
+		HandlerFunc(handler.findMe)
 
-}
+	secure.Path("/me/password").
+		Methods(http.MethodPut).
+		Name(auth.PasswordChangeRoute).
+		HandlerFunc(handler.changeMyPassword)
 
-type loginRequest struct {
-	model.LoginUserRequest
-}
+	secure.Path("/me").
+		Methods(http.MethodDelete).
+		HandlerFunc(handler.deleteMe)
 
-// Build builds request for user login.
-func (req *loginRequest) Build(r *http.Request) error {
-	err := json.NewDecoder(r.Body).Decode(&req.LoginUserRequest)
-	if err != nil {
-		return err
-	}
+	admin := secure.PathPrefix("/admin").Subrouter()
+	admin.Use(handler.authorizer.RequirePermission(dto.UserManage))
+
+	admin.Path("/").
+		Methods(http.MethodGet).
+		HandlerFunc(handler.findAllUser)
 
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			log.Printf("%v", err)
-		}
-	}(r.Body)
+	admin.Path("/{id}").
+		Methods(http.MethodGet).
+		HandlerFunc(handler.findByIDUser)
 
-	return nil
-}
+	admin.Path("/{id}/role").
+		Methods(http.MethodPut).
+		HandlerFunc(handler.updateUserRole)
+
+	admin.Path("/{id}/disable").
+		Methods(http.MethodPut).
+		HandlerFunc(handler.disableUser)
+
+	admin.Path("/{id}/enable").
+		Methods(http.MethodPut).
+		HandlerFunc(handler.enableUser)
+
+	admin.Path("/{id}/password-reset").
+		Methods(http.MethodPost).
+		HandlerFunc(handler.resetUserPassword)
+
+	admin.Path("/{id}").
+		Methods(http.MethodDelete).
+		HandlerFunc(handler.deleteUser)
+
+	admin.Path("/{id}/restore").
+		Methods(http.MethodPost).
+		HandlerFunc(handler.restoreUser)
+
+	return handler
 
-// Validate validates request for user login.
-func (req *loginRequest) Validate() error {
-	switch {
-	case req.Login == "":
-		return fmt.Errorf("login is required")
-	case req.Password == "":
-		return fmt.Errorf("password is required")
-	default:
-		return nil
-	}
 }
 
 // @Summary SingIn
@@ -87,65 +116,98 @@ func (req *loginRequest) Validate() error {
 // @Accept  json
 // @Produce  json
 // @Param userCred body model.LoginUserRequest true "User credentials"
-// @Success 200 {string} string token
+// @Success 200 {object} model.Tokens
 // @Failure 400 {object} middleware.SwagError
 // @Failure 404 {object} middleware.SwagEmptyError
+// @Failure 413 {object} middleware.SwagError
+// @Failure 429 {object} middleware.SwagError
 // @Failure 500 {object} middleware.SwagError
 // @Router /user/login [post]
 func (u *userRouter) loginUser(w http.ResponseWriter, r *http.Request) {
-	var req loginRequest
+	var req model.LoginUserRequest
 	err := middleware.ParseRequest(r, &req)
 	if err != nil {
 		middleware.JSONError(w, err, http.StatusBadRequest)
 		return
 	}
 
-	token, err := u.services.User.FindByCredentials(req.LoginUserRequest)
+	tokens, err := u.services.User.FindByCredentials(r.Context(), req)
 	if err != nil {
 		middleware.JSONError(w, err, http.StatusInternalServerError)
 		return
 	}
 
-	if token == "" {
+	if tokens == nil {
 		middleware.Empty(w, http.StatusNotFound)
 		return
 	}
 
-	middleware.JSONReturn(w, http.StatusOK, token)
+	middleware.JSONReturn(w, http.StatusOK, tokens)
 
 }
 
-type registerRequest struct {
-	model.RegisterUserRequest
-}
+// @Summary Refresh
+// @Tags user
+// @Description Rotate refresh token and get new tokens
+// @Accept  json
+// @Produce  json
+// @Param token body model.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} model.Tokens
+// @Failure 400 {object} middleware.SwagError
+// @Failure 401 {object} middleware.SwagError
+// @Failure 413 {object} middleware.SwagError
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /user/refresh [post]
+func (u *userRouter) refreshUser(w http.ResponseWriter, r *http.Request) {
+	var req model.RefreshTokenRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
+		return
+	}
 
-// Build builds request for user registration.
-func (req *registerRequest) Build(r *http.Request) error {
-	err := json.NewDecoder(r.Body).Decode(&req.RegisterUserRequest)
+	tokens, err := u.services.User.Refresh(r.Context(), req)
 	if err != nil {
-		return err
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
 	}
 
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			log.Printf("%v", err)
-		}
-	}(r.Body)
+	if tokens == nil {
+		middleware.JSONError(w, fmt.Errorf("invalid refresh token"), http.StatusUnauthorized)
+		return
+	}
 
-	return nil
+	middleware.JSONReturn(w, http.StatusOK, tokens)
 }
 
-// Validate validates request for user registration.
-func (req *registerRequest) Validate() error {
-	switch {
-	case req.Login == "":
-		return fmt.Errorf("login is required")
-	case req.Password == "":
-		return fmt.Errorf("password is required")
-	default:
-		return nil
+// @Summary Logout
+// @Tags user
+// @Description Revoke refresh token
+// @Accept  json
+// @Produce  json
+// @Param token body model.LogoutUserRequest true "Refresh token"
+// @Success 204
+// @Failure 400 {object} middleware.SwagError
+// @Failure 413 {object} middleware.SwagError
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /user/logout [post]
+func (u *userRouter) logoutUser(w http.ResponseWriter, r *http.Request) {
+	var req model.LogoutUserRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	err = u.services.User.Logout(r.Context(), req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
 	}
+
+	middleware.Empty(w, http.StatusNoContent)
 }
 
 // @Summary SingUp
@@ -155,29 +217,21 @@ func (req *registerRequest) Validate() error {
 // @Produce  json
 // @Param userCred body model.RegisterUserRequest true "User credentials"
 // @Success 200 {string} string id
-// @Failure 302 {object} middleware.SwagError
 // @Failure 400 {object} middleware.SwagError
+// @Failure 409 {object} middleware.SwagError
+// @Failure 413 {object} middleware.SwagError
+// @Failure 429 {object} middleware.SwagError
 // @Failure 500 {object} middleware.SwagError
 // @Router /user/registration [post]
 func (u *userRouter) registerUser(w http.ResponseWriter, r *http.Request) {
-	var req registerRequest
+	var req model.RegisterUserRequest
 	err := middleware.ParseRequest(r, &req)
 	if err != nil {
 		middleware.JSONError(w, err, http.StatusBadRequest)
 		return
 	}
 
-	exist, err := u.services.User.IsExist(req.Login)
-	if err != nil {
-		middleware.JSONError(w, err, http.StatusInternalServerError)
-		return
-	}
-	if exist {
-		middleware.JSONReturn(w, http.StatusFound, "this user already exist")
-		return
-	}
-
-	id, err := u.services.User.Create(req.RegisterUserRequest)
+	id, err := u.services.User.Create(r.Context(), req)
 	if err != nil {
 		middleware.JSONError(w, err, http.StatusInternalServerError)
 		return
@@ -187,7 +241,7 @@ func (u *userRouter) registerUser(w http.ResponseWriter, r *http.Request) {
 }
 
 func (u *userRouter) getAllUser(w http.ResponseWriter, r *http.Request) {
-	users, err := u.services.UserRole.FindAllUser()
+	users, err := u.services.UserRole.FindAllUser(r.Context())
 	if err != nil {
 		middleware.JSONError(w, err, http.StatusInternalServerError)
 		return
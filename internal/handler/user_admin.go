@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/model"
+	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/JesusG2000/hexsatisfaction/pkg/middleware"
+)
+
+// @Summary FindAll
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description Find a page of users
+// @Accept  json
+// @Produce  json
+// @Param limit query int false "Page size"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} model.UserPage
+// @Failure 400 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /user/api/admin/ [get]
+func (u *userRouter) findAllUser(w http.ResponseWriter, r *http.Request) {
+	var req model.ListUsersRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	page, err := u.services.User.FindAll(r.Context(), req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	middleware.JSONReturn(w, http.StatusOK, page)
+}
+
+// @Summary FindByID
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description Find user by id
+// @Accept  json
+// @Produce  json
+// @Param id path int true "User id"
+// @Success 200 {object} model.User
+// @Failure 400 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagError "No user"
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /user/api/admin/{id} [get]
+func (u *userRouter) findByIDUser(w http.ResponseWriter, r *http.Request) {
+	var req model.IDUserRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	user, err := u.services.User.FindByID(r.Context(), req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	middleware.JSONReturn(w, http.StatusOK, user)
+}
+
+type updateUserRoleRequest struct {
+	model.UpdateUserRoleRequest
+}
+
+// Validate validates request to change user role.
+func (req *updateUserRoleRequest) Validate() error {
+	errs := make(domain.FieldErrors)
+	errs.Check(req.RoleID == dto.ADMIN || req.RoleID == dto.USER, "roleID", "not correct role id")
+
+	return errs.Err()
+}
+
+// @Summary UpdateRole
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description Change user role and revoke its tokens, admins can't demote themselves
+// @Accept  json
+// @Produce  json
+// @Param id path int true "User id"
+// @Param role body model.UpdateUserRoleRequest true "Role"
+// @Success 200 {string} string id
+// @Failure 400 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagError "No user"
+// @Failure 413 {object} middleware.SwagError
+// @Failure 409 {object} middleware.SwagError "Last admin"
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /user/api/admin/{id}/role [put]
+func (u *userRouter) updateUserRole(w http.ResponseWriter, r *http.Request) {
+	var req updateUserRoleRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	id, err := u.services.UserRole.UpdateRole(r.Context(), req.UpdateUserRoleRequest)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	middleware.JSONReturn(w, http.StatusOK, strconv.Itoa(id))
+}
+
+// @Summary Disable
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description Disable user account and revoke its tokens, admins can't disable themselves
+// @Accept  json
+// @Produce  json
+// @Param id path int true "User id"
+// @Success 200 {string} string id
+// @Failure 400 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagError "No user"
+// @Failure 409 {object} middleware.SwagError "Last admin"
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /user/api/admin/{id}/disable [put]
+func (u *userRouter) disableUser(w http.ResponseWriter, r *http.Request) {
+	u.setUserDisabled(w, r, true)
+}
+
+// @Summary Enable
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description Enable user account
+// @Accept  json
+// @Produce  json
+// @Param id path int true "User id"
+// @Success 200 {string} string id
+// @Failure 400 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagError "No user"
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /user/api/admin/{id}/enable [put]
+func (u *userRouter) enableUser(w http.ResponseWriter, r *http.Request) {
+	u.setUserDisabled(w, r, false)
+}
+
+func (u *userRouter) setUserDisabled(w http.ResponseWriter, r *http.Request, disabled bool) {
+	var req model.DisableUserRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	req.Disabled = disabled
+	id, err := u.services.User.SetDisabled(r.Context(), req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	middleware.JSONReturn(w, http.StatusOK, strconv.Itoa(id))
+}
+
+// @Summary ResetPassword
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description Replace user password with a temporary one and revoke its refresh tokens
+// @Accept  json
+// @Produce  json
+// @Param id path int true "User id"
+// @Success 200 {object} model.TemporaryPassword
+// @Failure 400 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagError "No user"
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /user/api/admin/{id}/password-reset [post]
+func (u *userRouter) resetUserPassword(w http.ResponseWriter, r *http.Request) {
+	var req model.IDUserRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	password, err := u.services.User.ResetPassword(r.Context(), req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	middleware.JSONReturn(w, http.StatusOK, password)
+}
+
+type deleteUserRequest struct {
+	model.DeleteUserRequest
+}
+
+// Validate validates request to delete user.
+func (req *deleteUserRequest) Validate() error {
+	errs := make(domain.FieldErrors)
+	errs.Check(req.ReassignTo != req.ID, "reassignTo", "not correct reassign user id")
+
+	return errs.Err()
+}
+
+// @Summary Delete
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description Soft delete user, authors of the user are moved to the reassignTo user or deleted, admins can't delete themselves
+// @Accept  json
+// @Produce  json
+// @Param id path int true "User id"
+// @Param reassignTo query int false "User id to move authors to"
+// @Success 200 {string} string id
+// @Failure 400 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagError "No user"
+// @Failure 409 {object} middleware.SwagError "Last admin"
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /user/api/admin/{id} [delete]
+func (u *userRouter) deleteUser(w http.ResponseWriter, r *http.Request) {
+	var req deleteUserRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	id, err := u.services.User.Delete(r.Context(), req.DeleteUserRequest)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	middleware.JSONReturn(w, http.StatusOK, strconv.Itoa(id))
+}
+
+// @Summary Restore
+// @Security ApiKeyAuth
+// @Tags admin
+// @Description Restore deleted user, authors deleted with the user stay deleted
+// @Accept  json
+// @Produce  json
+// @Param id path int true "User id"
+// @Success 200 {string} string id
+// @Failure 400 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagError "No deleted user"
+// @Failure 409 {object} middleware.SwagError "Login is taken"
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /user/api/admin/{id}/restore [post]
+func (u *userRouter) restoreUser(w http.ResponseWriter, r *http.Request) {
+	var req model.IDUserRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	id, err := u.services.User.Restore(r.Context(), req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	middleware.JSONReturn(w, http.StatusOK, strconv.Itoa(id))
+}
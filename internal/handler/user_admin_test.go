@@ -0,0 +1,582 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	m "github.com/JesusG2000/hexsatisfaction/internal/handler/mock"
+	"github.com/JesusG2000/hexsatisfaction/internal/model"
+	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
+	"github.com/JesusG2000/hexsatisfaction/internal/service"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/pkg/errors"
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const admin = "admin"
+
+func TestUserAdmin_FindAll(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.ADMIN, 0)
+	require.NoError(t, err)
+	userToken, err := testAPI.TokenManager.NewJWT(2, dto.USER, 0)
+	require.NoError(t, err)
+
+	type test struct {
+		name    string
+		query   string
+		isUser  bool
+		fn      func(userService *m.User, data test)
+		page    *model.UserPage
+		expCode int
+	}
+	tt := []test{
+		{
+			name:    "permission denied",
+			isUser:  true,
+			expCode: http.StatusForbidden,
+		},
+		{
+			name:    "invalid limit",
+			query:   "?limit=1000",
+			expCode: http.StatusBadRequest,
+		},
+		{
+			name:  "find err",
+			query: "?limit=10&offset=5",
+			fn: func(userService *m.User, data test) {
+				userService.On("FindAll", mock.Anything, model.ListUsersRequest{Limit: 10, Offset: 5}).
+					Return(data.page, errors.New(""))
+			},
+			expCode: http.StatusInternalServerError,
+		},
+		{
+			name: "all ok",
+			fn: func(userService *m.User, data test) {
+				userService.On("FindAll", mock.Anything, model.ListUsersRequest{Limit: defaultPageLimit}).
+					Return(data.page, nil)
+			},
+			page: &model.UserPage{
+				Items: []model.User{
+					{
+						ID:       1,
+						Login:    "test",
+						Password: "test",
+						RoleID:   dto.USER,
+					},
+				},
+				Total: 1,
+			},
+			expCode: http.StatusOK,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := new(m.User)
+			testAPI.Services.User = userService
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(userService, tc)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, slash+user+slash+api+slash+admin+slash+tc.query, nil)
+			assert.Nil(err)
+
+			if tc.isUser {
+				req.Header.Set(authorizationHeader, "Bearer "+userToken)
+			} else {
+				req.Header.Set(authorizationHeader, "Bearer "+token)
+			}
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+
+			if tc.expCode == http.StatusOK {
+				assert.NotContains(res.Body.String(), "password\"")
+				var page model.UserPage
+				err = json.NewDecoder(res.Body).Decode(&page)
+				assert.Nil(err)
+				assert.Equal(tc.page.Total, page.Total)
+				assert.Equal(tc.page.Items[0].Login, page.Items[0].Login)
+			}
+		})
+	}
+}
+
+func TestUserAdmin_FindByID(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.ADMIN, 0)
+	require.NoError(t, err)
+
+	type test struct {
+		name    string
+		id      int
+		fn      func(userService *m.User, data test)
+		expCode int
+		expRes  *model.User
+	}
+	tt := []test{
+		{
+			name:    "invalid id",
+			id:      0,
+			expCode: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			id:   1,
+			fn: func(userService *m.User, data test) {
+				userService.On("FindByID", mock.Anything, model.IDUserRequest{ID: data.id}).
+					Return(nil, domain.Errorf(domain.ErrNotFound, "user not found"))
+			},
+			expCode: http.StatusNotFound,
+		},
+		{
+			name: "all ok",
+			id:   15,
+			fn: func(userService *m.User, data test) {
+				userService.On("FindByID", mock.Anything, model.IDUserRequest{ID: data.id}).
+					Return(data.expRes, nil)
+			},
+			expCode: http.StatusOK,
+			expRes: &model.User{
+				ID:     15,
+				Login:  "test",
+				RoleID: dto.USER,
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := new(m.User)
+			testAPI.Services.User = userService
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(userService, tc)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, slash+user+slash+api+slash+admin+slash+strconv.Itoa(tc.id), nil)
+			assert.Nil(err)
+			req.Header.Set(authorizationHeader, "Bearer "+token)
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+
+			if tc.expRes != nil {
+				var r model.User
+				err = json.NewDecoder(res.Body).Decode(&r)
+				assert.Nil(err)
+				assert.Equal(*tc.expRes, r)
+			}
+		})
+	}
+}
+
+func TestUserAdmin_UpdateRole(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.ADMIN, 0)
+	require.NoError(t, err)
+
+	type test struct {
+		name    string
+		req     model.UpdateUserRoleRequest
+		fn      func(userRoleService *m.UserRole, data test)
+		expCode int
+		expBody string
+	}
+	tt := []test{
+		{
+			name: "invalid role",
+			req: model.UpdateUserRoleRequest{
+				ID:     15,
+				RoleID: 10,
+			},
+			expCode: http.StatusBadRequest,
+			expBody: "not correct role id",
+		},
+		{
+			name: "update err",
+			req: model.UpdateUserRoleRequest{
+				ID:     15,
+				RoleID: dto.ADMIN,
+			},
+			fn: func(userRoleService *m.UserRole, data test) {
+				userRoleService.On("UpdateRole", mock.Anything, data.req).
+					Return(0, errors.New(""))
+			},
+			expCode: http.StatusInternalServerError,
+			expBody: http.StatusText(http.StatusInternalServerError),
+		},
+		{
+			name: "all ok",
+			req: model.UpdateUserRoleRequest{
+				ID:     15,
+				RoleID: dto.ADMIN,
+			},
+			fn: func(userRoleService *m.UserRole, data test) {
+				userRoleService.On("UpdateRole", mock.Anything, data.req).
+					Return(data.req.ID, nil)
+			},
+			expCode: http.StatusOK,
+			expBody: strconv.Itoa(15),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var r string
+			userRoleService := new(m.UserRole)
+			testAPI.Services.UserRole = userRoleService
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(userRoleService, tc)
+			}
+
+			body := new(bytes.Buffer)
+			err := json.NewEncoder(body).Encode(&tc.req)
+			assert.Nil(err)
+
+			req, err := http.NewRequest(http.MethodPut, slash+user+slash+api+slash+admin+slash+strconv.Itoa(tc.req.ID)+"/role", body)
+			assert.Nil(err)
+			req.Header.Set(authorizationHeader, "Bearer "+token)
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+
+			r, err = decodeBody(res)
+			assert.Nil(err)
+			assert.Equal(tc.expBody, r)
+		})
+	}
+}
+
+func TestUserAdmin_SetDisabled(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.ADMIN, 0)
+	require.NoError(t, err)
+
+	type test struct {
+		name    string
+		path    string
+		req     model.DisableUserRequest
+		fn      func(userService *m.User, data test)
+		expCode int
+	}
+	tt := []test{
+		{
+			name: "not found",
+			path: "/disable",
+			req: model.DisableUserRequest{
+				ID:       15,
+				Disabled: true,
+			},
+			fn: func(userService *m.User, data test) {
+				userService.On("SetDisabled", mock.Anything, data.req).
+					Return(0, domain.Errorf(domain.ErrNotFound, "user not found"))
+			},
+			expCode: http.StatusNotFound,
+		},
+		{
+			name: "disable",
+			path: "/disable",
+			req: model.DisableUserRequest{
+				ID:       15,
+				Disabled: true,
+			},
+			fn: func(userService *m.User, data test) {
+				userService.On("SetDisabled", mock.Anything, data.req).
+					Return(data.req.ID, nil)
+			},
+			expCode: http.StatusOK,
+		},
+		{
+			name: "enable",
+			path: "/enable",
+			req: model.DisableUserRequest{
+				ID: 15,
+			},
+			fn: func(userService *m.User, data test) {
+				userService.On("SetDisabled", mock.Anything, data.req).
+					Return(data.req.ID, nil)
+			},
+			expCode: http.StatusOK,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := new(m.User)
+			testAPI.Services.User = userService
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(userService, tc)
+			}
+
+			req, err := http.NewRequest(http.MethodPut, slash+user+slash+api+slash+admin+slash+strconv.Itoa(tc.req.ID)+tc.path, nil)
+			assert.Nil(err)
+			req.Header.Set(authorizationHeader, "Bearer "+token)
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+			userService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserAdmin_ResetPassword(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.ADMIN, 0)
+	require.NoError(t, err)
+
+	type test struct {
+		name     string
+		id       int
+		fn       func(userService *m.User, data test)
+		expCode  int
+		password *model.TemporaryPassword
+	}
+	tt := []test{
+		{
+			name: "reset err",
+			id:   15,
+			fn: func(userService *m.User, data test) {
+				userService.On("ResetPassword", mock.Anything, model.IDUserRequest{ID: data.id}).
+					Return(data.password, errors.New(""))
+			},
+			expCode: http.StatusInternalServerError,
+		},
+		{
+			name: "not found",
+			id:   15,
+			fn: func(userService *m.User, data test) {
+				userService.On("ResetPassword", mock.Anything, model.IDUserRequest{ID: data.id}).
+					Return(nil, domain.Errorf(domain.ErrNotFound, "user not found"))
+			},
+			expCode: http.StatusNotFound,
+		},
+		{
+			name: "all ok",
+			id:   15,
+			fn: func(userService *m.User, data test) {
+				userService.On("ResetPassword", mock.Anything, model.IDUserRequest{ID: data.id}).
+					Return(data.password, nil)
+			},
+			expCode:  http.StatusOK,
+			password: &model.TemporaryPassword{Password: "temporary"},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := new(m.User)
+			testAPI.Services.User = userService
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(userService, tc)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, slash+user+slash+api+slash+admin+slash+strconv.Itoa(tc.id)+"/password-reset", nil)
+			assert.Nil(err)
+			req.Header.Set(authorizationHeader, "Bearer "+token)
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+
+			if tc.password != nil {
+				var r model.TemporaryPassword
+				err = json.NewDecoder(res.Body).Decode(&r)
+				assert.Nil(err)
+				assert.Equal(*tc.password, r)
+			}
+		})
+	}
+}
+
+func TestUserAdmin_Delete(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.ADMIN, 0)
+	require.NoError(t, err)
+
+	type test struct {
+		name    string
+		query   string
+		req     model.DeleteUserRequest
+		fn      func(userService *m.User, data test)
+		expCode int
+		expBody string
+	}
+	tt := []test{
+		{
+			name:  "reassign to itself",
+			query: "?reassignTo=15",
+			req: model.DeleteUserRequest{
+				ID: 15,
+			},
+			expCode: http.StatusBadRequest,
+			expBody: "not correct reassign user id",
+		},
+		{
+			name: "not found",
+			req: model.DeleteUserRequest{
+				ID: 15,
+			},
+			fn: func(userService *m.User, data test) {
+				userService.On("Delete", mock.Anything, data.req).
+					Return(0, domain.Errorf(domain.ErrNotFound, "user not found"))
+			},
+			expCode: http.StatusNotFound,
+		},
+		{
+			name:  "all ok",
+			query: "?reassignTo=2",
+			req: model.DeleteUserRequest{
+				ID:         15,
+				ReassignTo: 2,
+			},
+			fn: func(userService *m.User, data test) {
+				userService.On("Delete", mock.Anything, data.req).
+					Return(data.req.ID, nil)
+			},
+			expCode: http.StatusOK,
+			expBody: strconv.Itoa(15),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var r string
+			userService := new(m.User)
+			testAPI.Services.User = userService
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(userService, tc)
+			}
+
+			req, err := http.NewRequest(http.MethodDelete, slash+user+slash+api+slash+admin+slash+strconv.Itoa(tc.req.ID)+tc.query, nil)
+			assert.Nil(err)
+			req.Header.Set(authorizationHeader, "Bearer "+token)
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+
+			if tc.expCode != http.StatusNotFound {
+				r, err = decodeBody(res)
+				assert.Nil(err)
+			}
+			assert.Equal(tc.expBody, r)
+		})
+	}
+}
+
+func TestUserAdmin_Restore(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.ADMIN, 0)
+	require.NoError(t, err)
+	userToken, err := testAPI.TokenManager.NewJWT(2, dto.USER, 0)
+	require.NoError(t, err)
+
+	type test struct {
+		name    string
+		isUser  bool
+		req     model.IDUserRequest
+		fn      func(userService *m.User, data test)
+		expCode int
+		expBody string
+	}
+	tt := []test{
+		{
+			name:   "not admin",
+			isUser: true,
+			req: model.IDUserRequest{
+				ID: 15,
+			},
+			expCode: http.StatusForbidden,
+			expBody: "permission denied",
+		},
+		{
+			name: "not found",
+			req: model.IDUserRequest{
+				ID: 15,
+			},
+			fn: func(userService *m.User, data test) {
+				userService.On("Restore", mock.Anything, data.req).
+					Return(0, domain.Errorf(domain.ErrNotFound, "user not found"))
+			},
+			expCode: http.StatusNotFound,
+		},
+		{
+			name: "login is taken",
+			req: model.IDUserRequest{
+				ID: 15,
+			},
+			fn: func(userService *m.User, data test) {
+				userService.On("Restore", mock.Anything, data.req).
+					Return(0, domain.Errorf(domain.ErrConflict, "user already exists"))
+			},
+			expCode: http.StatusConflict,
+			expBody: "user already exists",
+		},
+		{
+			name: "all ok",
+			req: model.IDUserRequest{
+				ID: 15,
+			},
+			fn: func(userService *m.User, data test) {
+				userService.On("Restore", mock.Anything, data.req).
+					Return(data.req.ID, nil)
+			},
+			expCode: http.StatusOK,
+			expBody: strconv.Itoa(15),
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var r string
+			userService := new(m.User)
+			testAPI.Services.User = userService
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(userService, tc)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, slash+user+slash+api+slash+admin+slash+strconv.Itoa(tc.req.ID)+"/restore", nil)
+			assert.Nil(err)
+			if tc.isUser {
+				req.Header.Set(authorizationHeader, "Bearer "+userToken)
+			} else {
+				req.Header.Set(authorizationHeader, "Bearer "+token)
+			}
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+
+			if tc.expCode != http.StatusNotFound {
+				r, err = decodeBody(res)
+				assert.Nil(err)
+			}
+			assert.Equal(tc.expBody, r)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/model"
+	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/middleware"
+)
+
+// @Summary Me
+// @Security ApiKeyAuth
+// @Tags user
+// @Description Find the authenticated user
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} model.User
+// @Failure 401 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagError "No user"
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /user/api/me [get]
+func (u *userRouter) findMe(w http.ResponseWriter, r *http.Request) {
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		middleware.JSONError(w, errNoPrincipal, http.StatusUnauthorized)
+		return
+	}
+
+	user, err := u.services.User.FindByID(r.Context(), model.IDUserRequest{ID: principal.UserID})
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	middleware.JSONReturn(w, http.StatusOK, user)
+}
+
+// @Summary ChangePassword
+// @Security ApiKeyAuth
+// @Tags user
+// @Description Change password of the authenticated user and revoke its refresh tokens
+// @Accept  json
+// @Produce  json
+// @Param passwords body model.ChangePasswordRequest true "Old and new passwords"
+// @Success 204
+// @Failure 400 {object} middleware.SwagError
+// @Failure 401 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError
+// @Failure 404 {object} middleware.SwagError "No user"
+// @Failure 413 {object} middleware.SwagError
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /user/api/me/password [put]
+func (u *userRouter) changeMyPassword(w http.ResponseWriter, r *http.Request) {
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		middleware.JSONError(w, errNoPrincipal, http.StatusUnauthorized)
+		return
+	}
+
+	var req model.ChangePasswordRequest
+	err := middleware.ParseRequest(r, &req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	req.ID = principal.UserID
+	err = u.services.User.ChangePassword(r.Context(), req)
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	middleware.Empty(w, http.StatusNoContent)
+}
+
+// @Summary DeleteMe
+// @Security ApiKeyAuth
+// @Tags user
+// @Description Delete the authenticated user with its authors, admins can't delete themselves
+// @Accept  json
+// @Produce  json
+// @Success 204
+// @Failure 401 {object} middleware.SwagError
+// @Failure 403 {object} middleware.SwagError "Admin account"
+// @Failure 404 {object} middleware.SwagError "No user"
+// @Failure 429 {object} middleware.SwagError
+// @Failure 500 {object} middleware.SwagError
+// @Router /user/api/me [delete]
+func (u *userRouter) deleteMe(w http.ResponseWriter, r *http.Request) {
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		middleware.JSONError(w, errNoPrincipal, http.StatusUnauthorized)
+		return
+	}
+
+	_, err := u.services.User.Delete(r.Context(), model.DeleteUserRequest{ID: principal.UserID})
+	if err != nil {
+		middleware.JSONError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	middleware.Empty(w, http.StatusNoContent)
+}
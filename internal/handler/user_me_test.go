@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	m "github.com/JesusG2000/hexsatisfaction/internal/handler/mock"
+	"github.com/JesusG2000/hexsatisfaction/internal/model"
+	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
+	"github.com/JesusG2000/hexsatisfaction/internal/service"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/pkg/errors"
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const me = "me"
+
+func TestUser_FindMe(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+	token, err := testAPI.TokenManager.NewJWT(15, dto.USER, 0)
+	require.NoError(t, err)
+
+	type test struct {
+		name    string
+		fn      func(userService *m.User, data test)
+		expCode int
+		expRes  *model.User
+	}
+	tt := []test{
+		{
+			name: "find err",
+			fn: func(userService *m.User, data test) {
+				userService.On("FindByID", mock.Anything, model.IDUserRequest{ID: 15}).
+					Return(&model.User{}, errors.New(""))
+			},
+			expCode: http.StatusInternalServerError,
+		},
+		{
+			name: "not found",
+			fn: func(userService *m.User, data test) {
+				userService.On("FindByID", mock.Anything, model.IDUserRequest{ID: 15}).
+					Return(nil, domain.Errorf(domain.ErrNotFound, "user not found"))
+			},
+			expCode: http.StatusNotFound,
+		},
+		{
+			name: "all ok",
+			fn: func(userService *m.User, data test) {
+				userService.On("FindByID", mock.Anything, model.IDUserRequest{ID: 15}).
+					Return(data.expRes, nil)
+			},
+			expCode: http.StatusOK,
+			expRes: &model.User{
+				ID:     15,
+				Login:  "test",
+				RoleID: dto.USER,
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := new(m.User)
+			testAPI.Services.User = userService
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(userService, tc)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, slash+user+slash+api+slash+me, nil)
+			assert.Nil(err)
+			req.Header.Set(authorizationHeader, "Bearer "+token)
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+
+			if tc.expRes != nil {
+				var r model.User
+				err = json.NewDecoder(res.Body).Decode(&r)
+				assert.Nil(err)
+				assert.Equal(*tc.expRes, r)
+			}
+		})
+	}
+}
+
+func TestUser_ChangeMyPassword(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+	token, err := testAPI.TokenManager.NewJWT(15, dto.USER, 0)
+	require.NoError(t, err)
+
+	type test struct {
+		name    string
+		req     model.ChangePasswordRequest
+		fn      func(userService *m.User, data test)
+		expCode int
+		expBody string
+	}
+	tt := []test{
+		{
+			name: "empty new password",
+			req: model.ChangePasswordRequest{
+				OldPassword: "old",
+			},
+			expCode: http.StatusBadRequest,
+			expBody: "newPassword is required",
+		},
+		{
+			name: "change err",
+			req: model.ChangePasswordRequest{
+				OldPassword: "old",
+				NewPassword: "new",
+			},
+			fn: func(userService *m.User, data test) {
+				data.req.ID = 15
+				userService.On("ChangePassword", mock.Anything, data.req).
+					Return(errors.New(""))
+			},
+			expCode: http.StatusInternalServerError,
+			expBody: http.StatusText(http.StatusInternalServerError),
+		},
+		{
+			name: "wrong old password",
+			req: model.ChangePasswordRequest{
+				OldPassword: "wrong",
+				NewPassword: "new",
+			},
+			fn: func(userService *m.User, data test) {
+				data.req.ID = 15
+				userService.On("ChangePassword", mock.Anything, data.req).
+					Return(domain.Errorf(domain.ErrForbidden, "old password is wrong"))
+			},
+			expCode: http.StatusForbidden,
+			expBody: "old password is wrong",
+		},
+		{
+			name: "all ok",
+			req: model.ChangePasswordRequest{
+				OldPassword: "old",
+				NewPassword: "new",
+			},
+			fn: func(userService *m.User, data test) {
+				data.req.ID = 15
+				userService.On("ChangePassword", mock.Anything, data.req).
+					Return(nil)
+			},
+			expCode: http.StatusNoContent,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := new(m.User)
+			testAPI.Services.User = userService
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(userService, tc)
+			}
+
+			body := new(bytes.Buffer)
+			err := json.NewEncoder(body).Encode(&tc.req)
+			assert.Nil(err)
+
+			req, err := http.NewRequest(http.MethodPut, slash+user+slash+api+slash+me+"/password", body)
+			assert.Nil(err)
+			req.Header.Set(authorizationHeader, "Bearer "+token)
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+
+			if tc.expBody != "" {
+				var r string
+				r, err = decodeBody(res)
+				assert.Nil(err)
+				assert.Equal(tc.expBody, r)
+			}
+		})
+	}
+}
+
+func TestUser_DeleteMe(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+	token, err := testAPI.TokenManager.NewJWT(15, dto.USER, 0)
+	require.NoError(t, err)
+
+	type test struct {
+		name    string
+		fn      func(userService *m.User, data test)
+		expCode int
+	}
+	tt := []test{
+		{
+			name: "delete err",
+			fn: func(userService *m.User, data test) {
+				userService.On("Delete", mock.Anything, model.DeleteUserRequest{ID: 15}).
+					Return(0, errors.New(""))
+			},
+			expCode: http.StatusInternalServerError,
+		},
+		{
+			name: "not found",
+			fn: func(userService *m.User, data test) {
+				userService.On("Delete", mock.Anything, model.DeleteUserRequest{ID: 15}).
+					Return(0, domain.Errorf(domain.ErrNotFound, "user not found"))
+			},
+			expCode: http.StatusNotFound,
+		},
+		{
+			name: "all ok",
+			fn: func(userService *m.User, data test) {
+				userService.On("Delete", mock.Anything, model.DeleteUserRequest{ID: 15}).
+					Return(15, nil)
+			},
+			expCode: http.StatusNoContent,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := new(m.User)
+			testAPI.Services.User = userService
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(userService, tc)
+			}
+
+			req, err := http.NewRequest(http.MethodDelete, slash+user+slash+api+slash+me, nil)
+			assert.Nil(err)
+			req.Header.Set(authorizationHeader, "Bearer "+token)
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+			userService.AssertExpectations(t)
+		})
+	}
+}
@@ -12,6 +12,9 @@ import (
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
 	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
 	"github.com/JesusG2000/hexsatisfaction/internal/service"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/JesusG2000/hexsatisfaction/pkg/middleware"
+	"github.com/JesusG2000/hexsatisfaction/pkg/ratelimit"
 	"github.com/pkg/errors"
 	testAssert "github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -23,27 +26,49 @@ const (
 	slash        = "/"
 	login        = "login"
 	registration = "registration"
+	refresh      = "refresh"
+	logout       = "logout"
 	api          = "api"
 	getAll       = "getAll"
 )
 const authorizationHeader = "Authorization"
 
+var testLimiters = Limiters{
+	Auth:   ratelimit.NewMemory(ratelimit.PerMinute(60000, 10000)),
+	Client: ratelimit.NewMemory(ratelimit.PerMinute(60000, 10000)),
+	Keys:   &ratelimit.Keys{},
+}
+
+// decodeBody decodes a JSON string body or the message of an error body.
+func decodeBody(res *httptest.ResponseRecorder) (string, error) {
+	if res.Code < http.StatusBadRequest {
+		var s string
+		err := json.NewDecoder(res.Body).Decode(&s)
+		return s, err
+	}
+
+	var e middleware.ErrorResponse
+	err := json.NewDecoder(res.Body).Decode(&e)
+	return e.Message, err
+}
+
 func TestUser_Login(t *testing.T) {
 	assert := testAssert.New(t)
 	testAPI, err := service.InitTest4Mock()
 	require.NoError(t, err)
 
-	token, err := testAPI.TokenManager.NewJWT(mock.Anything)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.USER, 0)
 	require.NoError(t, err)
 	type test struct {
-		name     string
-		path     string
-		method   string
-		req      model.LoginUserRequest
-		isNoBody bool
-		fn       func(userService *m.User, data test)
-		expCode  int
-		expBody  string
+		name      string
+		path      string
+		method    string
+		req       model.LoginUserRequest
+		isNoBody  bool
+		fn        func(userService *m.User, data test)
+		expCode   int
+		expBody   string
+		expTokens *model.Tokens
 	}
 	tt := []test{
 		{
@@ -55,8 +80,8 @@ func TestUser_Login(t *testing.T) {
 				Password: "test",
 			},
 			fn: func(userService *m.User, data test) {
-				userService.On("FindByCredentials", data.req).
-					Return(data.expBody, nil)
+				userService.On("FindByCredentials", mock.Anything, data.req).
+					Return(data.expTokens, nil)
 			},
 			expCode: http.StatusBadRequest,
 			expBody: "login is required",
@@ -70,10 +95,11 @@ func TestUser_Login(t *testing.T) {
 				Password: "test",
 			},
 			fn: func(userService *m.User, data test) {
-				userService.On("FindByCredentials", data.req).
-					Return(data.expBody, errors.New(""))
+				userService.On("FindByCredentials", mock.Anything, data.req).
+					Return(data.expTokens, errors.New(""))
 			},
 			expCode: http.StatusInternalServerError,
+			expBody: http.StatusText(http.StatusInternalServerError),
 		},
 		{
 			name:   "no user",
@@ -85,8 +111,8 @@ func TestUser_Login(t *testing.T) {
 			},
 			isNoBody: true,
 			fn: func(userService *m.User, data test) {
-				userService.On("FindByCredentials", data.req).
-					Return(data.expBody, nil)
+				userService.On("FindByCredentials", mock.Anything, data.req).
+					Return(data.expTokens, nil)
 			},
 			expCode: http.StatusNotFound,
 		},
@@ -98,12 +124,16 @@ func TestUser_Login(t *testing.T) {
 				Login:    "test",
 				Password: "test",
 			},
+			isNoBody: true,
 			fn: func(userService *m.User, data test) {
-				userService.On("FindByCredentials", data.req).
-					Return(data.expBody, nil)
+				userService.On("FindByCredentials", mock.Anything, data.req).
+					Return(data.expTokens, nil)
 			},
 			expCode: http.StatusOK,
-			expBody: token,
+			expTokens: &model.Tokens{
+				AccessToken:  token,
+				RefreshToken: "refresh",
+			},
 		},
 	}
 	for _, tc := range tt {
@@ -111,7 +141,7 @@ func TestUser_Login(t *testing.T) {
 			var r string
 			userService := new(m.User)
 			testAPI.Services.User = userService
-			router := newUser(testAPI.Services, testAPI.TokenManager)
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
 			if tc.fn != nil {
 				tc.fn(userService, tc)
 			}
@@ -127,8 +157,246 @@ func TestUser_Login(t *testing.T) {
 			router.ServeHTTP(res, req)
 			assert.Equal(tc.expCode, res.Code)
 
+			if tc.expTokens != nil {
+				var tokens model.Tokens
+				err = json.NewDecoder(res.Body).Decode(&tokens)
+				assert.Nil(err)
+				assert.Equal(*tc.expTokens, tokens)
+			}
 			if !tc.isNoBody {
-				err = json.NewDecoder(res.Body).Decode(&r)
+				r, err = decodeBody(res)
+				assert.Nil(err)
+			}
+			assert.Equal(tc.expBody, r)
+		})
+	}
+}
+
+func TestUser_LoginRateLimit(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+
+	req := model.LoginUserRequest{
+		Login:    "test",
+		Password: "test",
+	}
+	userService := new(m.User)
+	userService.On("FindByCredentials", mock.Anything, req).
+		Return(nil, nil).Once()
+	testAPI.Services.User = userService
+	limiters := Limiters{
+		Auth:   ratelimit.NewMemory(ratelimit.PerMinute(1, 1)),
+		Client: testLimiters.Client,
+		Keys:   testLimiters.Keys,
+	}
+	router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, limiters)
+
+	codes := make([]int, 0, 2)
+	for i := 0; i < 2; i++ {
+		payloadBuf := new(bytes.Buffer)
+		err := json.NewEncoder(payloadBuf).Encode(&req)
+		require.NoError(t, err)
+
+		r, err := http.NewRequest(http.MethodPost, slash+user+slash+login, payloadBuf)
+		require.NoError(t, err)
+		r.RemoteAddr = "10.0.0.1:1234"
+
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, r)
+		codes = append(codes, res.Code)
+
+		if res.Code == http.StatusTooManyRequests {
+			assert.Equal("60", res.Header().Get("Retry-After"))
+		}
+	}
+
+	assert.Equal([]int{http.StatusNotFound, http.StatusTooManyRequests}, codes)
+	userService.AssertExpectations(t)
+}
+
+func TestUser_Refresh(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+
+	token, err := testAPI.TokenManager.NewJWT(1, dto.USER, 0)
+	require.NoError(t, err)
+	type test struct {
+		name      string
+		path      string
+		method    string
+		req       model.RefreshTokenRequest
+		fn        func(userService *m.User, data test)
+		expCode   int
+		expBody   string
+		expTokens *model.Tokens
+	}
+	tt := []test{
+		{
+			name:    "empty token",
+			path:    slash + user + slash + refresh,
+			method:  http.MethodPost,
+			req:     model.RefreshTokenRequest{},
+			expCode: http.StatusBadRequest,
+			expBody: "refreshToken is required",
+		},
+		{
+			name:   "refresh err",
+			path:   slash + user + slash + refresh,
+			method: http.MethodPost,
+			req: model.RefreshTokenRequest{
+				RefreshToken: "refresh",
+			},
+			fn: func(userService *m.User, data test) {
+				userService.On("Refresh", mock.Anything, data.req).
+					Return(data.expTokens, errors.New(""))
+			},
+			expCode: http.StatusInternalServerError,
+			expBody: http.StatusText(http.StatusInternalServerError),
+		},
+		{
+			name:   "invalid token",
+			path:   slash + user + slash + refresh,
+			method: http.MethodPost,
+			req: model.RefreshTokenRequest{
+				RefreshToken: "refresh",
+			},
+			fn: func(userService *m.User, data test) {
+				userService.On("Refresh", mock.Anything, data.req).
+					Return(data.expTokens, nil)
+			},
+			expCode: http.StatusUnauthorized,
+			expBody: "invalid refresh token",
+		},
+		{
+			name:   "all ok",
+			path:   slash + user + slash + refresh,
+			method: http.MethodPost,
+			req: model.RefreshTokenRequest{
+				RefreshToken: "refresh",
+			},
+			fn: func(userService *m.User, data test) {
+				userService.On("Refresh", mock.Anything, data.req).
+					Return(data.expTokens, nil)
+			},
+			expCode: http.StatusOK,
+			expTokens: &model.Tokens{
+				AccessToken:  token,
+				RefreshToken: "new refresh",
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := new(m.User)
+			testAPI.Services.User = userService
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(userService, tc)
+			}
+
+			payloadBuf := new(bytes.Buffer)
+			err := json.NewEncoder(payloadBuf).Encode(&tc.req)
+			assert.Nil(err)
+
+			req, err := http.NewRequest(tc.method, tc.path, payloadBuf)
+			assert.Nil(err)
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+
+			if tc.expTokens != nil {
+				var tokens model.Tokens
+				err = json.NewDecoder(res.Body).Decode(&tokens)
+				assert.Nil(err)
+				assert.Equal(*tc.expTokens, tokens)
+				return
+			}
+			var r string
+			r, err = decodeBody(res)
+			assert.Nil(err)
+			assert.Equal(tc.expBody, r)
+		})
+	}
+}
+
+func TestUser_Logout(t *testing.T) {
+	assert := testAssert.New(t)
+	testAPI, err := service.InitTest4Mock()
+	require.NoError(t, err)
+	type test struct {
+		name     string
+		path     string
+		method   string
+		req      model.LogoutUserRequest
+		isNoBody bool
+		fn       func(userService *m.User, data test)
+		expCode  int
+		expBody  string
+	}
+	tt := []test{
+		{
+			name:    "empty token",
+			path:    slash + user + slash + logout,
+			method:  http.MethodPost,
+			req:     model.LogoutUserRequest{},
+			expCode: http.StatusBadRequest,
+			expBody: "refreshToken is required",
+		},
+		{
+			name:   "logout err",
+			path:   slash + user + slash + logout,
+			method: http.MethodPost,
+			req: model.LogoutUserRequest{
+				RefreshToken: "refresh",
+			},
+			fn: func(userService *m.User, data test) {
+				userService.On("Logout", mock.Anything, data.req).
+					Return(errors.New(""))
+			},
+			expCode: http.StatusInternalServerError,
+			expBody: http.StatusText(http.StatusInternalServerError),
+		},
+		{
+			name:   "all ok",
+			path:   slash + user + slash + logout,
+			method: http.MethodPost,
+			req: model.LogoutUserRequest{
+				RefreshToken: "refresh",
+			},
+			isNoBody: true,
+			fn: func(userService *m.User, data test) {
+				userService.On("Logout", mock.Anything, data.req).
+					Return(nil)
+			},
+			expCode: http.StatusNoContent,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var r string
+			userService := new(m.User)
+			testAPI.Services.User = userService
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
+			if tc.fn != nil {
+				tc.fn(userService, tc)
+			}
+
+			payloadBuf := new(bytes.Buffer)
+			err := json.NewEncoder(payloadBuf).Encode(&tc.req)
+			assert.Nil(err)
+
+			req, err := http.NewRequest(tc.method, tc.path, payloadBuf)
+			assert.Nil(err)
+
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(tc.expCode, res.Code)
+
+			if !tc.isNoBody {
+				r, err = decodeBody(res)
 				assert.Nil(err)
 			}
 			assert.Equal(tc.expBody, r)
@@ -158,27 +426,9 @@ func TestUser_Registration(t *testing.T) {
 				Login:    "",
 				Password: "test",
 			},
-			fn: func(userService *m.User, data test) {
-				userService.On("IsExist", data.req.Login).
-					Return(false, nil)
-			},
 			expCode: http.StatusBadRequest,
 			expBody: "login is required",
 		},
-		{
-			name:   "exist error",
-			path:   slash + user + slash + registration,
-			method: http.MethodPost,
-			req: model.RegisterUserRequest{
-				Login:    "test",
-				Password: "test",
-			},
-			fn: func(userService *m.User, data test) {
-				userService.On("IsExist", data.req.Login).
-					Return(false, errors.New(""))
-			},
-			expCode: http.StatusInternalServerError,
-		},
 		{
 			name:   "existed user",
 			path:   slash + user + slash + registration,
@@ -188,11 +438,11 @@ func TestUser_Registration(t *testing.T) {
 				Password: "test",
 			},
 			fn: func(userService *m.User, data test) {
-				userService.On("IsExist", data.req.Login).
-					Return(true, nil)
+				userService.On("Create", mock.Anything, data.req).
+					Return(0, errors.Wrap(domain.Errorf(domain.ErrConflict, "user already exists"), "couldn't create a user"))
 			},
-			expCode: http.StatusFound,
-			expBody: "this user already exist",
+			expCode: http.StatusConflict,
+			expBody: "user already exists",
 		},
 		{
 			name:   "create error",
@@ -203,12 +453,11 @@ func TestUser_Registration(t *testing.T) {
 				Password: "test",
 			},
 			fn: func(userService *m.User, data test) {
-				userService.On("IsExist", data.req.Login).
-					Return(false, nil)
-				userService.On("Create", data.req).
+				userService.On("Create", mock.Anything, data.req).
 					Return(0, errors.New(""))
 			},
 			expCode: http.StatusInternalServerError,
+			expBody: http.StatusText(http.StatusInternalServerError),
 		},
 		{
 			name:   "all ok",
@@ -219,9 +468,7 @@ func TestUser_Registration(t *testing.T) {
 				Password: "test",
 			},
 			fn: func(userService *m.User, data test) {
-				userService.On("IsExist", data.req.Login).
-					Return(false, nil)
-				userService.On("Create", data.req).
+				userService.On("Create", mock.Anything, data.req).
 					Return(15, nil)
 			},
 			expCode: http.StatusOK,
@@ -234,7 +481,7 @@ func TestUser_Registration(t *testing.T) {
 			var r string
 			userService := new(m.User)
 			testAPI.Services.User = userService
-			router := newUser(testAPI.Services, testAPI.TokenManager)
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
 			if tc.fn != nil {
 				tc.fn(userService, tc)
 			}
@@ -250,7 +497,7 @@ func TestUser_Registration(t *testing.T) {
 			router.ServeHTTP(res, req)
 			assert.Equal(tc.expCode, res.Code)
 
-			err = json.NewDecoder(res.Body).Decode(&r)
+			r, err = decodeBody(res)
 			assert.Nil(err)
 			assert.Equal(tc.expBody, r)
 		})
@@ -262,7 +509,9 @@ func TestUserRole_FindAll(t *testing.T) {
 	testAPI, err := service.InitTest4Mock()
 	require.NoError(t, err)
 
-	token, err := testAPI.TokenManager.NewJWT(mock.Anything)
+	token, err := testAPI.TokenManager.NewJWT(1, dto.ADMIN, 0)
+	require.NoError(t, err)
+	userToken, err := testAPI.TokenManager.NewJWT(2, dto.USER, 0)
 	require.NoError(t, err)
 
 	type test struct {
@@ -270,18 +519,26 @@ func TestUserRole_FindAll(t *testing.T) {
 		path    string
 		method  string
 		isOkRes bool
+		isUser  bool
 		fn      func(userRoleService *m.UserRole, data test)
+		users   []model.User
 		expCode int
 		expBody []model.User
 	}
 	tt := []test{
-
+		{
+			name:    "permission denied",
+			path:    slash + user + slash + api + slash + getAll,
+			method:  http.MethodGet,
+			isUser:  true,
+			expCode: http.StatusForbidden,
+		},
 		{
 			name:   "find error",
 			path:   slash + user + slash + api + slash + getAll,
 			method: http.MethodGet,
 			fn: func(userRoleService *m.UserRole, data test) {
-				userRoleService.On("FindAllUser").
+				userRoleService.On("FindAllUser", mock.Anything).
 					Return(data.expBody, errors.New(""))
 			},
 			expCode: http.StatusInternalServerError,
@@ -292,11 +549,10 @@ func TestUserRole_FindAll(t *testing.T) {
 			method:  http.MethodGet,
 			isOkRes: true,
 			fn: func(userRoleService *m.UserRole, data test) {
-				userRoleService.On("FindAllUser").
-					Return(data.expBody, nil)
+				userRoleService.On("FindAllUser", mock.Anything).
+					Return(data.users, nil)
 			},
-			expCode: http.StatusOK,
-			expBody: []model.User{
+			users: []model.User{
 				{
 					Login:    "test",
 					Password: "test",
@@ -308,6 +564,17 @@ func TestUserRole_FindAll(t *testing.T) {
 					RoleID:   dto.USER,
 				},
 			},
+			expCode: http.StatusOK,
+			expBody: []model.User{
+				{
+					Login:  "test",
+					RoleID: dto.USER,
+				},
+				{
+					Login:  "test1",
+					RoleID: dto.USER,
+				},
+			},
 		},
 	}
 	for _, tc := range tt {
@@ -315,7 +582,7 @@ func TestUserRole_FindAll(t *testing.T) {
 			var r []model.User
 			userRoleService := new(m.UserRole)
 			testAPI.Services.UserRole = userRoleService
-			router := newUser(testAPI.Services, testAPI.TokenManager)
+			router := newUser(testAPI.Services, testAPI.TokenManager, testAPI.Authorizer, testLimiters)
 			if tc.fn != nil {
 				tc.fn(userRoleService, tc)
 			}
@@ -323,7 +590,11 @@ func TestUserRole_FindAll(t *testing.T) {
 			req, err := http.NewRequest(tc.method, tc.path, nil)
 			assert.Nil(err)
 
-			req.Header.Set(authorizationHeader, "Bearer "+token)
+			if tc.isUser {
+				req.Header.Set(authorizationHeader, "Bearer "+userToken)
+			} else {
+				req.Header.Set(authorizationHeader, "Bearer "+token)
+			}
 
 			res := httptest.NewRecorder()
 			router.ServeHTTP(res, req)
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// Audit represents when and by whom a row was created and last updated.
+// CreatedBy and UpdatedBy are empty for changes made by anonymous users and other services.
+type Audit struct {
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	CreatedBy *int      `json:"createdBy,omitempty"`
+	UpdatedBy *int      `json:"updatedBy,omitempty"`
+}
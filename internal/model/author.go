@@ -1,10 +1,77 @@
 package model
 
+import "github.com/JesusG2000/hexsatisfaction/pkg/cursor"
+
+// Fields authors can be sorted by.
+const (
+	AuthorSortID   = "id"
+	AuthorSortName = "name"
+	AuthorSortAge  = "age"
+)
+
+// Sort orders.
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
 // Author represents author model.
+// A user may own several authors, one of them may be marked as primary.
 type Author struct {
 	ID          int    `json:"id,omitempty"`
 	Name        string `json:"name"`
 	Age         int    `json:"age"`
 	Description string `json:"description"`
 	UserID      int    `json:"userID"`
+	Primary     bool   `json:"primary"`
+	Audit
+}
+
+// AuthorPage represents a page of authors.
+type AuthorPage struct {
+	Items      []Author `json:"items"`
+	NextCursor string   `json:"nextCursor,omitempty"`
+	Total      int      `json:"total"`
+}
+
+// AuthorMatch represents an author found by search.
+// Highlights are HTML escaped and mark the matched words with <mark> tags.
+type AuthorMatch struct {
+	Author
+	Rank                 float64 `json:"rank"`
+	NameHighlight        string  `json:"nameHighlight"`
+	DescriptionHighlight string  `json:"descriptionHighlight"`
+}
+
+// AuthorCursor points to the last author of a page.
+type AuthorCursor struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Age    int    `json:"age,omitempty"`
+	SortBy string `json:"sortBy"`
+	Desc   bool   `json:"desc,omitempty"`
+}
+
+// UnmarshalText decodes the opaque cursor given to clients.
+func (c *AuthorCursor) UnmarshalText(text []byte) error {
+	// The alias has no UnmarshalText, so encoding/json decodes the position as an object.
+	type position AuthorCursor
+	return cursor.Decode(string(text), (*position)(c))
+}
+
+// Descending checks the authors are sorted in descending order.
+func (r ListAuthorsRequest) Descending() bool {
+	return r.Order == OrderDesc
+}
+
+// AuthorFilter represents options to find authors.
+type AuthorFilter struct {
+	Name   string
+	UserID int
+	MinAge int
+	MaxAge int
+	SortBy string
+	Desc   bool
+	Limit  int
+	After  *AuthorCursor
 }
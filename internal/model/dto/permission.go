@@ -0,0 +1,13 @@
+package dto
+
+// AuthorWrite permits to create, update and delete own authors.
+const AuthorWrite = "author:write"
+
+// AuthorWriteAny permits to update and delete authors of any user.
+const AuthorWriteAny = "author:write:any"
+
+// UserList permits to list users.
+const UserList = "user:list"
+
+// UserManage permits to manage accounts of other users.
+const UserManage = "user:manage"
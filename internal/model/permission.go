@@ -0,0 +1,7 @@
+package model
+
+// RolePermission represents a permission granted to a role.
+type RolePermission struct {
+	RoleID     int    `json:"roleID"`
+	Permission string `json:"permission"`
+}
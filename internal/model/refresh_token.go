@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// RefreshToken represents refresh token model.
+type RefreshToken struct {
+	ID        int       `json:"id,omitempty"`
+	UserID    int       `json:"userID"`
+	Token     string    `json:"-"`
+	Family    string    `json:"-"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Revoked   bool      `json:"revoked"`
+}
+
+// Tokens represents a pair of access and refresh tokens.
+// Users who must change a temporary password may use the access token only for that.
+type Tokens struct {
+	AccessToken           string `json:"accessToken"`
+	RefreshToken          string `json:"refreshToken"`
+	PasswordResetRequired bool   `json:"passwordResetRequired,omitempty"`
+}
@@ -5,17 +5,74 @@ type (
 	// RegisterUserRequest represents a request for user registration.
 	RegisterUserRequest struct {
 		// required: true
-		Login string `json:"login"`
+		Login string `json:"login" validate:"required"`
 		// required: true
-		Password string `json:"password"`
+		Password string `json:"password" validate:"required"`
 	}
 
 	// LoginUserRequest represents a request for user login.
 	LoginUserRequest struct {
 		// required: true
-		Login string `json:"login"`
+		Login string `json:"login" validate:"required"`
 		// required: true
-		Password string `json:"password"`
+		Password string `json:"password" validate:"required"`
+	}
+
+	// RefreshTokenRequest represents a request to refresh tokens.
+	RefreshTokenRequest struct {
+		// required: true
+		RefreshToken string `json:"refreshToken" validate:"required"`
+	}
+
+	// LogoutUserRequest represents a request for user logout.
+	LogoutUserRequest struct {
+		// required: true
+		RefreshToken string `json:"refreshToken" validate:"required"`
+	}
+
+	// ChangePasswordRequest represents a request to change own password.
+	ChangePasswordRequest struct {
+		// required: true
+		ID int `json:"-"`
+		// required: true
+		OldPassword string `json:"oldPassword" validate:"required"`
+		// required: true
+		NewPassword string `json:"newPassword" validate:"required"`
+	}
+
+	// ListUsersRequest represents a request to find a page of users.
+	ListUsersRequest struct {
+		Limit  int `json:"-" query:"limit" default:"20" validate:"min=1,max=100"`
+		Offset int `json:"-" query:"offset" validate:"min=0"`
+	}
+
+	// IDUserRequest represents a request to find user by id.
+	IDUserRequest struct {
+		// required: true
+		ID int `json:"-" path:"id" validate:"min=1"`
+	}
+
+	// UpdateUserRoleRequest represents a request to change user role.
+	UpdateUserRoleRequest struct {
+		// required: true
+		ID int `json:"-" path:"id" validate:"min=1"`
+		// required: true
+		RoleID int `json:"roleID"`
+	}
+
+	// DisableUserRequest represents a request to disable or enable user.
+	DisableUserRequest struct {
+		// required: true
+		ID       int  `json:"-" path:"id" validate:"min=1"`
+		Disabled bool `json:"-"`
+	}
+
+	// DeleteUserRequest represents a request to delete user.
+	DeleteUserRequest struct {
+		// required: true
+		ID int `json:"-" path:"id" validate:"min=1"`
+		// Authors of the user are moved to this user or deleted if it is empty.
+		ReassignTo int `json:"-" query:"reassignTo" validate:"min=0"`
 	}
 )
 
@@ -23,50 +80,90 @@ type (
 	// CreateAuthorRequest represents a request to create author.
 	CreateAuthorRequest struct {
 		// required: true
-		Name string `json:"name"`
+		Name string `json:"name" validate:"required,max=150"`
 		// required: true
-		Age int `json:"age"`
+		Age int `json:"age" validate:"min=1,max=150"`
 		// required: true
-		Description string `json:"description"`
+		Description string `json:"description" validate:"required"`
 		// required: true
-		UserID int `json:"userID"`
+		UserID int `json:"userID" validate:"min=1"`
 	}
 
 	// UpdateAuthorRequest represents a request to update author.
 	UpdateAuthorRequest struct {
 		// required: true
-		ID int `json:"-"`
+		ID int `json:"-" path:"id" validate:"min=1"`
 		// required: true
-		Name string `json:"name"`
+		Name string `json:"name" validate:"required,max=150"`
 		// required: true
-		Age int `json:"age"`
+		Age int `json:"age" validate:"min=1,max=150"`
 		// required: true
-		Description string `json:"description"`
+		Description string `json:"description" validate:"required"`
 		// required: true
-		UserID int `json:"userID"`
+		UserID int `json:"userID" validate:"min=1"`
 	}
 
 	// DeleteAuthorRequest represents a request to delete author.
 	DeleteAuthorRequest struct {
 		// required: true
-		ID int `json:"-"`
+		ID int `json:"-" path:"id" validate:"min=1"`
 	}
 
 	// IDAuthorRequest represents a request to find author by id.
 	IDAuthorRequest struct {
 		// required: true
-		ID int `json:"-"`
+		ID int `json:"-" path:"id" validate:"min=1"`
 	}
 
-	// UserIDAuthorRequest represents a request to find author by user id.
+	// UserIDAuthorRequest represents a request to find a page of authors by user id.
 	UserIDAuthorRequest struct {
 		// required: true
-		ID int `json:"-"`
+		ID int `json:"-" path:"id" validate:"min=1"`
+		ListAuthorsRequest
+	}
+
+	// PrimaryAuthorRequest represents a request to find the primary author of the user.
+	PrimaryAuthorRequest struct {
+		// required: true
+		UserID int `json:"-" validate:"min=1"`
+	}
+
+	// SetPrimaryAuthorRequest represents a request to mark author as primary for its user.
+	SetPrimaryAuthorRequest struct {
+		// required: true
+		ID int `json:"-" path:"id" validate:"min=1"`
+	}
+
+	// TransferAuthorRequest represents a request to transfer author to another user.
+	TransferAuthorRequest struct {
+		// required: true
+		ID int `json:"-" path:"id" validate:"min=1"`
+		// required: true
+		UserID int `json:"userID" validate:"min=1"`
+	}
+
+	// ListAuthorsRequest represents a request to find a page of authors.
+	ListAuthorsRequest struct {
+		Limit  int           `json:"-" query:"limit" default:"20"`
+		After  *AuthorCursor `json:"-" query:"after"`
+		SortBy string        `json:"-" query:"sort" default:"id"`
+		Order  string        `json:"-" query:"order" default:"asc"`
+		MinAge int           `json:"-" query:"minAge"`
+		MaxAge int           `json:"-" query:"maxAge"`
+		UserID int           `json:"-" query:"userID"`
+	}
+
+	// SearchAuthorRequest represents a request to search authors by name and description.
+	SearchAuthorRequest struct {
+		// required: true
+		Query string `json:"-" query:"q" validate:"required"`
+		Limit int    `json:"-" query:"limit" default:"20" validate:"min=1,max=100"`
 	}
 
-	// NameAuthorRequest represents a request to find authors by name.
+	// NameAuthorRequest represents a request to find a page of authors by name.
 	NameAuthorRequest struct {
 		// required: true
-		Name string `json:"-"`
+		Name string `json:"-" path:"name" validate:"required"`
+		ListAuthorsRequest
 	}
 )
@@ -1,9 +1,29 @@
 package model
 
+import "time"
+
 // User represents user model.
+// Logins of the user are locked until LockedUntil after FailedLogins failures in a row.
 type User struct {
-	ID       int    `json:"id,omitempty"`
-	Login    string `json:"login"`
+	ID                    int        `json:"id,omitempty"`
+	Login                 string     `json:"login"`
+	Password              string     `json:"-"`
+	RoleID                int        `json:"roleID"`
+	Disabled              bool       `json:"disabled"`
+	PasswordResetRequired bool       `json:"passwordResetRequired"`
+	FailedLogins          int        `json:"failedLogins"`
+	LockedUntil           *time.Time `json:"lockedUntil,omitempty"`
+	TokenVersion          int        `json:"-"`
+	Audit
+}
+
+// UserPage represents a page of users.
+type UserPage struct {
+	Items []User `json:"items"`
+	Total int    `json:"total"`
+}
+
+// TemporaryPassword represents a password generated on forced reset.
+type TemporaryPassword struct {
 	Password string `json:"password"`
-	RoleID   int    `json:"roleID"`
 }
@@ -1,135 +1,258 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"html"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
 )
 
+const (
+	authorColumns = "id, name, age, description, userID, is_primary, created_at, updated_at, created_by, updated_by"
+	// highlightStart and highlightStop mark matches in headlines until the text is escaped.
+	highlightStart = "\x01"
+	highlightStop  = "\x02"
+)
+
+// highlighter swaps the markers of matches in an escaped headline for <mark> tags.
+var highlighter = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
 // AuthorRepo is a author repository.
+// Deleted authors are kept until they are purged, finders ignore them.
 type AuthorRepo struct {
-	db *sql.DB
+	db      tracedDB
+	timeout time.Duration
 }
 
 // NewAuthorRepo is a AuthorRepo constructor.
-func NewAuthorRepo(db *sql.DB) *AuthorRepo {
-	return &AuthorRepo{db: db}
+func NewAuthorRepo(db *sql.DB, timeout time.Duration) *AuthorRepo {
+	return &AuthorRepo{db: newTracedDB(db, "AuthorRepo"), timeout: timeout}
+}
+
+// authorFields returns pointers to the fields of the author in the order of authorColumns.
+func authorFields(author *model.Author) []interface{} {
+	return []interface{}{&author.ID, &author.Name, &author.Age, &author.Description, &author.UserID, &author.Primary,
+		&author.CreatedAt, &author.UpdatedAt, &author.CreatedBy, &author.UpdatedBy}
 }
 
 // Create creates new author and returns id.
-func (a AuthorRepo) Create(author model.Author) (int, error) {
-	var creatID int
-	rows, err := a.db.Query("INSERT INTO author (name, age, description, userID) VALUES ($1,$2,$3,$4) RETURNING id",
-		author.Name, author.Age, author.Description, author.UserID)
-	if err != nil {
+func (a AuthorRepo) Create(ctx context.Context, author model.Author) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	if err := checkUser(ctx, a.db, author.UserID); err != nil {
 		return 0, err
 	}
 
-	if rows.Next() {
-		err = rows.Scan(&creatID)
-		if err != nil {
-			return 0, err
-		}
+	var creatID int
+	err := a.db.QueryRowContext(ctx, "INSERT INTO author (name, age, description, userID, created_by, updated_by) VALUES ($1,$2,$3,$4,$5,$5) RETURNING id",
+		author.Name, author.Age, author.Description, author.UserID, auditActor(ctx)).Scan(&creatID)
+	if err != nil {
+		return 0, mapError(err, "author")
 	}
-	return creatID, rows.Err()
+
+	return creatID, nil
 }
 
 // Update updates author and returns id.
-func (a AuthorRepo) Update(id int, author model.Author) (int, error) {
-	var updatedID int
-	rows, err := a.db.Query("UPDATE author SET name=$1, age=$2, description=$3, userID=$4 WHERE id=$5 RETURNING id",
-		author.Name, author.Age, author.Description, author.UserID, id)
-	if err != nil {
+// The author stops being primary if it is moved to another user.
+func (a AuthorRepo) Update(ctx context.Context, id int, author model.Author) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	if err := checkUser(ctx, a.db, author.UserID); err != nil {
 		return 0, err
 	}
 
-	if rows.Next() {
-		err = rows.Scan(&updatedID)
-		if err != nil {
-			return 0, err
-		}
+	var updatedID int
+	err := a.db.QueryRowContext(ctx, `UPDATE author SET name=$1, age=$2, description=$3, userID=$4, is_primary=is_primary AND userID=$4,
+		updated_at=now(), updated_by=$6 WHERE id=$5 AND deleted_at IS NULL RETURNING id`,
+		author.Name, author.Age, author.Description, author.UserID, id, auditActor(ctx)).Scan(&updatedID)
+	if err != nil {
+		return 0, mapError(err, "author")
 	}
-	return updatedID, rows.Err()
+
+	return updatedID, nil
 }
 
-// Delete deletes author and returns deleted id.
-func (a AuthorRepo) Delete(id int) (int, error) {
+// Delete soft deletes author and returns deleted id, a deleted author isn't primary.
+func (a AuthorRepo) Delete(ctx context.Context, id int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
 	var delID int
-	rows, err := a.db.Query("DELETE FROM author WHERE id=$1 RETURNING id ", id)
+	err := a.db.QueryRowContext(ctx, "UPDATE author SET deleted_at=now(), is_primary=false, updated_at=now(), updated_by=$2 WHERE id=$1 AND deleted_at IS NULL RETURNING id",
+		id, auditActor(ctx)).Scan(&delID)
 	if err != nil {
-		return 0, err
+		return 0, mapError(err, "author")
 	}
 
-	if rows.Next() {
-		err = rows.Scan(&delID)
-		if err != nil {
-			return 0, err
-		}
+	return delID, nil
+}
+
+// Restore restores the deleted author and returns id.
+// Authors of deleted users can't be restored.
+func (a AuthorRepo) Restore(ctx context.Context, id int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	var restoredID int
+	err := a.db.QueryRowContext(ctx, `UPDATE author SET deleted_at=NULL, updated_at=now(), updated_by=$2
+		WHERE id=$1 AND deleted_at IS NOT NULL AND EXISTS (SELECT 1 FROM users WHERE id = author.userID AND deleted_at IS NULL)
+		RETURNING id`, id, auditActor(ctx)).Scan(&restoredID)
+	if err != nil {
+		return 0, mapError(err, "author")
 	}
 
-	return delID, rows.Err()
+	return restoredID, nil
 }
 
-// FindByID finds author by id.
-func (a AuthorRepo) FindByID(id int) (*model.Author, error) {
-	var author model.Author
-	rows, err := a.db.Query("SELECT * FROM author WHERE id=$1", id)
+// Purge hard deletes authors deleted before the time and returns their count.
+func (a AuthorRepo) Purge(ctx context.Context, before time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	res, err := a.db.ExecContext(ctx, "DELETE FROM author WHERE deleted_at < $1", before)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	if rows.Next() {
-		err = rows.Scan(&author.ID, &author.Name, &author.Age, &author.Description, &author.UserID)
-		if err != nil {
-			return nil, err
-		}
+	count, err := res.RowsAffected()
+	return int(count), err
+}
+
+// FindByID finds author by id.
+func (a AuthorRepo) FindByID(ctx context.Context, id int) (*model.Author, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	var author model.Author
+	err := a.db.QueryRowContext(ctx, "SELECT "+authorColumns+" FROM author WHERE id=$1 AND deleted_at IS NULL", id).Scan(authorFields(&author)...)
+	if err != nil {
+		return nil, mapError(err, "author")
 	}
 
-	return &author, rows.Err()
+	return &author, nil
 }
 
 // IsExistByID checks if author exist.
-func (u AuthorRepo) IsExistByID(id int) (bool, error) {
-	existingAuthor, err := u.FindByID(id)
-	if err != nil && !strings.Contains(err.Error(), "sql: Rows are closed") {
-		return false, err
-	} else if existingAuthor.ID != 0 {
-		return true, nil
-	}
+func (a AuthorRepo) IsExistByID(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	var exist bool
+	err := a.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM author WHERE id = $1 AND deleted_at IS NULL)", id).Scan(&exist)
+	return exist, err
+}
+
+// ExistByIDs checks which of the authors exist.
+func (a AuthorRepo) ExistByIDs(ctx context.Context, ids []int) (map[int]bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
 
-	return false, nil
+	return existByIDs(ctx, a.db, "SELECT id FROM author WHERE id = ANY($1) AND deleted_at IS NULL", ids)
 }
 
-// FindByUserID finds author by user id.
-func (a AuthorRepo) FindByUserID(id int) (*model.Author, error) {
+// FindPrimary finds the primary author of the user, the oldest author is found if none is marked.
+func (a AuthorRepo) FindPrimary(ctx context.Context, userID int) (*model.Author, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
 	var author model.Author
-	rows, err := a.db.Query("SELECT * FROM author WHERE userID=$1", id)
+	err := a.db.QueryRowContext(ctx, "SELECT "+authorColumns+" FROM author WHERE userID=$1 AND deleted_at IS NULL ORDER BY is_primary DESC, id LIMIT 1", userID).Scan(authorFields(&author)...)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err, "author")
+	}
+
+	return &author, nil
+}
+
+// SetPrimary marks author as primary for its user, the previous primary one is unmarked. It returns id.
+func (a AuthorRepo) SetPrimary(ctx context.Context, id int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
 	}
 
-	if rows.Next() {
-		err = rows.Scan(&author.ID, &author.Name, &author.Age, &author.Description, &author.UserID)
+	defer func() {
 		if err != nil {
-			return nil, err
+			_ = tx.Rollback()
 		}
+	}()
+
+	_, err = tx.ExecContext(ctx, "UPDATE author SET is_primary=false WHERE is_primary AND id<>$1 AND userID=(SELECT userID FROM author WHERE id=$1)", id)
+	if err != nil {
+		return 0, mapError(err, "author")
+	}
+
+	var updatedID int
+	err = tx.QueryRowContext(ctx, "UPDATE author SET is_primary=true, updated_at=now(), updated_by=$2 WHERE id=$1 AND deleted_at IS NULL RETURNING id",
+		id, auditActor(ctx)).Scan(&updatedID)
+	if err != nil {
+		return 0, mapError(err, "author")
+	}
+
+	return updatedID, tx.Commit()
+}
+
+// Transfer moves author to another user and returns id.
+// The author stops being primary, the new user keeps its primary author.
+func (a AuthorRepo) Transfer(ctx context.Context, id, userID int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	if err := checkUser(ctx, a.db, userID); err != nil {
+		return 0, err
+	}
+
+	var updatedID int
+	err := a.db.QueryRowContext(ctx, "UPDATE author SET userID=$1, is_primary=false, updated_at=now(), updated_by=$3 WHERE id=$2 AND deleted_at IS NULL RETURNING id",
+		userID, id, auditActor(ctx)).Scan(&updatedID)
+	if err != nil {
+		return 0, mapError(err, "author")
 	}
 
-	return &author, rows.Err()
+	return updatedID, nil
 }
 
 // FindByName finds authors by name.
-func (a AuthorRepo) FindByName(name string) ([]model.Author, error) {
+func (a AuthorRepo) FindByName(ctx context.Context, name string, filter model.AuthorFilter) ([]model.Author, error) {
+	filter.Name = name
+	return a.FindAll(ctx, filter)
+}
+
+// FindAll finds authors matching the filter, ordered by the sort field and id.
+// Only authors after the cursor are returned if it is set.
+func (a AuthorRepo) FindAll(ctx context.Context, filter model.AuthorFilter) ([]model.Author, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
 	var authors []model.Author
 	var author model.Author
-	rows, err := a.db.Query("SELECT * FROM author WHERE name=$1", name)
+	where, args := authorWhere(filter, true)
+
+	query := "SELECT " + authorColumns + " FROM author" + where + " ORDER BY " + authorOrder(filter)
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += " LIMIT $" + strconv.Itoa(len(args))
+	}
+
+	rows, err := a.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	for i := 0; rows.Next(); i++ {
-		err = rows.Scan(&author.ID, &author.Name, &author.Age, &author.Description, &author.UserID)
+	for rows.Next() {
+		err = rows.Scan(authorFields(&author)...)
 		if err != nil {
 			return nil, err
 		}
@@ -139,22 +262,126 @@ func (a AuthorRepo) FindByName(name string) ([]model.Author, error) {
 	return authors, rows.Err()
 }
 
-// FindAll finds authors.
-func (a AuthorRepo) FindAll() ([]model.Author, error) {
-	var authors []model.Author
-	var author model.Author
-	rows, err := a.db.Query("SELECT * FROM author")
+// Search finds authors whose name or description matches the words of the query or their prefixes,
+// names similar to the query are found too. Authors are ordered by relevance.
+// Highlights are HTML escaped, only the <mark> tags around matches are left as markup.
+func (a AuthorRepo) Search(ctx context.Context, query string, limit int) ([]model.AuthorMatch, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	var matches []model.AuthorMatch
+	var match model.AuthorMatch
+	rows, err := a.db.QueryContext(ctx, `
+		SELECT `+authorColumns+`,
+		       ts_rank(search, q) + word_similarity($2, name) AS rank,
+		       ts_headline('simple', name, q, $4 || ', HighlightAll=true'),
+		       ts_headline('simple', description, q, $4 || ', MaxWords=20, MinWords=5')
+		FROM author, to_tsquery('simple', $1) q
+		WHERE (search @@ q OR $2 <% name) AND deleted_at IS NULL
+		ORDER BY rank DESC, id
+		LIMIT $3`,
+		prefixTSQuery(query), query, limit, "StartSel="+highlightStart+", StopSel="+highlightStop)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	for i := 0; rows.Next(); i++ {
-		err = rows.Scan(&author.ID, &author.Name, &author.Age, &author.Description, &author.UserID)
+	for rows.Next() {
+		err = rows.Scan(append(authorFields(&match.Author), &match.Rank, &match.NameHighlight, &match.DescriptionHighlight)...)
 		if err != nil {
 			return nil, err
 		}
-		authors = append(authors, author)
+		match.NameHighlight = highlight(match.NameHighlight)
+		match.DescriptionHighlight = highlight(match.DescriptionHighlight)
+		matches = append(matches, match)
 	}
 
-	return authors, rows.Err()
+	return matches, rows.Err()
+}
+
+// highlight escapes the headline and marks its matches with <mark> tags.
+func highlight(headline string) string {
+	return highlighter.Replace(html.EscapeString(headline))
+}
+
+// prefixTSQuery turns the words of the query into a tsquery matching all of them as prefixes.
+func prefixTSQuery(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i := range words {
+		words[i] += ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
+
+// Count counts authors matching the filter, the cursor and the limit are ignored.
+func (a AuthorRepo) Count(ctx context.Context, filter model.AuthorFilter) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	var count int
+	where, args := authorWhere(filter, false)
+	err := a.db.QueryRowContext(ctx, "SELECT count(*) FROM author"+where, args...).Scan(&count)
+	return count, err
+}
+
+// authorWhere builds the WHERE clause of the filter with its args, deleted authors never match.
+func authorWhere(filter model.AuthorFilter, withCursor bool) (string, []interface{}) {
+	conditions := []string{"deleted_at IS NULL"}
+	var args []interface{}
+	add := func(condition string, values ...interface{}) {
+		for _, v := range values {
+			args = append(args, v)
+			condition = strings.Replace(condition, "?", "$"+strconv.Itoa(len(args)), 1)
+		}
+		conditions = append(conditions, condition)
+	}
+
+	if filter.Name != "" {
+		add("name=?", filter.Name)
+	}
+	if filter.UserID != 0 {
+		add("userID=?", filter.UserID)
+	}
+	if filter.MinAge != 0 {
+		add("age>=?", filter.MinAge)
+	}
+	if filter.MaxAge != 0 {
+		add("age<=?", filter.MaxAge)
+	}
+
+	if withCursor && filter.After != nil {
+		op := ">"
+		if filter.Desc {
+			op = "<"
+		}
+
+		switch filter.SortBy {
+		case model.AuthorSortName:
+			add("(name, id)"+op+"(?, ?)", filter.After.Name, filter.After.ID)
+		case model.AuthorSortAge:
+			add("(age, id)"+op+"(?, ?)", filter.After.Age, filter.After.ID)
+		default:
+			add("id"+op+"?", filter.After.ID)
+		}
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// authorOrder builds the ORDER BY clause of the filter, id breaks ties between equal values.
+func authorOrder(filter model.AuthorFilter) string {
+	dir := " ASC"
+	if filter.Desc {
+		dir = " DESC"
+	}
+
+	switch filter.SortBy {
+	case model.AuthorSortName, model.AuthorSortAge:
+		return filter.SortBy + dir + ", id" + dir
+	default:
+		return "id" + dir
+	}
 }
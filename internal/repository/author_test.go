@@ -1,11 +1,14 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
 	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
 	testAssert "github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -45,10 +48,10 @@ func TestAuthorRepo_Create(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			deleteAuthorData(assert, db)
 
-			userID, err := repos.User.Create(tc.user)
+			userID, err := repos.User.Create(context.Background(), tc.user)
 			assert.Nil(err)
 			tc.author.UserID = userID
-			id, err := repos.Author.Create(tc.author)
+			id, err := repos.Author.Create(context.Background(), tc.author)
 			assert.Nil(err)
 
 			assert.NotZero(id)
@@ -110,17 +113,21 @@ func TestAuthorRepo_Update(t *testing.T) {
 			var authorID int
 			deleteAuthorData(assert, db)
 
-			userID, err := repos.User.Create(tc.user)
+			userID, err := repos.User.Create(context.Background(), tc.user)
 			assert.Nil(err)
 
 			if tc.isOk {
 				tc.author.UserID = userID
-				authorID, err = repos.Author.Create(tc.author)
+				authorID, err = repos.Author.Create(context.Background(), tc.author)
 				assert.Nil(err)
 			}
 
 			tc.update.UserID = userID
-			id, err := repos.Author.Update(authorID, tc.update)
+			id, err := repos.Author.Update(context.Background(), authorID, tc.update)
+			if !tc.isOk {
+				assert.ErrorIs(err, domain.ErrNotFound)
+				return
+			}
 			assert.Nil(err)
 			assert.Equal(authorID, id)
 
@@ -170,17 +177,26 @@ func TestAuthorRepo_Delete(t *testing.T) {
 			var authorID int
 			deleteAuthorData(assert, db)
 
-			userID, err := repos.User.Create(tc.user)
+			userID, err := repos.User.Create(context.Background(), tc.user)
 			assert.Nil(err)
 			if tc.isOk {
 				tc.author.UserID = userID
-				authorID, err = repos.Author.Create(tc.author)
+				authorID, err = repos.Author.Create(context.Background(), tc.author)
 				assert.Nil(err)
 			}
 
-			id, err := repos.Author.Delete(authorID)
+			id, err := repos.Author.Delete(context.Background(), authorID)
+			if !tc.isOk {
+				assert.ErrorIs(err, domain.ErrNotFound)
+				return
+			}
 			assert.Nil(err)
 			assert.Equal(authorID, id)
+			_, err = repos.Author.FindByID(context.Background(), authorID)
+			assert.ErrorIs(err, domain.ErrNotFound)
+			exist, err := repos.Author.IsExistByID(context.Background(), authorID)
+			assert.Nil(err)
+			assert.False(exist)
 
 			deleteAuthorData(assert, db)
 		})
@@ -189,6 +205,110 @@ func TestAuthorRepo_Delete(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestAuthorRepo_Restore(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	tt := []struct {
+		name        string
+		deleted     bool
+		userDeleted bool
+		expErr      error
+	}{
+		{
+			name:   "not deleted author not found",
+			expErr: domain.ErrNotFound,
+		},
+		{
+			name:        "deleted user not found",
+			deleted:     true,
+			userDeleted: true,
+			expErr:      domain.ErrNotFound,
+		},
+		{
+			name:    "all ok",
+			deleted: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			deleteAuthorData(assert, db)
+			userID, err := repos.User.Create(context.Background(), model.User{
+				Login:    "test",
+				Password: "test",
+				RoleID:   dto.USER,
+			})
+			assert.Nil(err)
+			authorID, err := repos.Author.Create(context.Background(), model.Author{
+				Name:   "test",
+				UserID: userID,
+			})
+			assert.Nil(err)
+			if tc.deleted {
+				_, err = repos.Author.Delete(context.Background(), authorID)
+				assert.Nil(err)
+			}
+			if tc.userDeleted {
+				_, err = repos.User.Delete(context.Background(), userID, 0)
+				assert.Nil(err)
+			}
+
+			id, err := repos.Author.Restore(context.Background(), authorID)
+			if tc.expErr != nil {
+				assert.ErrorIs(err, tc.expErr)
+			} else {
+				assert.Nil(err)
+				assert.Equal(authorID, id)
+				author, err := repos.Author.FindByID(context.Background(), authorID)
+				assert.Nil(err)
+				assert.Equal("test", author.Name)
+			}
+
+			deleteAuthorData(assert, db)
+		})
+	}
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestAuthorRepo_Purge(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	deleteAuthorData(assert, db)
+
+	userID, err := repos.User.Create(context.Background(), model.User{
+		Login:    "test",
+		Password: "test",
+		RoleID:   dto.USER,
+	})
+	assert.Nil(err)
+	active, err := repos.Author.Create(context.Background(), model.Author{Name: "active", UserID: userID})
+	assert.Nil(err)
+	deleted, err := repos.Author.Create(context.Background(), model.Author{Name: "deleted", UserID: userID})
+	assert.Nil(err)
+	_, err = repos.Author.Delete(context.Background(), deleted)
+	assert.Nil(err)
+
+	count, err := repos.Author.Purge(context.Background(), time.Now().Add(-time.Hour))
+	assert.Nil(err)
+	assert.Equal(0, count)
+
+	count, err = repos.Author.Purge(context.Background(), time.Now().Add(time.Hour))
+	assert.Nil(err)
+	assert.Equal(1, count)
+	_, err = repos.Author.Restore(context.Background(), deleted)
+	assert.ErrorIs(err, domain.ErrNotFound)
+	exist, err := repos.Author.IsExistByID(context.Background(), active)
+	assert.Nil(err)
+	assert.True(exist)
+
+	deleteAuthorData(assert, db)
+	err = db.Close()
+	require.NoError(t, err)
+}
+
 func TestAuthorRepo_FindByID(t *testing.T) {
 	assert := testAssert.New(t)
 	db, repos, err := Connect2Repositories()
@@ -240,17 +360,23 @@ func TestAuthorRepo_FindByID(t *testing.T) {
 			var authorID int
 			deleteAuthorData(assert, db)
 
-			userID, err := repos.User.Create(tc.user)
+			userID, err := repos.User.Create(context.Background(), tc.user)
 			assert.Nil(err)
 			if tc.isOk {
 				tc.author.UserID = userID
-				authorID, err = repos.Author.Create(tc.author)
+				authorID, err = repos.Author.Create(context.Background(), tc.author)
 				assert.Nil(err)
 				tc.exp.UserID = userID
 			}
-			author, err := repos.Author.FindByID(authorID)
+			author, err := repos.Author.FindByID(context.Background(), authorID)
+			if !tc.isOk {
+				assert.ErrorIs(err, domain.ErrNotFound)
+				return
+			}
 			assert.Nil(err)
+			assert.False(author.CreatedAt.IsZero())
 			tc.exp.ID = authorID
+			tc.exp.Audit = author.Audit
 			assert.Equal(tc.exp, author)
 
 			deleteAuthorData(assert, db)
@@ -260,69 +386,68 @@ func TestAuthorRepo_FindByID(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func TestAuthorRepo_FindByUserID(t *testing.T) {
+func TestAuthorRepo_FindPrimary(t *testing.T) {
 	assert := testAssert.New(t)
 	db, repos, err := Connect2Repositories()
 	require.NoError(t, err)
 	tt := []struct {
-		name   string
-		isOk   bool
-		user   model.User
-		author model.Author
-		exp    *model.Author
+		name    string
+		isOk    bool
+		authors []string
+		primary int
+		exp     string
 	}{
 		{
-			name: "find err",
-			user: model.User{
-				Login:    "test",
-				Password: "test",
-				RoleID:   dto.USER,
-			},
-			author: model.Author{
-				Name:        "test",
-				Age:         1,
-				Description: "test",
-			},
-			exp: &model.Author{},
+			name: "no authors",
 		},
 		{
-			name: "all ok",
-			isOk: true,
-			user: model.User{
-				Login:    "test",
-				Password: "test",
-				RoleID:   dto.USER,
-			},
-			author: model.Author{
-				Name:        "test",
-				Age:         1,
-				Description: "test",
-			},
-			exp: &model.Author{
-				Name:        "test",
-				Age:         1,
-				Description: "test",
-			},
+			name:    "oldest author if none is primary",
+			isOk:    true,
+			authors: []string{"first", "second"},
+			primary: -1,
+			exp:     "first",
+		},
+		{
+			name:    "primary author",
+			isOk:    true,
+			authors: []string{"first", "second"},
+			primary: 1,
+			exp:     "second",
 		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			var authorID int
 			deleteAuthorData(assert, db)
 
-			userID, err := repos.User.Create(tc.user)
+			userID, err := repos.User.Create(context.Background(), model.User{
+				Login:    "test",
+				Password: "test",
+			})
 			assert.Nil(err)
-			if tc.isOk {
-				tc.author.UserID = userID
-				authorID, err = repos.Author.Create(tc.author)
+			for i, name := range tc.authors {
+				id, err := repos.Author.Create(context.Background(), model.Author{
+					Name:        name,
+					Age:         1,
+					Description: "test",
+					UserID:      userID,
+				})
 				assert.Nil(err)
-				tc.exp.UserID = userID
+				if i == tc.primary {
+					_, err = repos.Author.SetPrimary(context.Background(), id)
+					assert.Nil(err)
+				}
+			}
+
+			author, err := repos.Author.FindPrimary(context.Background(), userID)
+			if !tc.isOk {
+				assert.ErrorIs(err, domain.ErrNotFound)
+				deleteAuthorData(assert, db)
+				return
 			}
-			author, err := repos.Author.FindByUserID(userID)
 			assert.Nil(err)
-			tc.exp.ID = authorID
-			assert.Equal(tc.exp, author)
+			assert.Equal(tc.exp, author.Name)
+			assert.Equal(tc.primary >= 0, author.Primary)
 
 			deleteAuthorData(assert, db)
 		})
@@ -331,6 +456,103 @@ func TestAuthorRepo_FindByUserID(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestAuthorRepo_SetPrimary(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+
+	deleteAuthorData(assert, db)
+
+	_, err = repos.Author.SetPrimary(context.Background(), 1)
+	assert.ErrorIs(err, domain.ErrNotFound)
+
+	userID, err := repos.User.Create(context.Background(), model.User{
+		Login:    "test",
+		Password: "test",
+	})
+	assert.Nil(err)
+
+	var ids []int
+	for _, name := range []string{"first", "second"} {
+		id, err := repos.Author.Create(context.Background(), model.Author{
+			Name:        name,
+			Age:         1,
+			Description: "test",
+			UserID:      userID,
+		})
+		assert.Nil(err)
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids {
+		updatedID, err := repos.Author.SetPrimary(context.Background(), id)
+		assert.Nil(err)
+		assert.Equal(id, updatedID)
+
+		authors, err := repos.Author.FindAll(context.Background(), model.AuthorFilter{UserID: userID})
+		assert.Nil(err)
+		for _, author := range authors {
+			assert.Equal(author.ID == id, author.Primary)
+		}
+	}
+
+	deleteAuthorData(assert, db)
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestAuthorRepo_Transfer(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+
+	deleteAuthorData(assert, db)
+
+	_, err = repos.Author.Transfer(context.Background(), 1, 1)
+	assert.ErrorIs(err, domain.ErrNotFound)
+
+	var userIDs, authorIDs []int
+	for _, login := range []string{"from", "to"} {
+		userID, err := repos.User.Create(context.Background(), model.User{
+			Login:    login,
+			Password: "test",
+		})
+		assert.Nil(err)
+		userIDs = append(userIDs, userID)
+
+		authorID, err := repos.Author.Create(context.Background(), model.Author{
+			Name:        login,
+			Age:         1,
+			Description: "test",
+			UserID:      userID,
+		})
+		assert.Nil(err)
+		_, err = repos.Author.SetPrimary(context.Background(), authorID)
+		assert.Nil(err)
+		authorIDs = append(authorIDs, authorID)
+	}
+
+	_, err = repos.Author.Transfer(context.Background(), authorIDs[0], userIDs[1]+1)
+	assert.ErrorIs(err, domain.ErrValidation)
+
+	id, err := repos.Author.Transfer(context.Background(), authorIDs[0], userIDs[1])
+	assert.Nil(err)
+	assert.Equal(authorIDs[0], id)
+
+	moved, err := repos.Author.FindByID(context.Background(), authorIDs[0])
+	assert.Nil(err)
+	assert.Equal(userIDs[1], moved.UserID)
+	assert.False(moved.Primary)
+
+	primary, err := repos.Author.FindPrimary(context.Background(), userIDs[1])
+	assert.Nil(err)
+	assert.Equal(authorIDs[1], primary.ID)
+
+	deleteAuthorData(assert, db)
+	err = db.Close()
+	require.NoError(t, err)
+}
+
 func TestAuthorRepo_IsExistByID(t *testing.T) {
 	assert := testAssert.New(t)
 	db, repos, err := Connect2Repositories()
@@ -377,14 +599,14 @@ func TestAuthorRepo_IsExistByID(t *testing.T) {
 			var authorID int
 			deleteAuthorData(assert, db)
 
-			userID, err := repos.User.Create(tc.user)
+			userID, err := repos.User.Create(context.Background(), tc.user)
 			assert.Nil(err)
 			if tc.isOk {
 				tc.author.UserID = userID
-				authorID, err = repos.Author.Create(tc.author)
+				authorID, err = repos.Author.Create(context.Background(), tc.author)
 				assert.Nil(err)
 			}
-			author, err := repos.Author.IsExistByID(authorID)
+			author, err := repos.Author.IsExistByID(context.Background(), authorID)
 			assert.Nil(err)
 			assert.Equal(tc.exp, author)
 
@@ -395,6 +617,57 @@ func TestAuthorRepo_IsExistByID(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestAuthorRepo_ExistByIDs(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+
+	deleteAuthorData(assert, db)
+
+	userID, err := repos.User.Create(context.Background(), model.User{
+		Login:    "test",
+		Password: "test",
+		RoleID:   dto.USER,
+	})
+	require.NoError(t, err)
+
+	authorID, err := repos.Author.Create(context.Background(), model.Author{
+		Name:        "test",
+		Age:         1,
+		Description: "test",
+		UserID:      userID,
+	})
+	require.NoError(t, err)
+
+	tt := []struct {
+		name string
+		ids  []int
+		exp  map[int]bool
+	}{
+		{
+			name: "no ids",
+			exp:  map[int]bool{},
+		},
+		{
+			name: "some authors not found",
+			ids:  []int{authorID, authorID + 1},
+			exp:  map[int]bool{authorID: true, authorID + 1: false},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			exist, err := repos.Author.ExistByIDs(context.Background(), tc.ids)
+			assert.Nil(err)
+			assert.Equal(tc.exp, exist)
+		})
+	}
+
+	deleteAuthorData(assert, db)
+	err = db.Close()
+	require.NoError(t, err)
+}
+
 func TestAuthorRepo_FindByName(t *testing.T) {
 	assert := testAssert.New(t)
 	db, repos, err := Connect2Repositories()
@@ -447,18 +720,19 @@ func TestAuthorRepo_FindByName(t *testing.T) {
 			var authorID int
 			deleteAuthorData(assert, db)
 
-			userID, err := repos.User.Create(tc.user)
+			userID, err := repos.User.Create(context.Background(), tc.user)
 			assert.Nil(err)
 			if tc.isOk {
 				tc.author.UserID = userID
-				authorID, err = repos.Author.Create(tc.author)
+				authorID, err = repos.Author.Create(context.Background(), tc.author)
 				assert.Nil(err)
 			}
-			authors, err := repos.Author.FindByName(tc.author.Name)
+			authors, err := repos.Author.FindByName(context.Background(), tc.author.Name, model.AuthorFilter{})
 			assert.Nil(err)
 			for i := range authors {
 				tc.exp[i].ID = authorID
 				tc.exp[i].UserID = userID
+				tc.exp[i].Audit = authors[i].Audit
 			}
 			assert.Equal(tc.exp, authors)
 
@@ -473,72 +747,256 @@ func TestAuthorRepo_FindAll(t *testing.T) {
 	assert := testAssert.New(t)
 	db, repos, err := Connect2Repositories()
 	require.NoError(t, err)
+	authors := []model.Author{
+		{
+			Name:        "b",
+			Age:         30,
+			Description: "test",
+		},
+		{
+			Name:        "a",
+			Age:         20,
+			Description: "test",
+		},
+		{
+			Name:        "a",
+			Age:         40,
+			Description: "test",
+		},
+	}
 	tt := []struct {
-		name   string
-		isOk   bool
-		user   model.User
-		author model.Author
-		exp    []model.Author
+		name     string
+		filter   func(ids []int) model.AuthorFilter
+		expOrder []int
+		expCount int
 	}{
 		{
-			name: "find err",
-			user: model.User{
-				Login:    "test",
-				Password: "test",
-				RoleID:   dto.USER,
-			},
-			author: model.Author{
-				Name:        "test",
-				Age:         1,
-				Description: "test",
+			name: "all",
+			filter: func(ids []int) model.AuthorFilter {
+				return model.AuthorFilter{}
 			},
+			expOrder: []int{0, 1, 2},
+			expCount: 3,
 		},
 		{
-			name: "all ok",
-			isOk: true,
-			user: model.User{
-				Login:    "test",
-				Password: "test",
-				RoleID:   dto.USER,
-			},
-			author: model.Author{
-				Name:        "test",
-				Age:         1,
-				Description: "test",
-			},
-			exp: []model.Author{
-				{
-					Name:        "test",
-					Age:         1,
-					Description: "test",
-				},
-			},
+			name: "limit",
+			filter: func(ids []int) model.AuthorFilter {
+				return model.AuthorFilter{
+					Limit: 2,
+				}
+			},
+			expOrder: []int{0, 1},
+			expCount: 3,
+		},
+		{
+			name: "after id",
+			filter: func(ids []int) model.AuthorFilter {
+				return model.AuthorFilter{
+					Limit: 2,
+					After: &model.AuthorCursor{ID: ids[1]},
+				}
+			},
+			expOrder: []int{2},
+			expCount: 3,
 		},
+		{
+			name: "sort by name",
+			filter: func(ids []int) model.AuthorFilter {
+				return model.AuthorFilter{
+					SortBy: model.AuthorSortName,
+				}
+			},
+			expOrder: []int{1, 2, 0},
+			expCount: 3,
+		},
+		{
+			name: "sort by name after equal name",
+			filter: func(ids []int) model.AuthorFilter {
+				return model.AuthorFilter{
+					SortBy: model.AuthorSortName,
+					After: &model.AuthorCursor{
+						ID:   ids[1],
+						Name: "a",
+					},
+				}
+			},
+			expOrder: []int{2, 0},
+			expCount: 3,
+		},
+		{
+			name: "sort by age desc",
+			filter: func(ids []int) model.AuthorFilter {
+				return model.AuthorFilter{
+					SortBy: model.AuthorSortAge,
+					Desc:   true,
+					After: &model.AuthorCursor{
+						ID:  ids[2],
+						Age: 40,
+					},
+				}
+			},
+			expOrder: []int{0, 1},
+			expCount: 3,
+		},
+		{
+			name: "age range",
+			filter: func(ids []int) model.AuthorFilter {
+				return model.AuthorFilter{
+					MinAge: 25,
+					MaxAge: 40,
+				}
+			},
+			expOrder: []int{0, 2},
+			expCount: 2,
+		},
+		{
+			name: "name and user",
+			filter: func(ids []int) model.AuthorFilter {
+				return model.AuthorFilter{
+					Name:   "a",
+					UserID: -1,
+				}
+			},
+			expCount: 0,
+		},
+	}
+
+	deleteAuthorData(assert, db)
+	userID, err := repos.User.Create(context.Background(), model.User{
+		Login:    "test",
+		Password: "test",
+	})
+	require.NoError(t, err)
+	ids := make([]int, len(authors))
+	for i := range authors {
+		authors[i].UserID = userID
+		ids[i], err = repos.Author.Create(context.Background(), authors[i])
+		require.NoError(t, err)
+		authors[i].ID = ids[i]
+		created, err := repos.Author.FindByID(context.Background(), ids[i])
+		require.NoError(t, err)
+		authors[i].Audit = created.Audit
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			var authorID int
-			deleteAuthorData(assert, db)
-
-			userID, err := repos.User.Create(tc.user)
-			assert.Nil(err)
-			if tc.isOk {
-				tc.author.UserID = userID
-				authorID, err = repos.Author.Create(tc.author)
-				assert.Nil(err)
+			var exp []model.Author
+			for _, i := range tc.expOrder {
+				exp = append(exp, authors[i])
 			}
-			authors, err := repos.Author.FindAll()
+			filter := tc.filter(ids)
+			found, err := repos.Author.FindAll(context.Background(), filter)
 			assert.Nil(err)
-			for i := range authors {
-				tc.exp[i].ID = authorID
-				tc.exp[i].UserID = userID
-			}
-			assert.Equal(tc.exp, authors)
+			assert.Equal(exp, found)
+			count, err := repos.Author.Count(context.Background(), filter)
+			assert.Nil(err)
+			assert.Equal(tc.expCount, count)
+		})
+	}
 
-			deleteAuthorData(assert, db)
+	deleteAuthorData(assert, db)
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestAuthorRepo_Search(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	authors := []model.Author{
+		{
+			Name:        "Leo Tolstoy",
+			Age:         82,
+			Description: "Wrote War and Peace",
+		},
+		{
+			Name:        "Fyodor Dostoevsky",
+			Age:         59,
+			Description: "Admired Tolstoy",
+		},
+		{
+			Name:        "Anton Chekhov",
+			Age:         44,
+			Description: "Short stories",
+		},
+		{
+			Name:        "<b>Ivan</b> Turgenev",
+			Age:         64,
+			Description: "<script>alert('Turgenev')</script>",
+		},
+	}
+	tt := []struct {
+		name          string
+		query         string
+		limit         int
+		expOrder      []int
+		expHighlights []string
+	}{
+		{
+			name:  "no match",
+			query: "pushkin",
+			limit: 10,
+		},
+		{
+			name:          "prefix",
+			query:         "tolst",
+			limit:         10,
+			expOrder:      []int{0, 1},
+			expHighlights: []string{"Leo <mark>Tolstoy</mark>", "Fyodor Dostoevsky"},
+		},
+		{
+			name:          "limit",
+			query:         "tolst",
+			limit:         1,
+			expOrder:      []int{0},
+			expHighlights: []string{"Leo <mark>Tolstoy</mark>"},
+		},
+		{
+			name:          "typo",
+			query:         "chekov",
+			limit:         10,
+			expOrder:      []int{2},
+			expHighlights: []string{"Anton Chekhov"},
+		},
+		{
+			name:          "escaped",
+			query:         "turgenev",
+			limit:         10,
+			expOrder:      []int{3},
+			expHighlights: []string{"&lt;b&gt;Ivan&lt;/b&gt; <mark>Turgenev</mark>"},
+		},
+	}
+
+	deleteAuthorData(assert, db)
+	userID, err := repos.User.Create(context.Background(), model.User{
+		Login:    "test",
+		Password: "test",
+	})
+	require.NoError(t, err)
+	for i := range authors {
+		authors[i].UserID = userID
+		authors[i].ID, err = repos.Author.Create(context.Background(), authors[i])
+		require.NoError(t, err)
+		created, err := repos.Author.FindByID(context.Background(), authors[i].ID)
+		require.NoError(t, err)
+		authors[i].Audit = created.Audit
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			matches, err := repos.Author.Search(context.Background(), tc.query, tc.limit)
+			assert.Nil(err)
+			assert.Len(matches, len(tc.expOrder))
+			for i, match := range matches {
+				assert.Equal(authors[tc.expOrder[i]], match.Author)
+				assert.Equal(tc.expHighlights[i], match.NameHighlight)
+				assert.NotContains(match.DescriptionHighlight, "<script>")
+				assert.Greater(match.Rank, float64(0))
+			}
 		})
 	}
+
+	deleteAuthorData(assert, db)
 	err = db.Close()
 	require.NoError(t, err)
 }
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/model"
+)
+
+// RefreshTokenRepo is a refresh token repository.
+type RefreshTokenRepo struct {
+	db      tracedDB
+	timeout time.Duration
+}
+
+// NewRefreshTokenRepo is a RefreshTokenRepo constructor.
+func NewRefreshTokenRepo(db *sql.DB, timeout time.Duration) *RefreshTokenRepo {
+	return &RefreshTokenRepo{db: newTracedDB(db, "RefreshTokenRepo"), timeout: timeout}
+}
+
+// Create saves refresh token and returns id.
+func (r RefreshTokenRepo) Create(ctx context.Context, token model.RefreshToken) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var id int
+	err := r.db.QueryRowContext(ctx, "INSERT INTO refresh_tokens (userID, token, family, expires_at) VALUES ($1,$2,$3,$4) RETURNING id",
+		token.UserID, token.Token, token.Family, token.ExpiresAt).Scan(&id)
+	if err != nil {
+		return 0, mapError(err, "refresh token")
+	}
+
+	return id, nil
+}
+
+// FindByToken finds refresh token by its hashed value.
+func (r RefreshTokenRepo) FindByToken(ctx context.Context, token string) (*model.RefreshToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var refreshToken model.RefreshToken
+	err := r.db.QueryRowContext(ctx, "SELECT id, userID, token, family, expires_at, revoked FROM refresh_tokens WHERE token = $1", token).Scan(&refreshToken.ID, &refreshToken.UserID, &refreshToken.Token, &refreshToken.Family, &refreshToken.ExpiresAt, &refreshToken.Revoked)
+	if err != nil {
+		return nil, mapError(err, "refresh token")
+	}
+
+	return &refreshToken, nil
+}
+
+// Revoke revokes refresh token and returns id.
+// Not found error means the token has been already revoked.
+func (r RefreshTokenRepo) Revoke(ctx context.Context, id int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var revokedID int
+	err := r.db.QueryRowContext(ctx, "UPDATE refresh_tokens SET revoked = true WHERE id = $1 AND NOT revoked RETURNING id", id).Scan(&revokedID)
+	if err != nil {
+		return 0, mapError(err, "refresh token")
+	}
+
+	return revokedID, nil
+}
+
+// RevokeFamily revokes all refresh tokens issued by the rotation of one login and returns their count.
+func (r RefreshTokenRepo) RevokeFamily(ctx context.Context, family string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, "UPDATE refresh_tokens SET revoked = true WHERE family = $1 AND NOT revoked", family)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := res.RowsAffected()
+	return int(count), err
+}
+
+// RevokeByUserID revokes all refresh tokens of the user and returns their count.
+func (r RefreshTokenRepo) RevokeByUserID(ctx context.Context, userID int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, "UPDATE refresh_tokens SET revoked = true WHERE userID = $1 AND NOT revoked", userID)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := res.RowsAffected()
+	return int(count), err
+}
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/model"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func deleteRefreshTokenData(assertions *testAssert.Assertions, db *sql.DB) {
+	_, err := db.Exec("DELETE FROM refresh_tokens")
+	assertions.Nil(err)
+	_, err = db.Exec("DELETE FROM users")
+	assertions.Nil(err)
+}
+
+func TestRefreshTokenRepo_Create(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	tt := []struct {
+		name  string
+		user  model.User
+		token model.RefreshToken
+	}{
+		{
+			name: "all ok",
+			user: model.User{
+				Login:    "test",
+				Password: "test",
+			},
+			token: model.RefreshToken{
+				Token:     "test",
+				Family:    "test",
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			deleteRefreshTokenData(assert, db)
+
+			userID, err := repos.User.Create(context.Background(), tc.user)
+			assert.Nil(err)
+			tc.token.UserID = userID
+			id, err := repos.RefreshToken.Create(context.Background(), tc.token)
+			assert.Nil(err)
+			assert.NotZero(id)
+
+			deleteRefreshTokenData(assert, db)
+		})
+	}
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestRefreshTokenRepo_FindByToken(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	tt := []struct {
+		name  string
+		isOk  bool
+		user  model.User
+		token model.RefreshToken
+		exp   *model.RefreshToken
+	}{
+		{
+			name: "find err",
+			user: model.User{
+				Login:    "test",
+				Password: "test",
+			},
+			token: model.RefreshToken{
+				Token:     "test",
+				Family:    "test",
+				ExpiresAt: expiresAt,
+			},
+			exp: &model.RefreshToken{},
+		},
+		{
+			name: "all ok",
+			isOk: true,
+			user: model.User{
+				Login:    "test",
+				Password: "test",
+			},
+			token: model.RefreshToken{
+				Token:     "test",
+				Family:    "test",
+				ExpiresAt: expiresAt,
+			},
+			exp: &model.RefreshToken{
+				Token:  "test",
+				Family: "test",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var id int
+			deleteRefreshTokenData(assert, db)
+
+			userID, err := repos.User.Create(context.Background(), tc.user)
+			assert.Nil(err)
+			if tc.isOk {
+				tc.token.UserID = userID
+				id, err = repos.RefreshToken.Create(context.Background(), tc.token)
+				assert.Nil(err)
+				tc.exp.UserID = userID
+			}
+			token, err := repos.RefreshToken.FindByToken(context.Background(), tc.token.Token)
+			if !tc.isOk {
+				assert.ErrorIs(err, domain.ErrNotFound)
+				return
+			}
+			assert.Nil(err)
+			tc.exp.ID = id
+			if tc.isOk {
+				assert.True(expiresAt.Equal(token.ExpiresAt))
+				tc.exp.ExpiresAt = token.ExpiresAt
+			}
+			assert.Equal(tc.exp, token)
+
+			deleteRefreshTokenData(assert, db)
+		})
+	}
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestRefreshTokenRepo_Revoke(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	tt := []struct {
+		name      string
+		isOk      bool
+		isRevoked bool
+		user      model.User
+		token     model.RefreshToken
+	}{
+		{
+			name: "not found",
+			user: model.User{
+				Login:    "test",
+				Password: "test",
+			},
+		},
+		{
+			name:      "already revoked",
+			isOk:      true,
+			isRevoked: true,
+			user: model.User{
+				Login:    "test",
+				Password: "test",
+			},
+			token: model.RefreshToken{
+				Token:     "test",
+				Family:    "test",
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+		},
+		{
+			name: "all ok",
+			isOk: true,
+			user: model.User{
+				Login:    "test",
+				Password: "test",
+			},
+			token: model.RefreshToken{
+				Token:     "test",
+				Family:    "test",
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var tokenID int
+			deleteRefreshTokenData(assert, db)
+
+			userID, err := repos.User.Create(context.Background(), tc.user)
+			assert.Nil(err)
+			if tc.isOk {
+				tc.token.UserID = userID
+				tokenID, err = repos.RefreshToken.Create(context.Background(), tc.token)
+				assert.Nil(err)
+			}
+			if tc.isRevoked {
+				_, err = repos.RefreshToken.Revoke(context.Background(), tokenID)
+				assert.Nil(err)
+			}
+
+			id, err := repos.RefreshToken.Revoke(context.Background(), tokenID)
+			if !tc.isOk || tc.isRevoked {
+				assert.ErrorIs(err, domain.ErrNotFound)
+				deleteRefreshTokenData(assert, db)
+				return
+			}
+			assert.Nil(err)
+			assert.Equal(tokenID, id)
+
+			deleteRefreshTokenData(assert, db)
+		})
+	}
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestRefreshTokenRepo_RevokeFamily(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	tt := []struct {
+		name     string
+		user     model.User
+		tokens   []model.RefreshToken
+		family   string
+		expCount int
+	}{
+		{
+			name: "not found",
+			user: model.User{
+				Login:    "test",
+				Password: "test",
+			},
+			family: "test",
+		},
+		{
+			name: "all ok",
+			user: model.User{
+				Login:    "test",
+				Password: "test",
+			},
+			tokens: []model.RefreshToken{
+				{
+					Token:     "test",
+					Family:    "test",
+					ExpiresAt: time.Now().Add(time.Hour),
+				},
+				{
+					Token:     "test1",
+					Family:    "test",
+					ExpiresAt: time.Now().Add(time.Hour),
+				},
+				{
+					Token:     "test2",
+					Family:    "other",
+					ExpiresAt: time.Now().Add(time.Hour),
+				},
+			},
+			family:   "test",
+			expCount: 2,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			deleteRefreshTokenData(assert, db)
+
+			userID, err := repos.User.Create(context.Background(), tc.user)
+			assert.Nil(err)
+			for i := range tc.tokens {
+				tc.tokens[i].UserID = userID
+				_, err = repos.RefreshToken.Create(context.Background(), tc.tokens[i])
+				assert.Nil(err)
+			}
+
+			count, err := repos.RefreshToken.RevokeFamily(context.Background(), tc.family)
+			assert.Nil(err)
+			assert.Equal(tc.expCount, count)
+
+			deleteRefreshTokenData(assert, db)
+		})
+	}
+	err = db.Close()
+	require.NoError(t, err)
+}
@@ -1,50 +1,186 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
+	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
+	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/lib/pq"
+)
+
+const (
+	foreignKeyViolation = pq.ErrorCode("23503")
+	uniqueViolation     = pq.ErrorCode("23505")
 )
 
 // User is an interface for UserRepo methods.
 type User interface {
-	Create(user model.User) (int, error)
-	FindByID(id int) (*model.User, error)
-	FindByLogin(login string) (*model.User, error)
-	FindByCredentials(user model.User) (*model.User, error)
-	IsExist(login string) (bool, error)
-	IsExistByID(id int) (bool, error)
+	Create(ctx context.Context, user model.User) (int, error)
+	FindByID(ctx context.Context, id int) (*model.User, error)
+	FindByLogin(ctx context.Context, login string) (*model.User, error)
+	UpdatePassword(ctx context.Context, id int, password string) (int, error)
+	FindAll(ctx context.Context, limit, offset int) ([]model.User, error)
+	Count(ctx context.Context) (int, error)
+	SetDisabled(ctx context.Context, id int, disabled bool) (int, error)
+	ResetPassword(ctx context.Context, id int, password string) (int, error)
+	FindAccount(ctx context.Context, id int) (*auth.Account, error)
+	RevokeTokens(ctx context.Context, id int) (int, error)
+	RecordFailedLogin(ctx context.Context, id, maxFailures int, backoff, lockout time.Duration) (time.Time, error)
+	ResetFailedLogins(ctx context.Context, id int) (int, error)
+	Delete(ctx context.Context, id, reassignTo int) (int, error)
+	Restore(ctx context.Context, id int) (int, error)
+	Purge(ctx context.Context, before time.Time) (int, error)
+	IsExist(ctx context.Context, login string) (bool, error)
+	IsExistByID(ctx context.Context, id int) (bool, error)
+	ExistByIDs(ctx context.Context, ids []int) (map[int]bool, error)
+}
+
+// RefreshToken is an interface for RefreshTokenRepo methods.
+type RefreshToken interface {
+	Create(ctx context.Context, token model.RefreshToken) (int, error)
+	FindByToken(ctx context.Context, token string) (*model.RefreshToken, error)
+	Revoke(ctx context.Context, id int) (int, error)
+	RevokeFamily(ctx context.Context, family string) (int, error)
+	RevokeByUserID(ctx context.Context, userID int) (int, error)
 }
 
 // UserRole is an interface for UserRoleRepo methods.
 type UserRole interface {
-	FindAllUser() ([]model.User, error)
+	FindAllUser(ctx context.Context) ([]model.User, error)
+	FindAllPermissions(ctx context.Context) ([]model.RolePermission, error)
+	UpdateRole(ctx context.Context, userID, roleID int) (int, error)
 }
 
 // Author is an interface for AuthorRepo methods.
 type Author interface {
-	Create(author model.Author) (int, error)
-	Update(id int, author model.Author) (int, error)
-	Delete(id int) (int, error)
-	FindByID(id int) (*model.Author, error)
-	IsExistByID(id int) (bool, error)
-	FindByUserID(id int) (*model.Author, error)
-	FindByName(name string) ([]model.Author, error)
-	FindAll() ([]model.Author, error)
+	Create(ctx context.Context, author model.Author) (int, error)
+	Update(ctx context.Context, id int, author model.Author) (int, error)
+	Delete(ctx context.Context, id int) (int, error)
+	Restore(ctx context.Context, id int) (int, error)
+	Purge(ctx context.Context, before time.Time) (int, error)
+	FindByID(ctx context.Context, id int) (*model.Author, error)
+	IsExistByID(ctx context.Context, id int) (bool, error)
+	ExistByIDs(ctx context.Context, ids []int) (map[int]bool, error)
+	FindPrimary(ctx context.Context, userID int) (*model.Author, error)
+	SetPrimary(ctx context.Context, id int) (int, error)
+	Transfer(ctx context.Context, id, userID int) (int, error)
+	FindByName(ctx context.Context, name string, filter model.AuthorFilter) ([]model.Author, error)
+	FindAll(ctx context.Context, filter model.AuthorFilter) ([]model.Author, error)
+	Count(ctx context.Context, filter model.AuthorFilter) (int, error)
+	Search(ctx context.Context, query string, limit int) ([]model.AuthorMatch, error)
 }
 
 // Repositories collects all repository interfaces.
 type Repositories struct {
-	User     User
-	UserRole UserRole
-	Author   Author
+	User         User
+	RefreshToken RefreshToken
+	UserRole     UserRole
+	Author       Author
 }
 
-// NewRepositories is a Repositories constructor.
-func NewRepositories(db *sql.DB) *Repositories {
+// NewRepositories is a Repositories constructor, every query is bounded by the timeout.
+func NewRepositories(db *sql.DB, timeout time.Duration) *Repositories {
 	return &Repositories{
-		User:     NewUserRepo(db),
-		UserRole: NewUserRoleRepo(db),
-		Author:   NewAuthorRepo(db),
+		User:         NewUserRepo(db, timeout),
+		RefreshToken: NewRefreshTokenRepo(db, timeout),
+		UserRole:     NewUserRoleRepo(db, timeout),
+		Author:       NewAuthorRepo(db, timeout),
+	}
+}
+
+// mapError turns a missing row and violated constraints into domain errors about the entity.
+func mapError(err error, entity string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Errorf(domain.ErrNotFound, "%s not found", entity)
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case uniqueViolation:
+			return domain.Errorf(domain.ErrConflict, "%s already exists", entity)
+		case foreignKeyViolation:
+			return domain.Errorf(domain.ErrValidation, "%s refers to a missing record", entity)
+		}
+	}
+
+	return err
+}
+
+// auditActor returns the id of the user making the change, it is NULL for anonymous users and other services.
+func auditActor(ctx context.Context) sql.NullInt64 {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.UserID == 0 {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: int64(principal.UserID), Valid: true}
+}
+
+// checkUser fails with a validation error if the user is missing or deleted.
+func checkUser(ctx context.Context, db queryer, id int) error {
+	var exist bool
+	err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL)", id).Scan(&exist)
+	if err != nil {
+		return err
+	}
+
+	if !exist {
+		return domain.Errorf(domain.ErrValidation, "user %d not found", id)
+	}
+
+	return nil
+}
+
+// checkLastAdmin fails with a conflict if the user is the only active admin.
+// Active admins stay locked until the end of the transaction, so concurrent changes can't remove all of them.
+func checkLastAdmin(ctx context.Context, db queryer, id int) error {
+	var admins int
+	var isAdmin bool
+	err := db.QueryRowContext(ctx, "SELECT count(*), coalesce(bool_or(id = $2), false) FROM (SELECT id FROM users WHERE roleID = $1 AND NOT disabled AND deleted_at IS NULL ORDER BY id FOR UPDATE) admins",
+		dto.ADMIN, id).Scan(&admins, &isAdmin)
+	if err != nil {
+		return err
+	}
+
+	if isAdmin && admins == 1 {
+		return domain.Errorf(domain.ErrConflict, "user is the last admin")
+	}
+
+	return nil
+}
+
+// existByIDs runs the query selecting found ids out of the $1 array, every id is a key of the result.
+func existByIDs(ctx context.Context, db queryer, query string, ids []int) (map[int]bool, error) {
+	exist := make(map[int]bool, len(ids))
+	arg := make(pq.Int64Array, 0, len(ids))
+	for _, id := range ids {
+		exist[id] = false
+		arg = append(arg, int64(id))
 	}
+
+	if len(ids) == 0 {
+		return exist, nil
+	}
+
+	rows, err := db.QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		exist[id] = true
+	}
+
+	return exist, rows.Err()
 }
@@ -1,10 +1,13 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/config"
+	"github.com/JesusG2000/hexsatisfaction/pkg/database/migrate"
 	"github.com/JesusG2000/hexsatisfaction/pkg/database/pg"
+	"github.com/JesusG2000/hexsatisfaction/pkg/migrations"
 	"github.com/pkg/errors"
 )
 
@@ -21,7 +24,16 @@ func Connect2Repositories() (*sql.DB, *Repositories, error) {
 		return nil, nil, errors.Wrap(err, "couldn't create pg model")
 	}
 
-	repos := NewRepositories(db)
+	migrator, err := migrate.NewMigrator(db, migrations.FS)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "couldn't read migrations")
+	}
+
+	if err := migrator.Up(context.Background()); err != nil {
+		return nil, nil, errors.Wrap(err, "couldn't migrate pg database")
+	}
+
+	repos := NewRepositories(db, cfg.Pg.QueryTimeout)
 
 	return db, repos, nil
 }
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/JesusG2000/hexsatisfaction/internal/repository")
+
+// tracedDB runs every query in a span named after the repository and the SQL command.
+type tracedDB struct {
+	*sql.DB
+	repo string
+}
+
+func newTracedDB(db *sql.DB, repo string) tracedDB {
+	return tracedDB{DB: db, repo: repo}
+}
+
+// QueryRowContext runs the query returning at most one row in a span.
+func (d tracedDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return queryRow(ctx, d.DB, d.repo, query, args...)
+}
+
+// QueryContext runs the query returning rows in a span ended by closing the rows.
+func (d tracedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*tracedRows, error) {
+	return queryRows(ctx, d.DB, d.repo, query, args...)
+}
+
+// ExecContext runs the query returning no rows in a span.
+func (d tracedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return exec(ctx, d.DB, d.repo, query, args...)
+}
+
+// BeginTx starts a transaction running every query in a span.
+func (d tracedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (tracedTx, error) {
+	tx, err := d.DB.BeginTx(ctx, opts)
+	return tracedTx{Tx: tx, repo: d.repo}, err
+}
+
+// tracedTx runs every query of the transaction in a span.
+type tracedTx struct {
+	*sql.Tx
+	repo string
+}
+
+// QueryRowContext runs the query returning at most one row in a span.
+func (t tracedTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return queryRow(ctx, t.Tx, t.repo, query, args...)
+}
+
+// QueryContext runs the query returning rows in a span ended by closing the rows.
+func (t tracedTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*tracedRows, error) {
+	return queryRows(ctx, t.Tx, t.repo, query, args...)
+}
+
+// ExecContext runs the query returning no rows in a span.
+func (t tracedTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return exec(ctx, t.Tx, t.repo, query, args...)
+}
+
+// queryer is implemented by tracedDB and tracedTx.
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*tracedRows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// sqlQueryer is implemented by sql.DB and sql.Tx.
+type sqlQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// tracedRows ends the span of the query once the rows are closed, so reading them is traced too.
+type tracedRows struct {
+	*sql.Rows
+	span trace.Span
+}
+
+// Close closes the rows and ends the span with the error of reading them.
+func (r *tracedRows) Close() error {
+	err := r.Rows.Close()
+	if readErr := r.Rows.Err(); readErr != nil {
+		tracing.End(r.span, readErr)
+	} else {
+		tracing.End(r.span, err)
+	}
+
+	return err
+}
+
+func queryRow(ctx context.Context, q sqlQueryer, repo, query string, args ...interface{}) *sql.Row {
+	ctx, span := startQuery(ctx, repo, query)
+	row := q.QueryRowContext(ctx, query, args...)
+	tracing.End(span, row.Err())
+
+	return row
+}
+
+func queryRows(ctx context.Context, q sqlQueryer, repo, query string, args ...interface{}) (*tracedRows, error) {
+	ctx, span := startQuery(ctx, repo, query)
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		tracing.End(span, err)
+		return nil, err
+	}
+
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+func exec(ctx context.Context, q sqlQueryer, repo, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startQuery(ctx, repo, query)
+	res, err := q.ExecContext(ctx, query, args...)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// startQuery starts a span named like UserRepo SELECT.
+func startQuery(ctx context.Context, repo, query string) (context.Context, trace.Span) {
+	command := query
+	if fields := strings.Fields(query); len(fields) > 0 {
+		command = strings.ToUpper(fields[0])
+	}
+
+	return tracer.Start(ctx, repo+" "+command,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(tracing.DBStatement(query)...),
+	)
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracedDB_QueryContext(t *testing.T) {
+	assert := testAssert.New(t)
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+	})
+
+	db, _, err := Connect2Repositories()
+	require.NoError(t, err)
+	traced := newTracedDB(db, "TestRepo")
+
+	rows, err := traced.QueryContext(context.Background(), "SELECT generate_series(1, 3)")
+	require.NoError(t, err)
+	for rows.Next() {
+		var n int
+		assert.Nil(rows.Scan(&n))
+	}
+	assert.Empty(recorder.Ended())
+
+	assert.Nil(rows.Close())
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal("TestRepo SELECT", spans[0].Name())
+
+	_, err = traced.QueryContext(context.Background(), "SELECT bad")
+	assert.Error(err)
+	assert.Len(recorder.Ended(), 2)
+
+	err = db.Close()
+	require.NoError(t, err)
+}
@@ -1,113 +1,333 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
-	"strings"
+	"time"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
 	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
+	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
 )
 
+const userColumns = "id, login, password, roleID, disabled, password_reset_required, failed_logins, locked_until, token_version, created_at, updated_at, created_by, updated_by"
+
 // UserRepo is a user repository.
+// Deleted users are kept until they are purged, finders ignore them.
 type UserRepo struct {
-	db *sql.DB
+	db      tracedDB
+	timeout time.Duration
 }
 
 // NewUserRepo is a UserRepo constructor.
-func NewUserRepo(db *sql.DB) *UserRepo {
-	return &UserRepo{db: db}
+func NewUserRepo(db *sql.DB, timeout time.Duration) *UserRepo {
+	return &UserRepo{db: newTracedDB(db, "UserRepo"), timeout: timeout}
+}
+
+// userFields returns pointers to the fields of the user in the order of userColumns.
+func userFields(user *model.User) []interface{} {
+	return []interface{}{&user.ID, &user.Login, &user.Password, &user.RoleID, &user.Disabled, &user.PasswordResetRequired,
+		&user.FailedLogins, &user.LockedUntil, &user.TokenVersion, &user.CreatedAt, &user.UpdatedAt, &user.CreatedBy, &user.UpdatedBy}
 }
 
 // Create saves user and returns id.
-func (u UserRepo) Create(user model.User) (int, error) {
+func (u UserRepo) Create(ctx context.Context, user model.User) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
 	var id int
-	rows, err := u.db.Query("INSERT INTO users (login , password,roleID) VALUES ($1,$2,$3) RETURNING id ", user.Login, user.Password, dto.USER)
+	err := u.db.QueryRowContext(ctx, "INSERT INTO users (login, password, roleID, created_by, updated_by) VALUES ($1,$2,$3,$4,$4) RETURNING id", user.Login, user.Password, dto.USER, auditActor(ctx)).Scan(&id)
 	if err != nil {
-		return 0, err
-	}
-	if rows.Next() {
-		err = rows.Scan(&id)
-		if err != nil {
-			return 0, err
-		}
+		return 0, mapError(err, "user")
 	}
-	return id, rows.Err()
+
+	return id, nil
 }
 
 // FindByLogin finds the user by login.
-func (u UserRepo) FindByLogin(login string) (*model.User, error) {
+func (u UserRepo) FindByLogin(ctx context.Context, login string) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
 	var user model.User
-	rows, err := u.db.Query("SELECT * FROM users WHERE login = $1", login)
+	err := u.db.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE login = $1 AND deleted_at IS NULL", login).Scan(userFields(&user)...)
+	if err != nil {
+		return nil, mapError(err, "user")
+	}
+
+	return &user, nil
+}
+
+// FindByID finds the user by id.
+func (u UserRepo) FindByID(ctx context.Context, id int) (*model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	var user model.User
+	err := u.db.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE id = $1 AND deleted_at IS NULL", id).Scan(userFields(&user)...)
+	if err != nil {
+		return nil, mapError(err, "user")
+	}
+
+	return &user, nil
+}
+
+// UpdatePassword updates user password and returns id.
+func (u UserRepo) UpdatePassword(ctx context.Context, id int, password string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	var updatedID int
+	err := u.db.QueryRowContext(ctx, "UPDATE users SET password=$1, password_reset_required=false, updated_at=now(), updated_by=$3 WHERE id=$2 AND deleted_at IS NULL RETURNING id",
+		password, id, auditActor(ctx)).Scan(&updatedID)
+	if err != nil {
+		return 0, mapError(err, "user")
+	}
+
+	return updatedID, nil
+}
+
+// FindAll finds a page of users ordered by id.
+func (u UserRepo) FindAll(ctx context.Context, limit, offset int) ([]model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	var users []model.User
+	var user model.User
+	rows, err := u.db.QueryContext(ctx, "SELECT "+userColumns+" FROM users WHERE deleted_at IS NULL ORDER BY id LIMIT $1 OFFSET $2", limit, offset)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	if rows.Next() {
-		err = rows.Scan(&user.ID, &user.Login, &user.Password, &user.RoleID)
+	for rows.Next() {
+		err = rows.Scan(userFields(&user)...)
 		if err != nil {
 			return nil, err
 		}
+		users = append(users, user)
 	}
 
-	return &user, rows.Err()
+	return users, rows.Err()
 }
 
-// FindByLogin finds the user by id.
-func (u UserRepo) FindByID(id int) (*model.User, error) {
-	var user model.User
-	rows, err := u.db.Query("SELECT * FROM users WHERE id = $1", id)
+// Count counts users.
+func (u UserRepo) Count(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	var count int
+	err := u.db.QueryRowContext(ctx, "SELECT count(*) FROM users WHERE deleted_at IS NULL").Scan(&count)
+	return count, err
+}
+
+// SetDisabled disables or enables the user and returns id, the last admin can't be disabled.
+func (u UserRepo) SetDisabled(ctx context.Context, id int, disabled bool) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	tx, err := u.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	if rows.Next() {
-		err = rows.Scan(&user.ID, &user.Login, &user.Password, &user.RoleID)
+	defer func() {
 		if err != nil {
-			return nil, err
+			_ = tx.Rollback()
+		}
+	}()
+
+	if disabled {
+		if err = checkLastAdmin(ctx, tx, id); err != nil {
+			return 0, err
 		}
 	}
 
-	return &user, rows.Err()
+	var updatedID int
+	err = tx.QueryRowContext(ctx, "UPDATE users SET disabled=$1, updated_at=now(), updated_by=$3 WHERE id=$2 AND deleted_at IS NULL RETURNING id",
+		disabled, id, auditActor(ctx)).Scan(&updatedID)
+	if err != nil {
+		return 0, mapError(err, "user")
+	}
+
+	return updatedID, tx.Commit()
 }
 
-// FindByCredentials finds the user by credentials.
-func (u UserRepo) FindByCredentials(user model.User) (*model.User, error) {
-	var newUser model.User
-	rows, err := u.db.Query("SELECT * FROM users WHERE login = $1 AND password = $2", user.Login, user.Password)
+// ResetPassword replaces user password with a temporary one, which must be changed, unlocks the user and returns id.
+func (u UserRepo) ResetPassword(ctx context.Context, id int, password string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	var updatedID int
+	err := u.db.QueryRowContext(ctx, `UPDATE users SET password=$1, password_reset_required=true, failed_logins=0, locked_until=NULL,
+		updated_at=now(), updated_by=$3 WHERE id=$2 AND deleted_at IS NULL RETURNING id`, password, id, auditActor(ctx)).Scan(&updatedID)
 	if err != nil {
-		return nil, err
+		return 0, mapError(err, "user")
+	}
+
+	return updatedID, nil
+}
+
+// FindAccount finds the state of the user which decides if its access tokens are accepted.
+func (u UserRepo) FindAccount(ctx context.Context, id int) (*auth.Account, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	var account auth.Account
+	err := u.db.QueryRowContext(ctx, "SELECT disabled, password_reset_required, token_version FROM users WHERE id = $1 AND deleted_at IS NULL", id).
+		Scan(&account.Disabled, &account.PasswordResetRequired, &account.TokenVersion)
+	if err != nil {
+		return nil, mapError(err, "user")
 	}
 
-	if rows.Next() {
-		err = rows.Scan(&newUser.ID, &newUser.Login, &newUser.Password, &newUser.RoleID)
+	return &account, nil
+}
+
+// RevokeTokens revokes access tokens issued to the user until now and returns id.
+func (u UserRepo) RevokeTokens(ctx context.Context, id int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	var updatedID int
+	err := u.db.QueryRowContext(ctx, "UPDATE users SET token_version=token_version+1 WHERE id=$1 RETURNING id", id).Scan(&updatedID)
+	if err != nil {
+		return 0, mapError(err, "user")
+	}
+
+	return updatedID, nil
+}
+
+// RecordFailedLogin counts a failed login of the user and locks logins until the returned time.
+// The lock lasts backoff doubled per previous failure, after maxFailures it lasts lockout.
+func (u UserRepo) RecordFailedLogin(ctx context.Context, id, maxFailures int, backoff, lockout time.Duration) (time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	var lockedUntil time.Time
+	err := u.db.QueryRowContext(ctx, `UPDATE users SET failed_logins = failed_logins + 1,
+		locked_until = now() + CASE WHEN failed_logins + 1 >= $2 THEN $4::float8
+			ELSE LEAST($3::float8 * power(2, failed_logins), $4::float8) END * interval '1 second'
+		WHERE id = $1 RETURNING locked_until`, id, maxFailures, backoff.Seconds(), lockout.Seconds()).Scan(&lockedUntil)
+	if err != nil {
+		return time.Time{}, mapError(err, "user")
+	}
+
+	return lockedUntil, nil
+}
+
+// ResetFailedLogins forgets failed logins of the user and returns id.
+func (u UserRepo) ResetFailedLogins(ctx context.Context, id int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	var updatedID int
+	err := u.db.QueryRowContext(ctx, "UPDATE users SET failed_logins=0, locked_until=NULL WHERE id=$1 RETURNING id", id).Scan(&updatedID)
+	if err != nil {
+		return 0, mapError(err, "user")
+	}
+
+	return updatedID, nil
+}
+
+// Delete soft deletes the user and returns deleted id.
+// Authors of the user are moved to the reassignTo user or deleted if reassignTo is zero,
+// moved authors aren't primary so the reassignTo user keeps its primary author.
+// The last admin can't be deleted.
+func (u UserRepo) Delete(ctx context.Context, id, reassignTo int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
 		if err != nil {
-			return nil, err
+			_ = tx.Rollback()
 		}
+	}()
+
+	if err = checkLastAdmin(ctx, tx, id); err != nil {
+		return 0, err
+	}
+
+	actor := auditActor(ctx)
+	if reassignTo != 0 {
+		if err = checkUser(ctx, tx, reassignTo); err != nil {
+			return 0, err
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE author SET userID=$1, is_primary=false, updated_at=now(), updated_by=$3 WHERE userID=$2 AND deleted_at IS NULL", reassignTo, id, actor)
+	} else {
+		_, err = tx.ExecContext(ctx, "UPDATE author SET deleted_at=now(), is_primary=false, updated_at=now(), updated_by=$2 WHERE userID=$1 AND deleted_at IS NULL", id, actor)
+	}
+	if err != nil {
+		return 0, mapError(err, "user")
 	}
 
-	return &newUser, rows.Err()
+	var delID int
+	err = tx.QueryRowContext(ctx, "UPDATE users SET deleted_at=now(), updated_at=now(), updated_by=$2 WHERE id=$1 AND deleted_at IS NULL RETURNING id", id, actor).Scan(&delID)
+	if err != nil {
+		return 0, mapError(err, "user")
+	}
+
+	return delID, tx.Commit()
 }
 
-// IsExist checks if user exist by login.
-func (u UserRepo) IsExist(login string) (bool, error) {
-	existingUser, err := u.FindByLogin(login)
-	if err != nil && !strings.Contains(err.Error(), "sql: Rows are closed") {
-		return false, err
-	} else if existingUser.ID != 0 {
-		return true, nil
+// Restore restores the deleted user and returns id, authors deleted with the user stay deleted.
+func (u UserRepo) Restore(ctx context.Context, id int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	var restoredID int
+	err := u.db.QueryRowContext(ctx, "UPDATE users SET deleted_at=NULL, updated_at=now(), updated_by=$2 WHERE id=$1 AND deleted_at IS NOT NULL RETURNING id",
+		id, auditActor(ctx)).Scan(&restoredID)
+	if err != nil {
+		return 0, mapError(err, "user")
 	}
 
-	return false, nil
+	return restoredID, nil
 }
 
-// IsExist checks if user exist.
-func (u UserRepo) IsExistByID(id int) (bool, error) {
-	existingUser, err := u.FindByID(id)
-	if err != nil && !strings.Contains(err.Error(), "sql: Rows are closed") {
-		return false, err
-	} else if existingUser.ID != 0 {
-		return true, nil
+// Purge hard deletes users deleted before the time and returns their count.
+// Users still owning authors, even deleted ones, are kept until the authors are purged.
+func (u UserRepo) Purge(ctx context.Context, before time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	res, err := u.db.ExecContext(ctx, "DELETE FROM users WHERE deleted_at < $1 AND NOT EXISTS (SELECT 1 FROM author WHERE author.userID = users.id)", before)
+	if err != nil {
+		return 0, err
 	}
 
-	return false, nil
+	count, err := res.RowsAffected()
+	return int(count), err
+}
+
+// IsExist checks if user exist by login.
+func (u UserRepo) IsExist(ctx context.Context, login string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	var exist bool
+	err := u.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM users WHERE login = $1 AND deleted_at IS NULL)", login).Scan(&exist)
+	return exist, err
+}
+
+// IsExistByID checks if user exist.
+func (u UserRepo) IsExistByID(ctx context.Context, id int) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	var exist bool
+	err := u.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL)", id).Scan(&exist)
+	return exist, err
+}
+
+// ExistByIDs checks which of the users exist.
+func (u UserRepo) ExistByIDs(ctx context.Context, ids []int) (map[int]bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return existByIDs(ctx, u.db, "SELECT id FROM users WHERE id = ANY($1) AND deleted_at IS NULL", ids)
 }
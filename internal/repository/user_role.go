@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
 	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
@@ -9,26 +11,31 @@ import (
 
 // UserRoleRepo is a user role repository.
 type UserRoleRepo struct {
-	db *sql.DB
+	db      tracedDB
+	timeout time.Duration
 }
 
 // NewUserRoleRepo is a UserRoleRepo constructor.
-func NewUserRoleRepo(db *sql.DB) *UserRoleRepo {
-	return &UserRoleRepo{db: db}
+func NewUserRoleRepo(db *sql.DB, timeout time.Duration) *UserRoleRepo {
+	return &UserRoleRepo{db: newTracedDB(db, "UserRoleRepo"), timeout: timeout}
 }
 
 // FindAllUser finds users.
-func (u UserRoleRepo) FindAllUser() ([]model.User, error) {
+func (u UserRoleRepo) FindAllUser(ctx context.Context) ([]model.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
 	var users []model.User
 	var user model.User
-	rows, err := u.db.Query("SELECT u.id , u.login , u.password , u.roleID FROM users u INNER JOIN user_role ur ON u.roleID=ur.id WHERE u.roleID=$1", dto.USER)
+	rows, err := u.db.QueryContext(ctx, "SELECT u.id , u.login , u.password , u.roleID, u.disabled, u.password_reset_required FROM users u INNER JOIN user_role ur ON u.roleID=ur.id WHERE u.roleID=$1 AND u.deleted_at IS NULL", dto.USER)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	for i := 0; rows.Next(); i++ {
 
-		err = rows.Scan(&user.ID, &user.Login, &user.Password, &user.RoleID)
+		err = rows.Scan(&user.ID, &user.Login, &user.Password, &user.RoleID, &user.Disabled, &user.PasswordResetRequired)
 		if err != nil {
 			return nil, err
 		}
@@ -37,3 +44,58 @@ func (u UserRoleRepo) FindAllUser() ([]model.User, error) {
 
 	return users, rows.Err()
 }
+
+// FindAllPermissions finds permissions granted to roles.
+func (u UserRoleRepo) FindAllPermissions(ctx context.Context) ([]model.RolePermission, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	var permissions []model.RolePermission
+	var permission model.RolePermission
+	rows, err := u.db.QueryContext(ctx, "SELECT rp.roleID, p.name FROM role_permission rp INNER JOIN permission p ON rp.permissionID=p.id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err = rows.Scan(&permission.RoleID, &permission.Permission)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, rows.Err()
+}
+
+// UpdateRole changes the role of the user and returns user id, the last admin can't be demoted.
+func (u UserRoleRepo) UpdateRole(ctx context.Context, userID, roleID int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if roleID != dto.ADMIN {
+		if err = checkLastAdmin(ctx, tx, userID); err != nil {
+			return 0, err
+		}
+	}
+
+	var updatedID int
+	err = tx.QueryRowContext(ctx, "UPDATE users SET roleID=$1, updated_at=now(), updated_by=$3 WHERE id=$2 AND deleted_at IS NULL RETURNING id", roleID, userID, auditActor(ctx)).Scan(&updatedID)
+	if err != nil {
+		return 0, mapError(err, "user")
+	}
+
+	return updatedID, tx.Commit()
+}
@@ -1,10 +1,12 @@
 package repository
 
 import (
+	"context"
 	"testing"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
 	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
 	testAssert "github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -58,12 +60,12 @@ func TestUserRole_FindAllUser(t *testing.T) {
 			assert.Nil(err)
 			if tc.isOk {
 				for i := range tc.users {
-					id, err := repos.User.Create(tc.users[i])
+					id, err := repos.User.Create(context.Background(), tc.users[i])
 					assert.Nil(err)
 					tc.expUsers[i].ID = id
 				}
 			}
-			users, err := repos.UserRole.FindAllUser()
+			users, err := repos.UserRole.FindAllUser(context.Background())
 			assert.Nil(err)
 			assert.Equal(tc.expUsers, users)
 			if tc.isOk {
@@ -75,3 +77,71 @@ func TestUserRole_FindAllUser(t *testing.T) {
 	err = db.Close()
 	require.NoError(t, err)
 }
+
+func TestUserRole_FindAllPermissions(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+
+	permissions, err := repos.UserRole.FindAllPermissions(context.Background())
+	assert.Nil(err)
+	assert.Contains(permissions, model.RolePermission{RoleID: dto.ADMIN, Permission: dto.UserList})
+	assert.Contains(permissions, model.RolePermission{RoleID: dto.USER, Permission: dto.AuthorWrite})
+	assert.NotContains(permissions, model.RolePermission{RoleID: dto.USER, Permission: dto.UserList})
+
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestUserRole_UpdateRole(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	tt := []struct {
+		name   string
+		isOk   bool
+		roleID int
+	}{
+		{
+			name:   "user not found errors",
+			roleID: dto.ADMIN,
+		},
+		{
+			name:   "all ok",
+			isOk:   true,
+			roleID: dto.ADMIN,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var id int
+			_, err := db.Exec("DELETE FROM users")
+			assert.Nil(err)
+			if tc.isOk {
+				id, err = repos.User.Create(context.Background(), model.User{
+					Login:    "test",
+					Password: "test",
+					RoleID:   dto.USER,
+				})
+				assert.Nil(err)
+			}
+			updatedID, err := repos.UserRole.UpdateRole(context.Background(), id, tc.roleID)
+			if !tc.isOk {
+				assert.ErrorIs(err, domain.ErrNotFound)
+				return
+			}
+			assert.Nil(err)
+			assert.Equal(id, updatedID)
+			if tc.isOk {
+				user, err := repos.User.FindByID(context.Background(), id)
+				assert.Nil(err)
+				assert.Equal(tc.roleID, user.RoleID)
+				_, err = db.Exec("DELETE FROM users")
+				assert.Nil(err)
+			}
+		})
+	}
+	err = db.Close()
+	require.NoError(t, err)
+}
@@ -1,32 +1,39 @@
 package repository
 
 import (
+	"context"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
 	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
+	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
 	_ "github.com/lib/pq"
 	testAssert "github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestUser_FindByCredentials(t *testing.T) {
+func TestUser_UpdatePassword(t *testing.T) {
 	assert := testAssert.New(t)
 	db, repos, err := Connect2Repositories()
 	require.NoError(t, err)
 	tt := []struct {
-		name    string
-		isOk    bool
-		user    model.User
-		expUser *model.User
+		name     string
+		isOk     bool
+		user     model.User
+		password string
+		expUser  *model.User
 	}{
 		{
 			name: "user not found errors",
 			user: model.User{
-				Login:    "not correct",
-				Password: "not correct",
+				Login:    "test",
+				Password: "test",
 			},
-			expUser: &model.User{},
+			password: "update",
+			expUser:  &model.User{},
 		},
 		{
 			name: "all ok",
@@ -35,9 +42,10 @@ func TestUser_FindByCredentials(t *testing.T) {
 				Login:    "test",
 				Password: "test",
 			},
+			password: "update",
 			expUser: &model.User{
 				Login:    "test",
-				Password: "test",
+				Password: "update",
 				RoleID:   dto.USER,
 			},
 		},
@@ -49,12 +57,21 @@ func TestUser_FindByCredentials(t *testing.T) {
 			_, err := db.Exec("DELETE FROM users")
 			assert.Nil(err)
 			if tc.isOk {
-				id, err = repos.User.Create(tc.user)
+				id, err = repos.User.Create(context.Background(), tc.user)
 				assert.Nil(err)
 			}
-			user, err := repos.User.FindByCredentials(tc.user)
+			updatedID, err := repos.User.UpdatePassword(context.Background(), id, tc.password)
+			if !tc.isOk {
+				assert.ErrorIs(err, domain.ErrNotFound)
+				return
+			}
+			assert.Nil(err)
+			assert.Equal(id, updatedID)
+			user, err := repos.User.FindByID(context.Background(), id)
 			assert.Nil(err)
+			assert.False(user.CreatedAt.IsZero())
 			tc.expUser.ID = id
+			tc.expUser.Audit = user.Audit
 			assert.Equal(tc.expUser, user)
 			if tc.isOk {
 				_, err := db.Exec("DELETE FROM users")
@@ -96,10 +113,10 @@ func TestUser_IsExist(t *testing.T) {
 			_, err := db.Exec("DELETE FROM users")
 			assert.Nil(err)
 			if tc.isOk {
-				_, err := repos.User.Create(user)
+				_, err := repos.User.Create(context.Background(), user)
 				assert.Nil(err)
 			}
-			exist, err := repos.User.IsExist(tc.login)
+			exist, err := repos.User.IsExist(context.Background(), tc.login)
 			assert.Nil(err)
 			assert.Equal(tc.expRes, exist)
 			if tc.isOk {
@@ -140,10 +157,10 @@ func TestUser_IsExistByID(t *testing.T) {
 			_, err := db.Exec("DELETE FROM users")
 			assert.Nil(err)
 			if tc.isOk {
-				id, err = repos.User.Create(user)
+				id, err = repos.User.Create(context.Background(), user)
 				assert.Nil(err)
 			}
-			exist, err := repos.User.IsExistByID(id)
+			exist, err := repos.User.IsExistByID(context.Background(), id)
 			assert.Nil(err)
 			assert.Equal(tc.expRes, exist)
 			if tc.isOk {
@@ -156,6 +173,49 @@ func TestUser_IsExistByID(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestUser_ExistByIDs(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+
+	_, err = db.Exec("DELETE FROM users")
+	require.NoError(t, err)
+
+	id, err := repos.User.Create(context.Background(), model.User{
+		Login:    "test",
+		Password: "test",
+	})
+	require.NoError(t, err)
+
+	tt := []struct {
+		name   string
+		ids    []int
+		expRes map[int]bool
+	}{
+		{
+			name:   "no ids",
+			expRes: map[int]bool{},
+		},
+		{
+			name:   "some users not found",
+			ids:    []int{id, id + 1},
+			expRes: map[int]bool{id: true, id + 1: false},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			exist, err := repos.User.ExistByIDs(context.Background(), tc.ids)
+			assert.Nil(err)
+			assert.Equal(tc.expRes, exist)
+		})
+	}
+
+	_, err = db.Exec("DELETE FROM users")
+	assert.Nil(err)
+	err = db.Close()
+	require.NoError(t, err)
+}
+
 func TestUser_FindByLogin(t *testing.T) {
 	assert := testAssert.New(t)
 	db, repos, err := Connect2Repositories()
@@ -189,10 +249,14 @@ func TestUser_FindByLogin(t *testing.T) {
 			_, err := db.Exec("DELETE FROM users")
 			assert.Nil(err)
 			if tc.isOk {
-				id, err = repos.User.Create(*tc.user)
+				id, err = repos.User.Create(context.Background(), *tc.user)
 				assert.Nil(err)
 			}
-			user, err := repos.User.FindByLogin(tc.login)
+			user, err := repos.User.FindByLogin(context.Background(), tc.login)
+			if !tc.isOk {
+				assert.ErrorIs(err, domain.ErrNotFound)
+				return
+			}
 			assert.Nil(err)
 			tc.user.ID = id
 			assert.Equal(tc.user, user)
@@ -236,10 +300,14 @@ func TestUser_FindByID(t *testing.T) {
 			_, err := db.Exec("DELETE FROM users")
 			assert.Nil(err)
 			if tc.isOk {
-				id, err = repos.User.Create(*tc.user)
+				id, err = repos.User.Create(context.Background(), *tc.user)
 				assert.Nil(err)
 			}
-			user, err := repos.User.FindByID(id)
+			user, err := repos.User.FindByID(context.Background(), id)
+			if !tc.isOk {
+				assert.ErrorIs(err, domain.ErrNotFound)
+				return
+			}
 			assert.Nil(err)
 			tc.user.ID = id
 			assert.Equal(tc.user, user)
@@ -258,12 +326,22 @@ func TestUserRepo_Create(t *testing.T) {
 	db, repos, err := Connect2Repositories()
 	require.NoError(t, err)
 	tt := []struct {
-		name string
-		isOk bool
-		user model.User
+		name  string
+		isOk  bool
+		exist bool
+		user  model.User
 	}{
+		{
+			name:  "login exists",
+			exist: true,
+			user: model.User{
+				Login:    "test",
+				Password: "test",
+			},
+		},
 		{
 			name: "all ok",
+			isOk: true,
 			user: model.User{
 				Login:    "test",
 				Password: "test",
@@ -275,9 +353,229 @@ func TestUserRepo_Create(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			_, err := db.Exec("DELETE FROM users")
 			assert.Nil(err)
-			id, err := repos.User.Create(tc.user)
+			if tc.exist {
+				_, err = repos.User.Create(context.Background(), tc.user)
+				assert.Nil(err)
+			}
+			id, err := repos.User.Create(context.Background(), tc.user)
+			if tc.isOk {
+				assert.Nil(err)
+				assert.NotZero(id)
+			} else {
+				assert.ErrorIs(err, domain.ErrConflict)
+			}
+			_, err = db.Exec("DELETE FROM users")
+			assert.Nil(err)
+		})
+	}
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestUser_FindAll(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	users := []model.User{
+		{
+			Login:    "test",
+			Password: "test",
+			RoleID:   dto.USER,
+		},
+		{
+			Login:    "test1",
+			Password: "test1",
+			RoleID:   dto.USER,
+		},
+		{
+			Login:    "test2",
+			Password: "test2",
+			RoleID:   dto.USER,
+		},
+	}
+	tt := []struct {
+		name     string
+		limit    int
+		offset   int
+		expUsers []model.User
+	}{
+		{
+			name:     "first page",
+			limit:    2,
+			expUsers: users[:2],
+		},
+		{
+			name:     "last page",
+			limit:    2,
+			offset:   2,
+			expUsers: users[2:],
+		},
+		{
+			name:   "out of range",
+			limit:  2,
+			offset: 4,
+		},
+	}
+
+	_, err = db.Exec("DELETE FROM users")
+	require.NoError(t, err)
+	for i := range users {
+		id, err := repos.User.Create(context.Background(), users[i])
+		require.NoError(t, err)
+		created, err := repos.User.FindByID(context.Background(), id)
+		require.NoError(t, err)
+		users[i].ID = id
+		users[i].Audit = created.Audit
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			found, err := repos.User.FindAll(context.Background(), tc.limit, tc.offset)
+			assert.Nil(err)
+			assert.Equal(tc.expUsers, found)
+			count, err := repos.User.Count(context.Background())
+			assert.Nil(err)
+			assert.Equal(len(users), count)
+		})
+	}
+	_, err = db.Exec("DELETE FROM users")
+	require.NoError(t, err)
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestUser_SetDisabled(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	tt := []struct {
+		name     string
+		isOk     bool
+		disabled bool
+	}{
+		{
+			name:     "user not found errors",
+			disabled: true,
+		},
+		{
+			name:     "disable",
+			isOk:     true,
+			disabled: true,
+		},
+		{
+			name: "enable",
+			isOk: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var id int
+			_, err := db.Exec("DELETE FROM users")
+			assert.Nil(err)
+			if tc.isOk {
+				id, err = repos.User.Create(context.Background(), model.User{
+					Login:    "test",
+					Password: "test",
+				})
+				assert.Nil(err)
+			}
+			updatedID, err := repos.User.SetDisabled(context.Background(), id, tc.disabled)
+			if !tc.isOk {
+				assert.ErrorIs(err, domain.ErrNotFound)
+				return
+			}
 			assert.Nil(err)
-			assert.NotZero(id)
+			assert.Equal(id, updatedID)
+			if tc.isOk {
+				user, err := repos.User.FindByID(context.Background(), id)
+				assert.Nil(err)
+				assert.Equal(tc.disabled, user.Disabled)
+				_, err = db.Exec("DELETE FROM users")
+				assert.Nil(err)
+			}
+		})
+	}
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestUser_RecordFailedLogin(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	const (
+		maxFailures = 3
+		backoff     = time.Second
+		lockout     = time.Hour
+	)
+	tt := []struct {
+		name       string
+		isOk       bool
+		failures   int
+		expLockout time.Duration
+	}{
+		{
+			name: "user not found errors",
+		},
+		{
+			name:       "first failure backs off",
+			isOk:       true,
+			failures:   1,
+			expLockout: backoff,
+		},
+		{
+			name:       "backoff doubles",
+			isOk:       true,
+			failures:   2,
+			expLockout: 2 * backoff,
+		},
+		{
+			name:       "max failures lock out",
+			isOk:       true,
+			failures:   maxFailures,
+			expLockout: lockout,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var id int
+			_, err := db.Exec("DELETE FROM users")
+			assert.Nil(err)
+			if !tc.isOk {
+				_, err = repos.User.RecordFailedLogin(context.Background(), id, maxFailures, backoff, lockout)
+				assert.ErrorIs(err, domain.ErrNotFound)
+				return
+			}
+
+			id, err = repos.User.Create(context.Background(), model.User{
+				Login:    "test",
+				Password: "test",
+			})
+			assert.Nil(err)
+
+			var lockedUntil time.Time
+			for i := 0; i < tc.failures; i++ {
+				lockedUntil, err = repos.User.RecordFailedLogin(context.Background(), id, maxFailures, backoff, lockout)
+				assert.Nil(err)
+			}
+			assert.WithinDuration(time.Now().Add(tc.expLockout), lockedUntil, backoff/2)
+
+			user, err := repos.User.FindByID(context.Background(), id)
+			assert.Nil(err)
+			assert.Equal(tc.failures, user.FailedLogins)
+			require.NotNil(t, user.LockedUntil)
+			assert.True(lockedUntil.Equal(*user.LockedUntil))
+
+			updatedID, err := repos.User.ResetFailedLogins(context.Background(), id)
+			assert.Nil(err)
+			assert.Equal(id, updatedID)
+
+			user, err = repos.User.FindByID(context.Background(), id)
+			assert.Nil(err)
+			assert.Zero(user.FailedLogins)
+			assert.Nil(user.LockedUntil)
+
 			_, err = db.Exec("DELETE FROM users")
 			assert.Nil(err)
 		})
@@ -285,3 +583,407 @@ func TestUserRepo_Create(t *testing.T) {
 	err = db.Close()
 	require.NoError(t, err)
 }
+
+func TestUser_ResetPassword(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	tt := []struct {
+		name     string
+		isOk     bool
+		password string
+		expUser  *model.User
+	}{
+		{
+			name:     "user not found errors",
+			password: "temporary",
+			expUser:  &model.User{},
+		},
+		{
+			name:     "all ok",
+			isOk:     true,
+			password: "temporary",
+			expUser: &model.User{
+				Login:                 "test",
+				Password:              "temporary",
+				RoleID:                dto.USER,
+				PasswordResetRequired: true,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var id int
+			_, err := db.Exec("DELETE FROM users")
+			assert.Nil(err)
+			if tc.isOk {
+				id, err = repos.User.Create(context.Background(), model.User{
+					Login:    "test",
+					Password: "test",
+				})
+				assert.Nil(err)
+			}
+			updatedID, err := repos.User.ResetPassword(context.Background(), id, tc.password)
+			if !tc.isOk {
+				assert.ErrorIs(err, domain.ErrNotFound)
+				return
+			}
+			assert.Nil(err)
+			assert.Equal(id, updatedID)
+			user, err := repos.User.FindByID(context.Background(), id)
+			assert.Nil(err)
+			assert.False(user.CreatedAt.IsZero())
+			tc.expUser.ID = id
+			tc.expUser.Audit = user.Audit
+			assert.Equal(tc.expUser, user)
+			if tc.isOk {
+				_, err := db.Exec("DELETE FROM users")
+				assert.Nil(err)
+			}
+		})
+	}
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestUser_Delete(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	tt := []struct {
+		name       string
+		isOk       bool
+		reassign   bool
+		expAuthors int
+	}{
+		{
+			name: "user not found errors",
+		},
+		{
+			name: "delete authors",
+			isOk: true,
+		},
+		{
+			name:       "reassign authors",
+			isOk:       true,
+			reassign:   true,
+			expAuthors: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var id, reassignTo int
+			_, err := db.Exec("DELETE FROM author")
+			assert.Nil(err)
+			_, err = db.Exec("DELETE FROM users")
+			assert.Nil(err)
+			if tc.isOk {
+				id, err = repos.User.Create(context.Background(), model.User{
+					Login:    "test",
+					Password: "test",
+				})
+				assert.Nil(err)
+				_, err = repos.Author.Create(context.Background(), model.Author{
+					Name:   "test",
+					UserID: id,
+				})
+				assert.Nil(err)
+			}
+			if tc.reassign {
+				reassignTo, err = repos.User.Create(context.Background(), model.User{
+					Login:    "test1",
+					Password: "test1",
+				})
+				assert.Nil(err)
+			}
+			delID, err := repos.User.Delete(context.Background(), id, reassignTo)
+			if !tc.isOk {
+				assert.ErrorIs(err, domain.ErrNotFound)
+				return
+			}
+			assert.Nil(err)
+			assert.Equal(id, delID)
+			exist, err := repos.User.IsExistByID(context.Background(), id)
+			assert.Nil(err)
+			assert.False(exist)
+			authors, err := repos.Author.FindAll(context.Background(), model.AuthorFilter{})
+			assert.Nil(err)
+			assert.Len(authors, tc.expAuthors)
+			for _, author := range authors {
+				assert.Equal(reassignTo, author.UserID)
+			}
+			_, err = db.Exec("DELETE FROM author")
+			assert.Nil(err)
+			_, err = db.Exec("DELETE FROM users")
+			assert.Nil(err)
+		})
+	}
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestUser_LastAdmin(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	tt := []struct {
+		name   string
+		admins int
+		fn     func(id int) (int, error)
+		isOk   bool
+	}{
+		{
+			name:   "demote the last admin",
+			admins: 1,
+			fn: func(id int) (int, error) {
+				return repos.UserRole.UpdateRole(context.Background(), id, dto.USER)
+			},
+		},
+		{
+			name:   "disable the last admin",
+			admins: 1,
+			fn: func(id int) (int, error) {
+				return repos.User.SetDisabled(context.Background(), id, true)
+			},
+		},
+		{
+			name:   "delete the last admin",
+			admins: 1,
+			fn: func(id int) (int, error) {
+				return repos.User.Delete(context.Background(), id, 0)
+			},
+		},
+		{
+			name:   "demote one of admins",
+			admins: 2,
+			fn: func(id int) (int, error) {
+				return repos.UserRole.UpdateRole(context.Background(), id, dto.USER)
+			},
+			isOk: true,
+		},
+		{
+			name:   "disable one of admins",
+			admins: 2,
+			fn: func(id int) (int, error) {
+				return repos.User.SetDisabled(context.Background(), id, true)
+			},
+			isOk: true,
+		},
+		{
+			name:   "delete one of admins",
+			admins: 2,
+			fn: func(id int) (int, error) {
+				return repos.User.Delete(context.Background(), id, 0)
+			},
+			isOk: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := db.Exec("DELETE FROM users")
+			assert.Nil(err)
+			var id int
+			for i := 0; i < tc.admins; i++ {
+				id, err = repos.User.Create(context.Background(), model.User{
+					Login:    "admin" + strconv.Itoa(i),
+					Password: "test",
+				})
+				assert.Nil(err)
+				_, err = repos.UserRole.UpdateRole(context.Background(), id, dto.ADMIN)
+				assert.Nil(err)
+			}
+			changedID, err := tc.fn(id)
+			if tc.isOk {
+				assert.Nil(err)
+				assert.Equal(id, changedID)
+			} else {
+				assert.ErrorIs(err, domain.ErrConflict)
+				user, err := repos.User.FindByID(context.Background(), id)
+				assert.Nil(err)
+				assert.Equal(dto.ADMIN, user.RoleID)
+				assert.False(user.Disabled)
+			}
+			_, err = db.Exec("DELETE FROM users")
+			assert.Nil(err)
+		})
+	}
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestUser_Restore(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	tt := []struct {
+		name    string
+		deleted bool
+		taken   bool
+		expErr  error
+	}{
+		{
+			name:   "not deleted user not found",
+			expErr: domain.ErrNotFound,
+		},
+		{
+			name:    "login taken errors",
+			deleted: true,
+			taken:   true,
+			expErr:  domain.ErrConflict,
+		},
+		{
+			name:    "all ok",
+			deleted: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := db.Exec("DELETE FROM author")
+			assert.Nil(err)
+			_, err = db.Exec("DELETE FROM users")
+			assert.Nil(err)
+			id, err := repos.User.Create(context.Background(), model.User{
+				Login:    "test",
+				Password: "test",
+			})
+			assert.Nil(err)
+			if tc.deleted {
+				_, err = repos.User.Delete(context.Background(), id, 0)
+				assert.Nil(err)
+			}
+			if tc.taken {
+				_, err = repos.User.Create(context.Background(), model.User{
+					Login:    "test",
+					Password: "test",
+				})
+				assert.Nil(err)
+			}
+
+			restoredID, err := repos.User.Restore(context.Background(), id)
+			if tc.expErr != nil {
+				assert.ErrorIs(err, tc.expErr)
+			} else {
+				assert.Nil(err)
+				assert.Equal(id, restoredID)
+				exist, err := repos.User.IsExistByID(context.Background(), id)
+				assert.Nil(err)
+				assert.True(exist)
+			}
+
+			_, err = db.Exec("DELETE FROM users")
+			assert.Nil(err)
+		})
+	}
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestUser_Purge(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	_, err = db.Exec("DELETE FROM author")
+	assert.Nil(err)
+	_, err = db.Exec("DELETE FROM users")
+	assert.Nil(err)
+
+	active, err := repos.User.Create(context.Background(), model.User{Login: "active", Password: "test"})
+	assert.Nil(err)
+	deleted, err := repos.User.Create(context.Background(), model.User{Login: "deleted", Password: "test"})
+	assert.Nil(err)
+	_, err = repos.User.Delete(context.Background(), deleted, 0)
+	assert.Nil(err)
+
+	count, err := repos.User.Purge(context.Background(), time.Now().Add(-time.Hour))
+	assert.Nil(err)
+	assert.Equal(0, count)
+
+	count, err = repos.User.Purge(context.Background(), time.Now().Add(time.Hour))
+	assert.Nil(err)
+	assert.Equal(1, count)
+	_, err = repos.User.Restore(context.Background(), deleted)
+	assert.ErrorIs(err, domain.ErrNotFound)
+	exist, err := repos.User.IsExistByID(context.Background(), active)
+	assert.Nil(err)
+	assert.True(exist)
+
+	_, err = db.Exec("DELETE FROM users")
+	assert.Nil(err)
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestUser_Audit(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	_, err = db.Exec("DELETE FROM author")
+	assert.Nil(err)
+	_, err = db.Exec("DELETE FROM users")
+	assert.Nil(err)
+
+	adminID, err := repos.User.Create(context.Background(), model.User{Login: "admin", Password: "test"})
+	assert.Nil(err)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: adminID})
+	id, err := repos.User.Create(ctx, model.User{Login: "test", Password: "test"})
+	assert.Nil(err)
+
+	admin, err := repos.User.FindByID(context.Background(), adminID)
+	assert.Nil(err)
+	assert.Nil(admin.CreatedBy)
+	user, err := repos.User.FindByID(context.Background(), id)
+	assert.Nil(err)
+	if assert.NotNil(user.CreatedBy) && assert.NotNil(user.UpdatedBy) {
+		assert.Equal(adminID, *user.CreatedBy)
+		assert.Equal(adminID, *user.UpdatedBy)
+	}
+	assert.False(user.CreatedAt.IsZero())
+
+	_, err = db.Exec("DELETE FROM users")
+	assert.Nil(err)
+	err = db.Close()
+	require.NoError(t, err)
+}
+
+func TestUser_FindAccount(t *testing.T) {
+	assert := testAssert.New(t)
+	db, repos, err := Connect2Repositories()
+	require.NoError(t, err)
+	_, err = db.Exec("DELETE FROM author")
+	assert.Nil(err)
+	_, err = db.Exec("DELETE FROM users")
+	assert.Nil(err)
+
+	id, err := repos.User.Create(context.Background(), model.User{Login: "test", Password: "test"})
+	assert.Nil(err)
+
+	account, err := repos.User.FindAccount(context.Background(), id)
+	assert.Nil(err)
+	assert.Equal(&auth.Account{}, account)
+
+	_, err = repos.User.ResetPassword(context.Background(), id, "temporary")
+	assert.Nil(err)
+	_, err = repos.User.RevokeTokens(context.Background(), id)
+	assert.Nil(err)
+	account, err = repos.User.FindAccount(context.Background(), id)
+	assert.Nil(err)
+	assert.True(account.PasswordResetRequired)
+	assert.Equal(1, account.TokenVersion)
+
+	_, err = repos.User.Delete(context.Background(), id, 0)
+	assert.Nil(err)
+	_, err = repos.User.FindAccount(context.Background(), id)
+	assert.ErrorIs(err, domain.ErrNotFound)
+
+	_, err = repos.User.RevokeTokens(context.Background(), id+1)
+	assert.ErrorIs(err, domain.ErrNotFound)
+
+	_, err = db.Exec("DELETE FROM users")
+	assert.Nil(err)
+	err = db.Close()
+	require.NoError(t, err)
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/config"
@@ -11,18 +12,35 @@ import (
 // Server represents a http server structure.
 type Server struct {
 	httpServer *http.Server
+	cancel     context.CancelFunc
 }
 
 // NewServer is a Server constructor.
+// Request contexts are cancelled when Stop returns, so queries still running after the shutdown timeout are aborted.
 func NewServer(cfg *config.Config, handler http.Handler) *Server {
+	return newServer(cfg, cfg.HTTP.Port, handler)
+}
+
+// NewAdminServer is a Server constructor for the admin port.
+func NewAdminServer(cfg *config.Config, handler http.Handler) *Server {
+	return newServer(cfg, cfg.HTTP.AdminPort, handler)
+}
+
+func newServer(cfg *config.Config, port int, handler http.Handler) *Server {
+	base, cancel := context.WithCancel(context.Background())
+
 	return &Server{
 		httpServer: &http.Server{
-			Addr:           fmt.Sprintf("%s:%d", cfg.HTTP.Host, cfg.HTTP.Port),
+			Addr:           fmt.Sprintf("%s:%d", cfg.HTTP.Host, port),
 			Handler:        handler,
 			ReadTimeout:    cfg.HTTP.ReadTimeout,
 			WriteTimeout:   cfg.HTTP.WriteTimeout,
 			MaxHeaderBytes: cfg.HTTP.MaxHeaderBytes << 20,
+			BaseContext: func(net.Listener) context.Context {
+				return base
+			},
 		},
+		cancel: cancel,
 	}
 }
 
@@ -33,5 +51,7 @@ func (s *Server) Run() error {
 
 // Stop stops a http server.
 func (s *Server) Stop(ctx context.Context) error {
+	defer s.cancel()
+
 	return s.httpServer.Shutdown(ctx)
 }
@@ -1,8 +1,11 @@
 package service
 
 import (
+	"context"
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
 	"github.com/JesusG2000/hexsatisfaction/internal/repository"
+	"github.com/JesusG2000/hexsatisfaction/pkg/cursor"
+	"github.com/JesusG2000/hexsatisfaction/pkg/metrics"
 	"github.com/pkg/errors"
 )
 
@@ -17,30 +20,31 @@ func NewAuthorService(author repository.Author) *AuthorService {
 }
 
 // Create creates author and returns id.
-func (a AuthorService) Create(request model.CreateAuthorRequest) (int, error) {
+func (a AuthorService) Create(ctx context.Context, request model.CreateAuthorRequest) (int, error) {
 	author := model.Author{
 		Name:        request.Name,
 		Age:         request.Age,
 		Description: request.Description,
 		UserID:      request.UserID,
 	}
-	id, err := a.Author.Create(author)
+	id, err := a.Author.Create(ctx, author)
 	if err != nil {
 		return 0, errors.Wrap(err, "couldn't create author")
 	}
+	metrics.AuthorsCreated.Inc()
 
 	return id, nil
 }
 
 // Update updates author and returns id.
-func (a AuthorService) Update(request model.UpdateAuthorRequest) (int, error) {
+func (a AuthorService) Update(ctx context.Context, request model.UpdateAuthorRequest) (int, error) {
 	author := model.Author{
 		Name:        request.Name,
 		Age:         request.Age,
 		Description: request.Description,
 		UserID:      request.UserID,
 	}
-	id, err := a.Author.Update(request.ID, author)
+	id, err := a.Author.Update(ctx, request.ID, author)
 	if err != nil {
 		return 0, errors.Wrap(err, "couldn't update author")
 	}
@@ -48,9 +52,9 @@ func (a AuthorService) Update(request model.UpdateAuthorRequest) (int, error) {
 	return id, nil
 }
 
-// Delete deletes author and returns deleted id.
-func (a AuthorService) Delete(request model.DeleteAuthorRequest) (int, error) {
-	id, err := a.Author.Delete(request.ID)
+// Delete soft deletes author and returns deleted id.
+func (a AuthorService) Delete(ctx context.Context, request model.DeleteAuthorRequest) (int, error) {
+	id, err := a.Author.Delete(ctx, request.ID)
 	if err != nil {
 		return 0, errors.Wrap(err, "couldn't delete author")
 	}
@@ -58,9 +62,19 @@ func (a AuthorService) Delete(request model.DeleteAuthorRequest) (int, error) {
 	return id, nil
 }
 
+// Restore restores the deleted author and returns id.
+func (a AuthorService) Restore(ctx context.Context, request model.IDAuthorRequest) (int, error) {
+	id, err := a.Author.Restore(ctx, request.ID)
+	if err != nil {
+		return 0, errors.Wrap(err, "couldn't restore author")
+	}
+
+	return id, nil
+}
+
 // FindByID finds author by id.
-func (a AuthorService) FindByID(request model.IDAuthorRequest) (*model.Author, error) {
-	author, err := a.Author.FindByID(request.ID)
+func (a AuthorService) FindByID(ctx context.Context, request model.IDAuthorRequest) (*model.Author, error) {
+	author, err := a.Author.FindByID(ctx, request.ID)
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't find author")
 	}
@@ -68,32 +82,120 @@ func (a AuthorService) FindByID(request model.IDAuthorRequest) (*model.Author, e
 	return author, nil
 }
 
-// FindByUserID finds author by user id.
-func (a AuthorService) FindByUserID(request model.UserIDAuthorRequest) (*model.Author, error) {
-	author, err := a.Author.FindByUserID(request.ID)
+// FindByUserID finds a page of authors owned by the user.
+func (a AuthorService) FindByUserID(ctx context.Context, request model.UserIDAuthorRequest) (*model.AuthorPage, error) {
+	filter := authorFilter(request.ListAuthorsRequest)
+	filter.UserID = request.ID
+
+	return a.findPage(ctx, filter, a.Author.FindAll)
+}
+
+// FindPrimary finds the primary author of the user.
+func (a AuthorService) FindPrimary(ctx context.Context, request model.PrimaryAuthorRequest) (*model.Author, error) {
+	author, err := a.Author.FindPrimary(ctx, request.UserID)
 	if err != nil {
-		return nil, errors.Wrap(err, "couldn't find author")
+		return nil, errors.Wrap(err, "couldn't find primary author")
 	}
 
 	return author, nil
 }
 
-// FindByName finds authors by name.
-func (a AuthorService) FindByName(request model.NameAuthorRequest) ([]model.Author, error) {
-	authors, err := a.Author.FindByName(request.Name)
+// SetPrimary marks author as primary for its user and returns id.
+func (a AuthorService) SetPrimary(ctx context.Context, request model.SetPrimaryAuthorRequest) (int, error) {
+	id, err := a.Author.SetPrimary(ctx, request.ID)
 	if err != nil {
-		return nil, errors.Wrap(err, "couldn't find authors")
+		return 0, errors.Wrap(err, "couldn't set primary author")
+	}
+
+	return id, nil
+}
+
+// Transfer transfers author to another user and returns id.
+func (a AuthorService) Transfer(ctx context.Context, request model.TransferAuthorRequest) (int, error) {
+	id, err := a.Author.Transfer(ctx, request.ID, request.UserID)
+	if err != nil {
+		return 0, errors.Wrap(err, "couldn't transfer author")
+	}
+
+	return id, nil
+}
+
+// FindByName finds a page of authors by name.
+func (a AuthorService) FindByName(ctx context.Context, request model.NameAuthorRequest) (*model.AuthorPage, error) {
+	filter := authorFilter(request.ListAuthorsRequest)
+	filter.Name = request.Name
+
+	return a.findPage(ctx, filter, func(ctx context.Context, filter model.AuthorFilter) ([]model.Author, error) {
+		return a.Author.FindByName(ctx, request.Name, filter)
+	})
+}
+
+// FindAll finds a page of authors.
+func (a AuthorService) FindAll(ctx context.Context, request model.ListAuthorsRequest) (*model.AuthorPage, error) {
+	return a.findPage(ctx, authorFilter(request), a.Author.FindAll)
+}
+
+// Search finds authors by name and description ordered by relevance.
+func (a AuthorService) Search(ctx context.Context, request model.SearchAuthorRequest) ([]model.AuthorMatch, error) {
+	matches, err := a.Author.Search(ctx, request.Query, request.Limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't search authors")
 	}
 
-	return authors, nil
+	return matches, nil
 }
 
-// FindAll finds authors.
-func (a AuthorService) FindAll() ([]model.Author, error) {
-	authors, err := a.Author.FindAll()
+// findPage finds one extra author to know whether the next page exists.
+// Zero limit finds all authors.
+func (a AuthorService) findPage(ctx context.Context, filter model.AuthorFilter, find func(context.Context, model.AuthorFilter) ([]model.Author, error)) (*model.AuthorPage, error) {
+	limit := filter.Limit
+	if limit > 0 {
+		filter.Limit++
+	}
+	authors, err := find(ctx, filter)
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't find authors")
 	}
 
-	return authors, nil
+	total, err := a.Author.Count(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't count authors")
+	}
+
+	page := model.AuthorPage{
+		Items: authors,
+		Total: total,
+	}
+	if limit > 0 && len(authors) > limit {
+		page.Items = authors[:limit]
+		last := page.Items[limit-1]
+		page.NextCursor, err = cursor.Encode(model.AuthorCursor{
+			ID:     last.ID,
+			Name:   last.Name,
+			Age:    last.Age,
+			SortBy: filter.SortBy,
+			Desc:   filter.Desc,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if page.Items == nil {
+		page.Items = []model.Author{}
+	}
+
+	return &page, nil
+}
+
+func authorFilter(request model.ListAuthorsRequest) model.AuthorFilter {
+	return model.AuthorFilter{
+		UserID: request.UserID,
+		MinAge: request.MinAge,
+		MaxAge: request.MaxAge,
+		SortBy: request.SortBy,
+		Desc:   request.Descending(),
+		Limit:  request.Limit,
+		After:  request.After,
+	}
 }
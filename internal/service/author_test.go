@@ -1,12 +1,15 @@
 package service
 
 import (
+	"context"
 	"testing"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
 	m "github.com/JesusG2000/hexsatisfaction/internal/service/mock"
 	"github.com/pkg/errors"
 	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAuthorService_Create(t *testing.T) {
@@ -28,7 +31,7 @@ func TestAuthorService_Create(t *testing.T) {
 				UserID:      1,
 			},
 			fn: func(author *m.Author, data test) {
-				author.On("Create", model.Author{
+				author.On("Create", mock.Anything, model.Author{
 					Name:        data.req.Name,
 					Age:         data.req.Age,
 					Description: data.req.Description,
@@ -47,7 +50,7 @@ func TestAuthorService_Create(t *testing.T) {
 				UserID:      1,
 			},
 			fn: func(author *m.Author, data test) {
-				author.On("Create", model.Author{
+				author.On("Create", mock.Anything, model.Author{
 					Name:        data.req.Name,
 					Age:         data.req.Age,
 					Description: data.req.Description,
@@ -66,7 +69,7 @@ func TestAuthorService_Create(t *testing.T) {
 			if tc.fn != nil {
 				tc.fn(author, tc)
 			}
-			id, err := service.Create(tc.req)
+			id, err := service.Create(context.Background(), tc.req)
 			if err != nil {
 				assert.Equal(tc.expErr.Error(), err.Error())
 			}
@@ -95,7 +98,7 @@ func TestAuthorService_Update(t *testing.T) {
 				UserID:      1,
 			},
 			fn: func(author *m.Author, data test) {
-				author.On("Update", data.req.ID, model.Author{
+				author.On("Update", mock.Anything, data.req.ID, model.Author{
 					Name:        data.req.Name,
 					Age:         data.req.Age,
 					Description: data.req.Description,
@@ -115,7 +118,7 @@ func TestAuthorService_Update(t *testing.T) {
 				UserID:      1,
 			},
 			fn: func(author *m.Author, data test) {
-				author.On("Update", data.req.ID, model.Author{
+				author.On("Update", mock.Anything, data.req.ID, model.Author{
 					Name:        data.req.Name,
 					Age:         data.req.Age,
 					Description: data.req.Description,
@@ -134,7 +137,7 @@ func TestAuthorService_Update(t *testing.T) {
 			if tc.fn != nil {
 				tc.fn(author, tc)
 			}
-			id, err := service.Update(tc.req)
+			id, err := service.Update(context.Background(), tc.req)
 			if err != nil {
 				assert.Equal(tc.expErr.Error(), err.Error())
 			}
@@ -160,7 +163,7 @@ func TestAuthorService_Delete(t *testing.T) {
 			},
 
 			fn: func(author *m.Author, data test) {
-				author.On("Delete", data.req.ID).
+				author.On("Delete", mock.Anything, data.req.ID).
 					Return(data.expID, errors.New(""))
 			},
 			expErr: errors.Wrap(errors.New(""), "couldn't delete author"),
@@ -172,7 +175,7 @@ func TestAuthorService_Delete(t *testing.T) {
 			},
 
 			fn: func(author *m.Author, data test) {
-				author.On("Delete", data.req.ID).
+				author.On("Delete", mock.Anything, data.req.ID).
 					Return(data.expID, nil)
 			},
 			expID: 1,
@@ -185,7 +188,56 @@ func TestAuthorService_Delete(t *testing.T) {
 			if tc.fn != nil {
 				tc.fn(author, tc)
 			}
-			id, err := service.Delete(tc.req)
+			id, err := service.Delete(context.Background(), tc.req)
+			if err != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
+			}
+			assert.Equal(tc.expID, id)
+		})
+	}
+}
+
+func TestAuthorService_Restore(t *testing.T) {
+	assert := testAssert.New(t)
+	type test struct {
+		name   string
+		req    model.IDAuthorRequest
+		fn     func(author *m.Author, data test)
+		expID  int
+		expErr error
+	}
+	tt := []test{
+		{
+			name: "Restore author errors",
+			req: model.IDAuthorRequest{
+				ID: 1,
+			},
+			fn: func(author *m.Author, data test) {
+				author.On("Restore", mock.Anything, data.req.ID).
+					Return(data.expID, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't restore author"),
+		},
+		{
+			name: "All ok",
+			req: model.IDAuthorRequest{
+				ID: 1,
+			},
+			fn: func(author *m.Author, data test) {
+				author.On("Restore", mock.Anything, data.req.ID).
+					Return(data.expID, nil)
+			},
+			expID: 1,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			author := new(m.Author)
+			service := NewAuthorService(author)
+			if tc.fn != nil {
+				tc.fn(author, tc)
+			}
+			id, err := service.Restore(context.Background(), tc.req)
 			if err != nil {
 				assert.Equal(tc.expErr.Error(), err.Error())
 			}
@@ -210,7 +262,7 @@ func TestAuthorService_FindByID(t *testing.T) {
 				ID: 1,
 			},
 			fn: func(author *m.Author, data test) {
-				author.On("FindByID", data.req.ID).
+				author.On("FindByID", mock.Anything, data.req.ID).
 					Return(data.exp, errors.New(""))
 			},
 			expErr: errors.Wrap(errors.New(""), "couldn't find author"),
@@ -221,7 +273,7 @@ func TestAuthorService_FindByID(t *testing.T) {
 				ID: 1,
 			},
 			fn: func(author *m.Author, data test) {
-				author.On("FindByID", data.req.ID).
+				author.On("FindByID", mock.Anything, data.req.ID).
 					Return(data.exp, nil)
 			},
 			exp: &model.Author{
@@ -241,7 +293,7 @@ func TestAuthorService_FindByID(t *testing.T) {
 			if tc.fn != nil {
 				tc.fn(author, tc)
 			}
-			a, err := service.FindByID(tc.req)
+			a, err := service.FindByID(context.Background(), tc.req)
 			if err != nil {
 				assert.Equal(tc.expErr.Error(), err.Error())
 			}
@@ -251,10 +303,95 @@ func TestAuthorService_FindByID(t *testing.T) {
 }
 
 func TestAuthorService_FindByUserID(t *testing.T) {
+	assert := testAssert.New(t)
+	authors := []model.Author{
+		{
+			ID:      1,
+			Name:    "some",
+			Age:     1,
+			UserID:  1,
+			Primary: true,
+		},
+		{
+			ID:     2,
+			Name:   "other",
+			Age:    2,
+			UserID: 1,
+		},
+	}
+	type test struct {
+		name    string
+		req     model.UserIDAuthorRequest
+		fn      func(author *m.Author, data test)
+		authors []model.Author
+		exp     *model.AuthorPage
+		expErr  error
+	}
+	tt := []test{
+		{
+			name: "Find errors",
+			req: model.UserIDAuthorRequest{
+				ID: 1,
+				ListAuthorsRequest: model.ListAuthorsRequest{
+					Limit: 2,
+				},
+			},
+			fn: func(author *m.Author, data test) {
+				author.On("FindAll", mock.Anything, mock.Anything).
+					Return(data.authors, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't find authors"),
+		},
+		{
+			name: "Filters by user",
+			req: model.UserIDAuthorRequest{
+				ID: 1,
+				ListAuthorsRequest: model.ListAuthorsRequest{
+					Limit:  2,
+					SortBy: model.AuthorSortID,
+					UserID: 2,
+				},
+			},
+			fn: func(author *m.Author, data test) {
+				filter := model.AuthorFilter{
+					UserID: data.req.ID,
+					SortBy: data.req.SortBy,
+					Limit:  data.req.Limit + 1,
+				}
+				author.On("FindAll", mock.Anything, filter).
+					Return(data.authors, nil)
+				author.On("Count", mock.Anything, filter).
+					Return(len(data.authors), nil)
+			},
+			authors: authors,
+			exp: &model.AuthorPage{
+				Items: authors,
+				Total: 2,
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			author := new(m.Author)
+
+			service := NewAuthorService(author)
+			if tc.fn != nil {
+				tc.fn(author, tc)
+			}
+			page, err := service.FindByUserID(context.Background(), tc.req)
+			if err != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
+			}
+			assert.Equal(tc.exp, page)
+		})
+	}
+}
+
+func TestAuthorService_FindPrimary(t *testing.T) {
 	assert := testAssert.New(t)
 	type test struct {
 		name   string
-		req    model.UserIDAuthorRequest
+		req    model.PrimaryAuthorRequest
 		fn     func(author *m.Author, data test)
 		exp    *model.Author
 		expErr error
@@ -262,22 +399,22 @@ func TestAuthorService_FindByUserID(t *testing.T) {
 	tt := []test{
 		{
 			name: "Find errors",
-			req: model.UserIDAuthorRequest{
-				ID: 1,
+			req: model.PrimaryAuthorRequest{
+				UserID: 1,
 			},
 			fn: func(author *m.Author, data test) {
-				author.On("FindByUserID", data.req.ID).
+				author.On("FindPrimary", mock.Anything, data.req.UserID).
 					Return(data.exp, errors.New(""))
 			},
-			expErr: errors.Wrap(errors.New(""), "couldn't find author"),
+			expErr: errors.Wrap(errors.New(""), "couldn't find primary author"),
 		},
 		{
 			name: "All ok",
-			req: model.UserIDAuthorRequest{
-				ID: 1,
+			req: model.PrimaryAuthorRequest{
+				UserID: 1,
 			},
 			fn: func(author *m.Author, data test) {
-				author.On("FindByUserID", data.req.ID).
+				author.On("FindPrimary", mock.Anything, data.req.UserID).
 					Return(data.exp, nil)
 			},
 			exp: &model.Author{
@@ -286,6 +423,7 @@ func TestAuthorService_FindByUserID(t *testing.T) {
 				Age:         1,
 				Description: "some",
 				UserID:      1,
+				Primary:     true,
 			},
 		},
 	}
@@ -297,7 +435,7 @@ func TestAuthorService_FindByUserID(t *testing.T) {
 			if tc.fn != nil {
 				tc.fn(author, tc)
 			}
-			a, err := service.FindByUserID(tc.req)
+			a, err := service.FindPrimary(context.Background(), tc.req)
 			if err != nil {
 				assert.Equal(tc.expErr.Error(), err.Error())
 			}
@@ -306,37 +444,170 @@ func TestAuthorService_FindByUserID(t *testing.T) {
 	}
 }
 
-func TestAuthorService_FindByName(t *testing.T) {
+func TestAuthorService_SetPrimary(t *testing.T) {
+	assert := testAssert.New(t)
+	type test struct {
+		name   string
+		req    model.SetPrimaryAuthorRequest
+		fn     func(author *m.Author, data test)
+		expID  int
+		expErr error
+	}
+	tt := []test{
+		{
+			name: "SetPrimary errors",
+			req: model.SetPrimaryAuthorRequest{
+				ID: 1,
+			},
+			fn: func(author *m.Author, data test) {
+				author.On("SetPrimary", mock.Anything, data.req.ID).
+					Return(data.expID, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't set primary author"),
+		},
+		{
+			name: "All ok",
+			req: model.SetPrimaryAuthorRequest{
+				ID: 1,
+			},
+			fn: func(author *m.Author, data test) {
+				author.On("SetPrimary", mock.Anything, data.req.ID).
+					Return(data.expID, nil)
+			},
+			expID: 1,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			author := new(m.Author)
+			service := NewAuthorService(author)
+			if tc.fn != nil {
+				tc.fn(author, tc)
+			}
+			id, err := service.SetPrimary(context.Background(), tc.req)
+			if err != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
+			}
+			assert.Equal(tc.expID, id)
+		})
+	}
+}
+
+func TestAuthorService_Transfer(t *testing.T) {
 	assert := testAssert.New(t)
 	type test struct {
 		name   string
-		req    model.NameAuthorRequest
+		req    model.TransferAuthorRequest
 		fn     func(author *m.Author, data test)
-		exp    []model.Author
+		expID  int
 		expErr error
 	}
+	tt := []test{
+		{
+			name: "Transfer errors",
+			req: model.TransferAuthorRequest{
+				ID:     1,
+				UserID: 2,
+			},
+			fn: func(author *m.Author, data test) {
+				author.On("Transfer", mock.Anything, data.req.ID, data.req.UserID).
+					Return(data.expID, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't transfer author"),
+		},
+		{
+			name: "All ok",
+			req: model.TransferAuthorRequest{
+				ID:     1,
+				UserID: 2,
+			},
+			fn: func(author *m.Author, data test) {
+				author.On("Transfer", mock.Anything, data.req.ID, data.req.UserID).
+					Return(data.expID, nil)
+			},
+			expID: 1,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			author := new(m.Author)
+			service := NewAuthorService(author)
+			if tc.fn != nil {
+				tc.fn(author, tc)
+			}
+			id, err := service.Transfer(context.Background(), tc.req)
+			if err != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
+			}
+			assert.Equal(tc.expID, id)
+		})
+	}
+}
+
+func TestAuthorService_FindByName(t *testing.T) {
+	assert := testAssert.New(t)
+	type test struct {
+		name    string
+		req     model.NameAuthorRequest
+		fn      func(author *m.Author, data test)
+		authors []model.Author
+		exp     *model.AuthorPage
+		expErr  error
+	}
 	tt := []test{
 		{
 			name: "Find errors",
 			req: model.NameAuthorRequest{
 				Name: "some",
+				ListAuthorsRequest: model.ListAuthorsRequest{
+					Limit: 10,
+				},
 			},
 			fn: func(author *m.Author, data test) {
-				author.On("FindByName", data.req.Name).
-					Return(data.exp, errors.New(""))
+				author.On("FindByName", mock.Anything, data.req.Name, mock.Anything).
+					Return(data.authors, errors.New(""))
 			},
 			expErr: errors.Wrap(errors.New(""), "couldn't find authors"),
 		},
+		{
+			name: "Count errors",
+			req: model.NameAuthorRequest{
+				Name: "some",
+				ListAuthorsRequest: model.ListAuthorsRequest{
+					Limit: 10,
+				},
+			},
+			fn: func(author *m.Author, data test) {
+				author.On("FindByName", mock.Anything, data.req.Name, mock.Anything).
+					Return(data.authors, nil)
+				author.On("Count", mock.Anything, mock.Anything).
+					Return(0, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't count authors"),
+		},
 		{
 			name: "All ok",
 			req: model.NameAuthorRequest{
 				Name: "some",
+				ListAuthorsRequest: model.ListAuthorsRequest{
+					Limit:  10,
+					SortBy: model.AuthorSortAge,
+					MinAge: 1,
+				},
 			},
 			fn: func(author *m.Author, data test) {
-				author.On("FindByName", data.req.Name).
-					Return(data.exp, nil)
+				filter := model.AuthorFilter{
+					Name:   data.req.Name,
+					MinAge: data.req.MinAge,
+					SortBy: data.req.SortBy,
+					Limit:  data.req.Limit + 1,
+				}
+				author.On("FindByName", mock.Anything, data.req.Name, filter).
+					Return(data.authors, nil)
+				author.On("Count", mock.Anything, filter).
+					Return(1, nil)
 			},
-			exp: []model.Author{
+			authors: []model.Author{
 				{
 					ID:          1,
 					Name:        "some",
@@ -345,6 +616,18 @@ func TestAuthorService_FindByName(t *testing.T) {
 					UserID:      1,
 				},
 			},
+			exp: &model.AuthorPage{
+				Items: []model.Author{
+					{
+						ID:          1,
+						Name:        "some",
+						Age:         1,
+						Description: "some",
+						UserID:      1,
+					},
+				},
+				Total: 1,
+			},
 		},
 	}
 	for _, tc := range tt {
@@ -355,7 +638,7 @@ func TestAuthorService_FindByName(t *testing.T) {
 			if tc.fn != nil {
 				tc.fn(author, tc)
 			}
-			a, err := service.FindByName(tc.req)
+			a, err := service.FindByName(context.Background(), tc.req)
 			if err != nil {
 				assert.Equal(tc.expErr.Error(), err.Error())
 			}
@@ -365,35 +648,182 @@ func TestAuthorService_FindByName(t *testing.T) {
 }
 
 func TestAuthorService_FindAll(t *testing.T) {
+	assert := testAssert.New(t)
+	authors := []model.Author{
+		{
+			ID:   1,
+			Name: "some",
+			Age:  1,
+		},
+		{
+			ID:   2,
+			Name: "other",
+			Age:  2,
+		},
+	}
+	type test struct {
+		name      string
+		req       model.ListAuthorsRequest
+		fn        func(author *m.Author, data test)
+		authors   []model.Author
+		exp       *model.AuthorPage
+		expCursor *model.AuthorCursor
+		expErr    error
+	}
+	tt := []test{
+		{
+			name: "Find errors",
+			req: model.ListAuthorsRequest{
+				Limit: 1,
+			},
+			fn: func(author *m.Author, data test) {
+				author.On("FindAll", mock.Anything, mock.Anything).
+					Return(data.authors, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't find authors"),
+		},
+		{
+			name: "Empty page",
+			req: model.ListAuthorsRequest{
+				Limit:  1,
+				SortBy: model.AuthorSortID,
+			},
+			fn: func(author *m.Author, data test) {
+				author.On("FindAll", mock.Anything, mock.Anything).
+					Return(data.authors, nil)
+				author.On("Count", mock.Anything, mock.Anything).
+					Return(0, nil)
+			},
+			exp: &model.AuthorPage{
+				Items: []model.Author{},
+			},
+		},
+		{
+			name: "Last page",
+			req: model.ListAuthorsRequest{
+				Limit:  2,
+				SortBy: model.AuthorSortID,
+			},
+			fn: func(author *m.Author, data test) {
+				author.On("FindAll", mock.Anything, mock.Anything).
+					Return(data.authors, nil)
+				author.On("Count", mock.Anything, mock.Anything).
+					Return(2, nil)
+			},
+			authors: authors,
+			exp: &model.AuthorPage{
+				Items: authors,
+				Total: 2,
+			},
+		},
+		{
+			name: "Next page",
+			req: model.ListAuthorsRequest{
+				Limit:  1,
+				SortBy: model.AuthorSortName,
+				Order:  model.OrderDesc,
+				After: &model.AuthorCursor{
+					ID:     3,
+					Name:   "test",
+					SortBy: model.AuthorSortName,
+					Desc:   true,
+				},
+			},
+			fn: func(author *m.Author, data test) {
+				filter := model.AuthorFilter{
+					SortBy: data.req.SortBy,
+					Desc:   data.req.Descending(),
+					Limit:  data.req.Limit + 1,
+					After:  data.req.After,
+				}
+				author.On("FindAll", mock.Anything, filter).
+					Return(data.authors, nil)
+				author.On("Count", mock.Anything, filter).
+					Return(3, nil)
+			},
+			authors: authors,
+			exp: &model.AuthorPage{
+				Items: authors[:1],
+				Total: 3,
+			},
+			expCursor: &model.AuthorCursor{
+				ID:     1,
+				Name:   "some",
+				Age:    1,
+				SortBy: model.AuthorSortName,
+				Desc:   true,
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			author := new(m.Author)
+
+			service := NewAuthorService(author)
+			if tc.fn != nil {
+				tc.fn(author, tc)
+			}
+			a, err := service.FindAll(context.Background(), tc.req)
+			if err != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
+			}
+			if tc.expCursor != nil {
+				var c model.AuthorCursor
+				require.NotNil(t, a)
+				err = c.UnmarshalText([]byte(a.NextCursor))
+				assert.Nil(err)
+				assert.Equal(*tc.expCursor, c)
+				a.NextCursor = ""
+			}
+			assert.Equal(tc.exp, a)
+		})
+	}
+}
+
+func TestAuthorService_Search(t *testing.T) {
 	assert := testAssert.New(t)
 	type test struct {
 		name   string
+		req    model.SearchAuthorRequest
 		fn     func(author *m.Author, data test)
-		exp    []model.Author
+		exp    []model.AuthorMatch
 		expErr error
 	}
 	tt := []test{
 		{
-			name: "Find errors",
+			name: "Search errors",
+			req: model.SearchAuthorRequest{
+				Query: "some",
+				Limit: 10,
+			},
 			fn: func(author *m.Author, data test) {
-				author.On("FindAll").
+				author.On("Search", mock.Anything, data.req.Query, data.req.Limit).
 					Return(data.exp, errors.New(""))
 			},
-			expErr: errors.Wrap(errors.New(""), "couldn't find authors"),
+			expErr: errors.Wrap(errors.New(""), "couldn't search authors"),
 		},
 		{
 			name: "All ok",
+			req: model.SearchAuthorRequest{
+				Query: "some",
+				Limit: 10,
+			},
 			fn: func(author *m.Author, data test) {
-				author.On("FindAll").
+				author.On("Search", mock.Anything, data.req.Query, data.req.Limit).
 					Return(data.exp, nil)
 			},
-			exp: []model.Author{
+			exp: []model.AuthorMatch{
 				{
-					ID:          1,
-					Name:        "some",
-					Age:         1,
-					Description: "some",
-					UserID:      1,
+					Author: model.Author{
+						ID:          1,
+						Name:        "some",
+						Age:         1,
+						Description: "some",
+						UserID:      1,
+					},
+					Rank:                 1,
+					NameHighlight:        "<mark>some</mark>",
+					DescriptionHighlight: "<mark>some</mark>",
 				},
 			},
 		},
@@ -406,7 +836,7 @@ func TestAuthorService_FindAll(t *testing.T) {
 			if tc.fn != nil {
 				tc.fn(author, tc)
 			}
-			a, err := service.FindAll()
+			a, err := service.Search(context.Background(), tc.req)
 			if err != nil {
 				assert.Equal(tc.expErr.Error(), err.Error())
 			}
@@ -3,8 +3,12 @@
 package mock
 
 import (
+	context "context"
+
 	model "github.com/JesusG2000/hexsatisfaction/internal/model"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Author is an autogenerated mock type for the Author type
@@ -12,20 +16,20 @@ type Author struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: author
-func (_m *Author) Create(author model.Author) (int, error) {
-	ret := _m.Called(author)
+// Count provides a mock function with given fields: ctx, filter
+func (_m *Author) Count(ctx context.Context, filter model.AuthorFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(model.Author) int); ok {
-		r0 = rf(author)
+	if rf, ok := ret.Get(0).(func(context.Context, model.AuthorFilter) int); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(model.Author) error); ok {
-		r1 = rf(author)
+	if rf, ok := ret.Get(1).(func(context.Context, model.AuthorFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,20 +37,64 @@ func (_m *Author) Create(author model.Author) (int, error) {
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Author) Delete(id int) (int, error) {
-	ret := _m.Called(id)
+// Create provides a mock function with given fields: ctx, author
+func (_m *Author) Create(ctx context.Context, author model.Author) (int, error) {
+	ret := _m.Called(ctx, author)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(int) int); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Author) int); ok {
+		r0 = rf(ctx, author)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, model.Author) error); ok {
+		r1 = rf(ctx, author)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *Author) Delete(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExistByIDs provides a mock function with given fields: ctx, ids
+func (_m *Author) ExistByIDs(ctx context.Context, ids []int) (map[int]bool, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 map[int]bool
+	if rf, ok := ret.Get(0).(func(context.Context, []int) map[int]bool); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]bool)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -54,13 +102,13 @@ func (_m *Author) Delete(id int) (int, error) {
 	return r0, r1
 }
 
-// FindAll provides a mock function with given fields:
-func (_m *Author) FindAll() ([]model.Author, error) {
-	ret := _m.Called()
+// FindAll provides a mock function with given fields: ctx, filter
+func (_m *Author) FindAll(ctx context.Context, filter model.AuthorFilter) ([]model.Author, error) {
+	ret := _m.Called(ctx, filter)
 
 	var r0 []model.Author
-	if rf, ok := ret.Get(0).(func() []model.Author); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, model.AuthorFilter) []model.Author); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.Author)
@@ -68,8 +116,8 @@ func (_m *Author) FindAll() ([]model.Author, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, model.AuthorFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -77,13 +125,13 @@ func (_m *Author) FindAll() ([]model.Author, error) {
 	return r0, r1
 }
 
-// FindByID provides a mock function with given fields: id
-func (_m *Author) FindByID(id int) (*model.Author, error) {
-	ret := _m.Called(id)
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *Author) FindByID(ctx context.Context, id int) (*model.Author, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 *model.Author
-	if rf, ok := ret.Get(0).(func(int) *model.Author); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.Author); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Author)
@@ -91,8 +139,8 @@ func (_m *Author) FindByID(id int) (*model.Author, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -100,13 +148,13 @@ func (_m *Author) FindByID(id int) (*model.Author, error) {
 	return r0, r1
 }
 
-// FindByName provides a mock function with given fields: name
-func (_m *Author) FindByName(name string) ([]model.Author, error) {
-	ret := _m.Called(name)
+// FindByName provides a mock function with given fields: ctx, name, filter
+func (_m *Author) FindByName(ctx context.Context, name string, filter model.AuthorFilter) ([]model.Author, error) {
+	ret := _m.Called(ctx, name, filter)
 
 	var r0 []model.Author
-	if rf, ok := ret.Get(0).(func(string) []model.Author); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.AuthorFilter) []model.Author); ok {
+		r0 = rf(ctx, name, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.Author)
@@ -114,8 +162,8 @@ func (_m *Author) FindByName(name string) ([]model.Author, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.AuthorFilter) error); ok {
+		r1 = rf(ctx, name, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -123,13 +171,13 @@ func (_m *Author) FindByName(name string) ([]model.Author, error) {
 	return r0, r1
 }
 
-// FindByUserID provides a mock function with given fields: id
-func (_m *Author) FindByUserID(id int) (*model.Author, error) {
-	ret := _m.Called(id)
+// FindPrimary provides a mock function with given fields: ctx, userID
+func (_m *Author) FindPrimary(ctx context.Context, userID int) (*model.Author, error) {
+	ret := _m.Called(ctx, userID)
 
 	var r0 *model.Author
-	if rf, ok := ret.Get(0).(func(int) *model.Author); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.Author); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Author)
@@ -137,8 +185,8 @@ func (_m *Author) FindByUserID(id int) (*model.Author, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -146,20 +194,127 @@ func (_m *Author) FindByUserID(id int) (*model.Author, error) {
 	return r0, r1
 }
 
-// IsExistByID provides a mock function with given fields: id
-func (_m *Author) IsExistByID(id int) (bool, error) {
-	ret := _m.Called(id)
+// IsExistByID provides a mock function with given fields: ctx, id
+func (_m *Author) IsExistByID(ctx context.Context, id int) (bool, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(int) bool); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: ctx, before
+func (_m *Author) Purge(ctx context.Context, before time.Time) (int, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *Author) Restore(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, query, limit
+func (_m *Author) Search(ctx context.Context, query string, limit int) ([]model.AuthorMatch, error) {
+	ret := _m.Called(ctx, query, limit)
+
+	var r0 []model.AuthorMatch
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []model.AuthorMatch); ok {
+		r0 = rf(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.AuthorMatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetPrimary provides a mock function with given fields: ctx, id
+func (_m *Author) SetPrimary(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Transfer provides a mock function with given fields: ctx, id, userID
+func (_m *Author) Transfer(ctx context.Context, id int, userID int) (int, error) {
+	ret := _m.Called(ctx, id, userID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) int); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -167,20 +322,20 @@ func (_m *Author) IsExistByID(id int) (bool, error) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, author
-func (_m *Author) Update(id int, author model.Author) (int, error) {
-	ret := _m.Called(id, author)
+// Update provides a mock function with given fields: ctx, id, author
+func (_m *Author) Update(ctx context.Context, id int, author model.Author) (int, error) {
+	ret := _m.Called(ctx, id, author)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(int, model.Author) int); ok {
-		r0 = rf(id, author)
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.Author) int); ok {
+		r0 = rf(ctx, id, author)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int, model.Author) error); ok {
-		r1 = rf(id, author)
+	if rf, ok := ret.Get(1).(func(context.Context, int, model.Author) error); ok {
+		r1 = rf(ctx, id, author)
 	} else {
 		r1 = ret.Error(1)
 	}
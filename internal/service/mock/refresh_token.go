@@ -0,0 +1,122 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	model "github.com/JesusG2000/hexsatisfaction/internal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RefreshToken is an autogenerated mock type for the RefreshToken type
+type RefreshToken struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, token
+func (_m *RefreshToken) Create(ctx context.Context, token model.RefreshToken) (int, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, model.RefreshToken) int); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.RefreshToken) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByToken provides a mock function with given fields: ctx, token
+func (_m *RefreshToken) FindByToken(ctx context.Context, token string) (*model.RefreshToken, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *model.RefreshToken
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.RefreshToken); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RefreshToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, id
+func (_m *RefreshToken) Revoke(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeByUserID provides a mock function with given fields: ctx, userID
+func (_m *RefreshToken) RevokeByUserID(ctx context.Context, userID int) (int, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeFamily provides a mock function with given fields: ctx, family
+func (_m *RefreshToken) RevokeFamily(ctx context.Context, family string) (int, error) {
+	ret := _m.Called(ctx, family)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, family)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, family)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
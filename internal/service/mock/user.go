@@ -3,8 +3,15 @@
 package mock
 
 import (
-	model "github.com/JesusG2000/hexsatisfaction/internal/model"
+	context "context"
+
+	auth "github.com/JesusG2000/hexsatisfaction/pkg/auth"
+
 	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/JesusG2000/hexsatisfaction/internal/model"
+
+	time "time"
 )
 
 // User is an autogenerated mock type for the User type
@@ -12,20 +19,20 @@ type User struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: user
-func (_m *User) Create(user model.User) (int, error) {
-	ret := _m.Called(user)
+// Count provides a mock function with given fields: ctx
+func (_m *User) Count(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(model.User) int); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(model.User) error); ok {
-		r1 = rf(user)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -33,22 +40,64 @@ func (_m *User) Create(user model.User) (int, error) {
 	return r0, r1
 }
 
-// FindByCredentials provides a mock function with given fields: user
-func (_m *User) FindByCredentials(user model.User) (*model.User, error) {
-	ret := _m.Called(user)
+// Create provides a mock function with given fields: ctx, user
+func (_m *User) Create(ctx context.Context, user model.User) (int, error) {
+	ret := _m.Called(ctx, user)
 
-	var r0 *model.User
-	if rf, ok := ret.Get(0).(func(model.User) *model.User); ok {
-		r0 = rf(user)
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, model.User) int); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.User) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id, reassignTo
+func (_m *User) Delete(ctx context.Context, id int, reassignTo int) (int, error) {
+	ret := _m.Called(ctx, id, reassignTo)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) int); ok {
+		r0 = rf(ctx, id, reassignTo)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, reassignTo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExistByIDs provides a mock function with given fields: ctx, ids
+func (_m *User) ExistByIDs(ctx context.Context, ids []int) (map[int]bool, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 map[int]bool
+	if rf, ok := ret.Get(0).(func(context.Context, []int) map[int]bool); ok {
+		r0 = rf(ctx, ids)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.User)
+			r0 = ret.Get(0).(map[int]bool)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(model.User) error); ok {
-		r1 = rf(user)
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,13 +105,59 @@ func (_m *User) FindByCredentials(user model.User) (*model.User, error) {
 	return r0, r1
 }
 
-// FindByID provides a mock function with given fields: id
-func (_m *User) FindByID(id int) (*model.User, error) {
-	ret := _m.Called(id)
+// FindAccount provides a mock function with given fields: ctx, id
+func (_m *User) FindAccount(ctx context.Context, id int) (*auth.Account, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *auth.Account
+	if rf, ok := ret.Get(0).(func(context.Context, int) *auth.Account); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*auth.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindAll provides a mock function with given fields: ctx, limit, offset
+func (_m *User) FindAll(ctx context.Context, limit int, offset int) ([]model.User, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	var r0 []model.User
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []model.User); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *User) FindByID(ctx context.Context, id int) (*model.User, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 *model.User
-	if rf, ok := ret.Get(0).(func(int) *model.User); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.User); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.User)
@@ -70,8 +165,8 @@ func (_m *User) FindByID(id int) (*model.User, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -79,13 +174,13 @@ func (_m *User) FindByID(id int) (*model.User, error) {
 	return r0, r1
 }
 
-// FindByLogin provides a mock function with given fields: login
-func (_m *User) FindByLogin(login string) (*model.User, error) {
-	ret := _m.Called(login)
+// FindByLogin provides a mock function with given fields: ctx, login
+func (_m *User) FindByLogin(ctx context.Context, login string) (*model.User, error) {
+	ret := _m.Called(ctx, login)
 
 	var r0 *model.User
-	if rf, ok := ret.Get(0).(func(string) *model.User); ok {
-		r0 = rf(login)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, login)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.User)
@@ -93,8 +188,8 @@ func (_m *User) FindByLogin(login string) (*model.User, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(login)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, login)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -102,20 +197,20 @@ func (_m *User) FindByLogin(login string) (*model.User, error) {
 	return r0, r1
 }
 
-// IsExist provides a mock function with given fields: login
-func (_m *User) IsExist(login string) (bool, error) {
-	ret := _m.Called(login)
+// IsExist provides a mock function with given fields: ctx, login
+func (_m *User) IsExist(ctx context.Context, login string) (bool, error) {
+	ret := _m.Called(ctx, login)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(login)
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, login)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(login)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, login)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -123,20 +218,188 @@ func (_m *User) IsExist(login string) (bool, error) {
 	return r0, r1
 }
 
-// IsExistByID provides a mock function with given fields: id
-func (_m *User) IsExistByID(id int) (bool, error) {
-	ret := _m.Called(id)
+// IsExistByID provides a mock function with given fields: ctx, id
+func (_m *User) IsExistByID(ctx context.Context, id int) (bool, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(int) bool); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: ctx, before
+func (_m *User) Purge(ctx context.Context, before time.Time) (int, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordFailedLogin provides a mock function with given fields: ctx, id, maxFailures, backoff, lockout
+func (_m *User) RecordFailedLogin(ctx context.Context, id int, maxFailures int, backoff time.Duration, lockout time.Duration) (time.Time, error) {
+	ret := _m.Called(ctx, id, maxFailures, backoff, lockout)
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, time.Duration, time.Duration) time.Time); ok {
+		r0 = rf(ctx, id, maxFailures, backoff, lockout)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, time.Duration, time.Duration) error); ok {
+		r1 = rf(ctx, id, maxFailures, backoff, lockout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetFailedLogins provides a mock function with given fields: ctx, id
+func (_m *User) ResetFailedLogins(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetPassword provides a mock function with given fields: ctx, id, password
+func (_m *User) ResetPassword(ctx context.Context, id int, password string) (int, error) {
+	ret := _m.Called(ctx, id, password)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) int); ok {
+		r0 = rf(ctx, id, password)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, id, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *User) Restore(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeTokens provides a mock function with given fields: ctx, id
+func (_m *User) RevokeTokens(ctx context.Context, id int) (int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetDisabled provides a mock function with given fields: ctx, id, disabled
+func (_m *User) SetDisabled(ctx context.Context, id int, disabled bool) (int, error) {
+	ret := _m.Called(ctx, id, disabled)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) int); ok {
+		r0 = rf(ctx, id, disabled)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, bool) error); ok {
+		r1 = rf(ctx, id, disabled)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePassword provides a mock function with given fields: ctx, id, password
+func (_m *User) UpdatePassword(ctx context.Context, id int, password string) (int, error) {
+	ret := _m.Called(ctx, id, password)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) int); ok {
+		r0 = rf(ctx, id, password)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, id, password)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,6 +3,8 @@
 package mock
 
 import (
+	context "context"
+
 	model "github.com/JesusG2000/hexsatisfaction/internal/model"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,13 +14,36 @@ type UserRole struct {
 	mock.Mock
 }
 
-// FindAllUser provides a mock function with given fields:
-func (_m *UserRole) FindAllUser() ([]model.User, error) {
-	ret := _m.Called()
+// FindAllPermissions provides a mock function with given fields: ctx
+func (_m *UserRole) FindAllPermissions(ctx context.Context) ([]model.RolePermission, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.RolePermission
+	if rf, ok := ret.Get(0).(func(context.Context) []model.RolePermission); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.RolePermission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindAllUser provides a mock function with given fields: ctx
+func (_m *UserRole) FindAllUser(ctx context.Context) ([]model.User, error) {
+	ret := _m.Called(ctx)
 
 	var r0 []model.User
-	if rf, ok := ret.Get(0).(func() []model.User); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []model.User); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.User)
@@ -26,8 +51,29 @@ func (_m *UserRole) FindAllUser() ([]model.User, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRole provides a mock function with given fields: ctx, userID, roleID
+func (_m *UserRole) UpdateRole(ctx context.Context, userID int, roleID int) (int, error) {
+	ret := _m.Called(ctx, userID, roleID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) int); ok {
+		r0 = rf(ctx, userID, roleID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, roleID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,33 +1,53 @@
 package service
 
 import (
+	"context"
+
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
 	"github.com/JesusG2000/hexsatisfaction/internal/repository"
 	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/hash"
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
 )
 
 // User is an interface for UserService methods.
 type User interface {
-	Create(req model.RegisterUserRequest) (int, error)
-	FindByLogin(login string) (*model.User, error)
-	FindByCredentials(req model.LoginUserRequest) (string, error)
-	IsExist(login string) (bool, error)
+	Create(ctx context.Context, req model.RegisterUserRequest) (int, error)
+	FindByLogin(ctx context.Context, login string) (*model.User, error)
+	FindByCredentials(ctx context.Context, req model.LoginUserRequest) (*model.Tokens, error)
+	Refresh(ctx context.Context, req model.RefreshTokenRequest) (*model.Tokens, error)
+	Logout(ctx context.Context, req model.LogoutUserRequest) error
+	ChangePassword(ctx context.Context, req model.ChangePasswordRequest) error
+	IsExist(ctx context.Context, login string) (bool, error)
+	FindAll(ctx context.Context, req model.ListUsersRequest) (*model.UserPage, error)
+	FindByID(ctx context.Context, req model.IDUserRequest) (*model.User, error)
+	SetDisabled(ctx context.Context, req model.DisableUserRequest) (int, error)
+	ResetPassword(ctx context.Context, req model.IDUserRequest) (*model.TemporaryPassword, error)
+	Delete(ctx context.Context, req model.DeleteUserRequest) (int, error)
+	Restore(ctx context.Context, req model.IDUserRequest) (int, error)
 }
 
 // UserRole is an interface for UserRoleService methods.
 type UserRole interface {
-	FindAllUser() ([]model.User, error)
+	FindAllUser(ctx context.Context) ([]model.User, error)
+	FindPermissions(ctx context.Context) (map[int][]string, error)
+	UpdateRole(ctx context.Context, req model.UpdateUserRoleRequest) (int, error)
 }
 
 // Author is an interface for AuthorService repository methods.
 type Author interface {
-	Create(request model.CreateAuthorRequest) (int, error)
-	Update(request model.UpdateAuthorRequest) (int, error)
-	Delete(request model.DeleteAuthorRequest) (int, error)
-	FindByID(request model.IDAuthorRequest) (*model.Author, error)
-	FindByUserID(request model.UserIDAuthorRequest) (*model.Author, error)
-	FindByName(request model.NameAuthorRequest) ([]model.Author, error)
-	FindAll() ([]model.Author, error)
+	Create(ctx context.Context, request model.CreateAuthorRequest) (int, error)
+	Update(ctx context.Context, request model.UpdateAuthorRequest) (int, error)
+	Delete(ctx context.Context, request model.DeleteAuthorRequest) (int, error)
+	Restore(ctx context.Context, request model.IDAuthorRequest) (int, error)
+	FindByID(ctx context.Context, request model.IDAuthorRequest) (*model.Author, error)
+	FindByUserID(ctx context.Context, request model.UserIDAuthorRequest) (*model.AuthorPage, error)
+	FindPrimary(ctx context.Context, request model.PrimaryAuthorRequest) (*model.Author, error)
+	SetPrimary(ctx context.Context, request model.SetPrimaryAuthorRequest) (int, error)
+	Transfer(ctx context.Context, request model.TransferAuthorRequest) (int, error)
+	FindByName(ctx context.Context, request model.NameAuthorRequest) (*model.AuthorPage, error)
+	FindAll(ctx context.Context, request model.ListAuthorsRequest) (*model.AuthorPage, error)
+	Search(ctx context.Context, request model.SearchAuthorRequest) ([]model.AuthorMatch, error)
 }
 
 // Services collects all service interfaces.
@@ -39,15 +59,18 @@ type Services struct {
 
 // Deps represents dependencies for services.
 type Deps struct {
-	Repos        *repository.Repositories
-	TokenManager auth.TokenManager
+	Repos          *repository.Repositories
+	TokenManager   auth.TokenManager
+	PasswordHasher hash.PasswordHasher
+	Lockout        Lockout
+	Logger         logger.Logger
 }
 
-// NewServices is a Services constructor.
+// NewServices is a Services constructor, every call of the services is traced.
 func NewServices(deps Deps) *Services {
 	return &Services{
-		User:     NewUserService(deps.Repos.User, deps.TokenManager),
-		UserRole: NewUserRoleService(deps.Repos.UserRole),
-		Author:   NewAuthorService(deps.Repos.Author),
+		User:     tracedUser{NewUserService(deps.Repos.User, deps.Repos.RefreshToken, deps.TokenManager, deps.PasswordHasher, deps.Lockout, deps.Logger)},
+		UserRole: tracedUserRole{NewUserRoleService(deps.Repos.UserRole, deps.Repos.User, deps.Repos.RefreshToken)},
+		Author:   tracedAuthor{NewAuthorService(deps.Repos.Author)},
 	}
 }
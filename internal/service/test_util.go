@@ -4,8 +4,11 @@ import (
 	"log"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/config"
+	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
 	"github.com/JesusG2000/hexsatisfaction/internal/repository"
 	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/hash"
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
 	"github.com/pkg/errors"
 )
 
@@ -13,6 +16,8 @@ import (
 type TestAPI struct {
 	*Services
 	auth.TokenManager
+	hash.PasswordHasher
+	*auth.Authorizer
 }
 
 // InitTest4Mock initialize an a TestAPI for mock testing.
@@ -35,16 +40,28 @@ func initServices4Test() (*TestAPI, error) {
 		return nil, errors.Wrap(err, "couldn't connect to db")
 	}
 
-	tokenManager, err := auth.NewManager(cfg.Auth.SigningKey)
+	tokenManager, err := auth.NewManager(cfg.Auth.SigningKey, cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't create jwt manager")
 	}
 
+	hasher, err := hash.NewHasher(cfg.Hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create password hasher")
+	}
+
 	return &TestAPI{
 		Services: NewServices(Deps{
-			Repos:        repos,
-			TokenManager: tokenManager,
+			Repos:          repos,
+			TokenManager:   tokenManager,
+			PasswordHasher: hasher,
+			Logger:         logger.Nop(),
+		}),
+		TokenManager:   tokenManager,
+		PasswordHasher: hasher,
+		Authorizer: auth.NewAuthorizer(map[int][]string{
+			dto.ADMIN: {dto.AuthorWrite, dto.AuthorWriteAny, dto.UserList, dto.UserManage},
+			dto.USER:  {dto.AuthorWrite},
 		}),
-		TokenManager: tokenManager,
 	}, nil
 }
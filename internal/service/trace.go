@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/model"
+	"github.com/JesusG2000/hexsatisfaction/pkg/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/JesusG2000/hexsatisfaction/internal/service")
+
+// startSpan starts a span of the service call named like service.User.Create.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "service."+name)
+}
+
+// tracedUser runs every call of User service in a span.
+type tracedUser struct {
+	next User
+}
+
+// Create runs User.Create in a span.
+func (t tracedUser) Create(ctx context.Context, req model.RegisterUserRequest) (int, error) {
+	ctx, span := startSpan(ctx, "User.Create")
+	id, err := t.next.Create(ctx, req)
+	tracing.End(span, err)
+
+	return id, err
+}
+
+// FindByLogin runs User.FindByLogin in a span.
+func (t tracedUser) FindByLogin(ctx context.Context, login string) (*model.User, error) {
+	ctx, span := startSpan(ctx, "User.FindByLogin")
+	res, err := t.next.FindByLogin(ctx, login)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// FindByCredentials runs User.FindByCredentials in a span.
+func (t tracedUser) FindByCredentials(ctx context.Context, req model.LoginUserRequest) (*model.Tokens, error) {
+	ctx, span := startSpan(ctx, "User.FindByCredentials")
+	res, err := t.next.FindByCredentials(ctx, req)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// Refresh runs User.Refresh in a span.
+func (t tracedUser) Refresh(ctx context.Context, req model.RefreshTokenRequest) (*model.Tokens, error) {
+	ctx, span := startSpan(ctx, "User.Refresh")
+	res, err := t.next.Refresh(ctx, req)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// Logout runs User.Logout in a span.
+func (t tracedUser) Logout(ctx context.Context, req model.LogoutUserRequest) error {
+	ctx, span := startSpan(ctx, "User.Logout")
+	err := t.next.Logout(ctx, req)
+	tracing.End(span, err)
+
+	return err
+}
+
+// ChangePassword runs User.ChangePassword in a span.
+func (t tracedUser) ChangePassword(ctx context.Context, req model.ChangePasswordRequest) error {
+	ctx, span := startSpan(ctx, "User.ChangePassword")
+	err := t.next.ChangePassword(ctx, req)
+	tracing.End(span, err)
+
+	return err
+}
+
+// IsExist runs User.IsExist in a span.
+func (t tracedUser) IsExist(ctx context.Context, login string) (bool, error) {
+	ctx, span := startSpan(ctx, "User.IsExist")
+	exist, err := t.next.IsExist(ctx, login)
+	tracing.End(span, err)
+
+	return exist, err
+}
+
+// FindAll runs User.FindAll in a span.
+func (t tracedUser) FindAll(ctx context.Context, req model.ListUsersRequest) (*model.UserPage, error) {
+	ctx, span := startSpan(ctx, "User.FindAll")
+	res, err := t.next.FindAll(ctx, req)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// FindByID runs User.FindByID in a span.
+func (t tracedUser) FindByID(ctx context.Context, req model.IDUserRequest) (*model.User, error) {
+	ctx, span := startSpan(ctx, "User.FindByID")
+	res, err := t.next.FindByID(ctx, req)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// SetDisabled runs User.SetDisabled in a span.
+func (t tracedUser) SetDisabled(ctx context.Context, req model.DisableUserRequest) (int, error) {
+	ctx, span := startSpan(ctx, "User.SetDisabled")
+	id, err := t.next.SetDisabled(ctx, req)
+	tracing.End(span, err)
+
+	return id, err
+}
+
+// ResetPassword runs User.ResetPassword in a span.
+func (t tracedUser) ResetPassword(ctx context.Context, req model.IDUserRequest) (*model.TemporaryPassword, error) {
+	ctx, span := startSpan(ctx, "User.ResetPassword")
+	res, err := t.next.ResetPassword(ctx, req)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// Delete runs User.Delete in a span.
+func (t tracedUser) Delete(ctx context.Context, req model.DeleteUserRequest) (int, error) {
+	ctx, span := startSpan(ctx, "User.Delete")
+	id, err := t.next.Delete(ctx, req)
+	tracing.End(span, err)
+
+	return id, err
+}
+
+// Restore runs User.Restore in a span.
+func (t tracedUser) Restore(ctx context.Context, req model.IDUserRequest) (int, error) {
+	ctx, span := startSpan(ctx, "User.Restore")
+	id, err := t.next.Restore(ctx, req)
+	tracing.End(span, err)
+
+	return id, err
+}
+
+// tracedUserRole runs every call of UserRole service in a span.
+type tracedUserRole struct {
+	next UserRole
+}
+
+// FindAllUser runs UserRole.FindAllUser in a span.
+func (t tracedUserRole) FindAllUser(ctx context.Context) ([]model.User, error) {
+	ctx, span := startSpan(ctx, "UserRole.FindAllUser")
+	res, err := t.next.FindAllUser(ctx)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// FindPermissions runs UserRole.FindPermissions in a span.
+func (t tracedUserRole) FindPermissions(ctx context.Context) (map[int][]string, error) {
+	ctx, span := startSpan(ctx, "UserRole.FindPermissions")
+	res, err := t.next.FindPermissions(ctx)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// UpdateRole runs UserRole.UpdateRole in a span.
+func (t tracedUserRole) UpdateRole(ctx context.Context, req model.UpdateUserRoleRequest) (int, error) {
+	ctx, span := startSpan(ctx, "UserRole.UpdateRole")
+	id, err := t.next.UpdateRole(ctx, req)
+	tracing.End(span, err)
+
+	return id, err
+}
+
+// tracedAuthor runs every call of Author service in a span.
+type tracedAuthor struct {
+	next Author
+}
+
+// Create runs Author.Create in a span.
+func (t tracedAuthor) Create(ctx context.Context, request model.CreateAuthorRequest) (int, error) {
+	ctx, span := startSpan(ctx, "Author.Create")
+	id, err := t.next.Create(ctx, request)
+	tracing.End(span, err)
+
+	return id, err
+}
+
+// Update runs Author.Update in a span.
+func (t tracedAuthor) Update(ctx context.Context, request model.UpdateAuthorRequest) (int, error) {
+	ctx, span := startSpan(ctx, "Author.Update")
+	id, err := t.next.Update(ctx, request)
+	tracing.End(span, err)
+
+	return id, err
+}
+
+// Delete runs Author.Delete in a span.
+func (t tracedAuthor) Delete(ctx context.Context, request model.DeleteAuthorRequest) (int, error) {
+	ctx, span := startSpan(ctx, "Author.Delete")
+	id, err := t.next.Delete(ctx, request)
+	tracing.End(span, err)
+
+	return id, err
+}
+
+// Restore runs Author.Restore in a span.
+func (t tracedAuthor) Restore(ctx context.Context, request model.IDAuthorRequest) (int, error) {
+	ctx, span := startSpan(ctx, "Author.Restore")
+	id, err := t.next.Restore(ctx, request)
+	tracing.End(span, err)
+
+	return id, err
+}
+
+// FindByID runs Author.FindByID in a span.
+func (t tracedAuthor) FindByID(ctx context.Context, request model.IDAuthorRequest) (*model.Author, error) {
+	ctx, span := startSpan(ctx, "Author.FindByID")
+	res, err := t.next.FindByID(ctx, request)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// FindByUserID runs Author.FindByUserID in a span.
+func (t tracedAuthor) FindByUserID(ctx context.Context, request model.UserIDAuthorRequest) (*model.AuthorPage, error) {
+	ctx, span := startSpan(ctx, "Author.FindByUserID")
+	res, err := t.next.FindByUserID(ctx, request)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// FindPrimary runs Author.FindPrimary in a span.
+func (t tracedAuthor) FindPrimary(ctx context.Context, request model.PrimaryAuthorRequest) (*model.Author, error) {
+	ctx, span := startSpan(ctx, "Author.FindPrimary")
+	res, err := t.next.FindPrimary(ctx, request)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// SetPrimary runs Author.SetPrimary in a span.
+func (t tracedAuthor) SetPrimary(ctx context.Context, request model.SetPrimaryAuthorRequest) (int, error) {
+	ctx, span := startSpan(ctx, "Author.SetPrimary")
+	id, err := t.next.SetPrimary(ctx, request)
+	tracing.End(span, err)
+
+	return id, err
+}
+
+// Transfer runs Author.Transfer in a span.
+func (t tracedAuthor) Transfer(ctx context.Context, request model.TransferAuthorRequest) (int, error) {
+	ctx, span := startSpan(ctx, "Author.Transfer")
+	id, err := t.next.Transfer(ctx, request)
+	tracing.End(span, err)
+
+	return id, err
+}
+
+// FindByName runs Author.FindByName in a span.
+func (t tracedAuthor) FindByName(ctx context.Context, request model.NameAuthorRequest) (*model.AuthorPage, error) {
+	ctx, span := startSpan(ctx, "Author.FindByName")
+	res, err := t.next.FindByName(ctx, request)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// FindAll runs Author.FindAll in a span.
+func (t tracedAuthor) FindAll(ctx context.Context, request model.ListAuthorsRequest) (*model.AuthorPage, error) {
+	ctx, span := startSpan(ctx, "Author.FindAll")
+	res, err := t.next.FindAll(ctx, request)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+// Search runs Author.Search in a span.
+func (t tracedAuthor) Search(ctx context.Context, request model.SearchAuthorRequest) ([]model.AuthorMatch, error) {
+	ctx, span := startSpan(ctx, "Author.Search")
+	res, err := t.next.Search(ctx, request)
+	tracing.End(span, err)
+
+	return res, err
+}
@@ -1,41 +1,91 @@
 package service
 
 import (
-	"strconv"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"time"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
+	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
 	"github.com/JesusG2000/hexsatisfaction/internal/repository"
 	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/JesusG2000/hexsatisfaction/pkg/hash"
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
+	"github.com/JesusG2000/hexsatisfaction/pkg/metrics"
 	"github.com/pkg/errors"
 )
 
+const (
+	temporaryPasswordLen = 12
+	dummyPassword        = "dummy password"
+)
+
 // UserService is a user service.
 type UserService struct {
 	repository.User
 	auth.TokenManager
+	hash.PasswordHasher
+	refreshTokens repository.RefreshToken
+	lockout       Lockout
+	log           logger.Logger
+	dummyHash     string
+}
+
+// Lockout delays logins of a user after failed ones.
+// Every failure locks logins for Backoff doubled per previous failure,
+// after MaxFailures failures in a row logins are locked for Duration.
+type Lockout struct {
+	MaxFailures int
+	Backoff     time.Duration
+	Duration    time.Duration
 }
 
 // NewUserService is a UserService service constructor.
-func NewUserService(userRepo repository.User, tokenManager auth.TokenManager) *UserService {
-	return &UserService{userRepo, tokenManager}
+// The hasher hashes a dummy password up front, logins of unknown users verify against it.
+func NewUserService(userRepo repository.User, refreshTokenRepo repository.RefreshToken, tokenManager auth.TokenManager, hasher hash.PasswordHasher, lockout Lockout, log logger.Logger) *UserService {
+	dummyHash, err := hasher.Hash(dummyPassword)
+	if err != nil {
+		log.Warn("couldn't hash a dummy password, failed logins are faster for unknown users", "error", err)
+	}
+
+	return &UserService{userRepo, tokenManager, hasher, refreshTokenRepo, lockout, log, dummyHash}
 }
 
 // Create creates new user and returns id.
-func (u UserService) Create(req model.RegisterUserRequest) (int, error) {
+// Login is checked up front, the unique index only backs it up against concurrent registrations.
+func (u UserService) Create(ctx context.Context, req model.RegisterUserRequest) (int, error) {
+	exist, err := u.User.IsExist(ctx, req.Login)
+	if err != nil {
+		return 0, errors.Wrap(err, "couldn't check a user existence")
+	}
+	if exist {
+		return 0, domain.Errorf(domain.ErrConflict, "user already exists")
+	}
+
+	password, err := u.Hash(req.Password)
+	if err != nil {
+		return 0, errors.Wrap(err, "couldn't hash a password")
+	}
+
 	user := model.User{
 		Login:    req.Login,
-		Password: req.Password,
+		Password: password,
 	}
-	id, err := u.User.Create(user)
+	id, err := u.User.Create(ctx, user)
 	if err != nil {
 		return 0, errors.Wrap(err, "couldn't create a user")
 	}
+	metrics.Registrations.Inc()
+
 	return id, nil
 }
 
 // FindByLogin finds the user by login.
-func (u UserService) FindByLogin(login string) (*model.User, error) {
-	user, err := u.User.FindByLogin(login)
+func (u UserService) FindByLogin(ctx context.Context, login string) (*model.User, error) {
+	user, err := u.User.FindByLogin(ctx, login)
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't find a user by login")
 	}
@@ -43,34 +93,366 @@ func (u UserService) FindByLogin(login string) (*model.User, error) {
 	return user, nil
 }
 
-// FindByCredentials finds the user by credentials and returns jwt-token.
-func (u UserService) FindByCredentials(req model.LoginUserRequest) (string, error) {
-	user := model.User{
-		Login:    req.Login,
-		Password: req.Password,
+// FindByCredentials finds the user by credentials and returns access and refresh tokens.
+// Passwords stored in a legacy or outdated format are rehashed after a successful login.
+func (u UserService) FindByCredentials(ctx context.Context, req model.LoginUserRequest) (*model.Tokens, error) {
+	tokens, err := u.login(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := metrics.ResultOK
+	if tokens == nil {
+		result = metrics.ResultFailed
+	}
+	metrics.Logins.WithLabelValues(result).Inc()
+
+	return tokens, nil
+}
+
+// login returns nil tokens if the credentials are wrong or the user is disabled or locked after failures.
+// Unknown, disabled and locked users get the same answer after the same hash work, so logins can't be probed.
+func (u UserService) login(ctx context.Context, req model.LoginUserRequest) (*model.Tokens, error) {
+	user, err := u.User.FindByLogin(ctx, req.Login)
+	if errors.Is(err, domain.ErrNotFound) {
+		u.verifyDummy(req.Password)
+		return nil, nil
 	}
-	newUser, err := u.User.FindByCredentials(user)
 	if err != nil {
-		return "", errors.Wrap(err, "couldn't find a user by credentials")
+		return nil, errors.Wrap(err, "couldn't find a user by credentials")
+	}
+
+	if user.Disabled || user.LockedUntil != nil && user.LockedUntil.After(time.Now()) {
+		u.verifyDummy(req.Password)
+		return nil, nil
+	}
+
+	ok, err := u.checkPassword(user.Password, req.Password)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't verify a password")
+	}
+
+	if !ok {
+		lockedUntil, err := u.User.RecordFailedLogin(ctx, user.ID, u.lockout.MaxFailures, u.lockout.Backoff, u.lockout.Duration)
+		if err != nil {
+			return nil, errors.Wrap(err, "couldn't record a failed login")
+		}
+
+		if user.FailedLogins+1 >= u.lockout.MaxFailures {
+			u.log.WithContext(ctx).Warn("user locked after failed logins", "user_id", user.ID, "locked_until", lockedUntil)
+		}
+		return nil, nil
+	}
+
+	if user.FailedLogins > 0 {
+		if _, err := u.User.ResetFailedLogins(ctx, user.ID); err != nil {
+			return nil, errors.Wrap(err, "couldn't reset failed logins")
+		}
 	}
 
-	if newUser.ID != 0 {
-		newJWT, err := u.NewJWT(strconv.Itoa(newUser.ID))
+	if u.NeedsRehash(user.Password) {
+		password, err := u.Hash(req.Password)
 		if err != nil {
-			return "", errors.Wrap(err, "couldn't create a token")
+			return nil, errors.Wrap(err, "couldn't hash a password")
+		}
+
+		if _, err := u.User.UpdatePassword(ctx, user.ID, password); err != nil {
+			return nil, errors.Wrap(err, "couldn't rehash a password")
 		}
-		return newJWT, nil
 	}
 
-	return "", nil
+	return u.issueTokens(ctx, user, "")
+}
+
+// Refresh rotates the refresh token and returns a new pair of tokens.
+// Reuse of an already rotated token revokes every token issued since the login.
+func (u UserService) Refresh(ctx context.Context, req model.RefreshTokenRequest) (*model.Tokens, error) {
+	token, err := u.refreshTokens.FindByToken(ctx, auth.HashToken(req.RefreshToken))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't find a refresh token")
+	}
+
+	if token.ExpiresAt.Before(time.Now()) {
+		return nil, nil
+	}
+
+	revoked := token.Revoked
+	if !revoked {
+		_, err = u.refreshTokens.Revoke(ctx, token.ID)
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			revoked = true
+		case err != nil:
+			return nil, errors.Wrap(err, "couldn't revoke a refresh token")
+		}
+	}
+
+	if revoked {
+		u.log.WithContext(ctx).Warn("refresh token reused, revoking the family", "user_id", token.UserID)
+		if _, err := u.refreshTokens.RevokeFamily(ctx, token.Family); err != nil {
+			return nil, errors.Wrap(err, "couldn't revoke refresh tokens")
+		}
+		return nil, nil
+	}
+
+	user, err := u.User.FindByID(ctx, token.UserID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't find a user")
+	}
+
+	if user.Disabled {
+		return nil, nil
+	}
+
+	return u.issueTokens(ctx, user, token.Family)
+}
+
+// Logout revokes the refresh token with every token rotated from the same login.
+func (u UserService) Logout(ctx context.Context, req model.LogoutUserRequest) error {
+	token, err := u.refreshTokens.FindByToken(ctx, auth.HashToken(req.RefreshToken))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "couldn't find a refresh token")
+	}
+
+	if _, err := u.refreshTokens.RevokeFamily(ctx, token.Family); err != nil {
+		return errors.Wrap(err, "couldn't revoke refresh tokens")
+	}
+
+	return nil
+}
+
+// ChangePassword replaces the user password after checking the old one and revokes tokens of the user.
+func (u UserService) ChangePassword(ctx context.Context, req model.ChangePasswordRequest) error {
+	user, err := u.User.FindByID(ctx, req.ID)
+	if err != nil {
+		return errors.Wrap(err, "couldn't find a user")
+	}
+
+	ok, err := u.checkPassword(user.Password, req.OldPassword)
+	if err != nil {
+		return errors.Wrap(err, "couldn't verify a password")
+	}
+
+	if !ok {
+		return domain.Errorf(domain.ErrForbidden, "old password is wrong")
+	}
+
+	password, err := u.Hash(req.NewPassword)
+	if err != nil {
+		return errors.Wrap(err, "couldn't hash a password")
+	}
+
+	if _, err := u.User.UpdatePassword(ctx, user.ID, password); err != nil {
+		return errors.Wrap(err, "couldn't update a password")
+	}
+
+	return revokeTokens(ctx, u.User, u.refreshTokens, user.ID)
 }
 
 // IsExist checks if the user exists.
-func (u UserService) IsExist(login string) (bool, error) {
-	exist, err := u.User.IsExist(login)
+func (u UserService) IsExist(ctx context.Context, login string) (bool, error) {
+	exist, err := u.User.IsExist(ctx, login)
 	if err != nil {
 		return false, errors.Wrap(err, "couldn't check user existence")
 	}
 
 	return exist, nil
 }
+
+// FindAll finds a page of users.
+func (u UserService) FindAll(ctx context.Context, req model.ListUsersRequest) (*model.UserPage, error) {
+	users, err := u.User.FindAll(ctx, req.Limit, req.Offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't find users")
+	}
+
+	total, err := u.User.Count(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't count users")
+	}
+
+	if users == nil {
+		users = []model.User{}
+	}
+
+	return &model.UserPage{
+		Items: users,
+		Total: total,
+	}, nil
+}
+
+// FindByID finds the user by id.
+func (u UserService) FindByID(ctx context.Context, req model.IDUserRequest) (*model.User, error) {
+	user, err := u.User.FindByID(ctx, req.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't find a user")
+	}
+
+	return user, nil
+}
+
+// SetDisabled disables or enables the user and returns id.
+// Tokens of a disabled user are revoked, admins can't disable themselves and the last admin can't be disabled.
+func (u UserService) SetDisabled(ctx context.Context, req model.DisableUserRequest) (int, error) {
+	if req.Disabled {
+		if err := checkOwnAccount(ctx, req.ID, "disable"); err != nil {
+			return 0, err
+		}
+	}
+
+	id, err := u.User.SetDisabled(ctx, req.ID, req.Disabled)
+	if err != nil {
+		return 0, errors.Wrap(err, "couldn't update a user")
+	}
+
+	if req.Disabled {
+		if err := revokeTokens(ctx, u.User, u.refreshTokens, id); err != nil {
+			return 0, err
+		}
+	}
+
+	return id, nil
+}
+
+// ResetPassword replaces the user password with a temporary one and revokes tokens of the user.
+func (u UserService) ResetPassword(ctx context.Context, req model.IDUserRequest) (*model.TemporaryPassword, error) {
+	password, err := temporaryPassword()
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't generate a password")
+	}
+
+	hashed, err := u.Hash(password)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't hash a password")
+	}
+
+	id, err := u.User.ResetPassword(ctx, req.ID, hashed)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't reset a password")
+	}
+
+	if err := revokeTokens(ctx, u.User, u.refreshTokens, id); err != nil {
+		return nil, err
+	}
+
+	return &model.TemporaryPassword{Password: password}, nil
+}
+
+// Delete soft deletes the user, revokes tokens of the user and returns deleted id.
+// Admins can't delete themselves and the last admin can't be deleted.
+func (u UserService) Delete(ctx context.Context, req model.DeleteUserRequest) (int, error) {
+	if err := checkOwnAccount(ctx, req.ID, "delete"); err != nil {
+		return 0, err
+	}
+
+	id, err := u.User.Delete(ctx, req.ID, req.ReassignTo)
+	if err != nil {
+		return 0, errors.Wrap(err, "couldn't delete a user")
+	}
+
+	if err := revokeTokens(ctx, u.User, u.refreshTokens, id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Restore restores the deleted user and returns id.
+func (u UserService) Restore(ctx context.Context, req model.IDUserRequest) (int, error) {
+	id, err := u.User.Restore(ctx, req.ID)
+	if err != nil {
+		return 0, errors.Wrap(err, "couldn't restore a user")
+	}
+
+	return id, nil
+}
+
+// revokeTokens revokes refresh tokens of the user and access tokens issued to it until now.
+func revokeTokens(ctx context.Context, users repository.User, refreshTokens repository.RefreshToken, id int) error {
+	if _, err := refreshTokens.RevokeByUserID(ctx, id); err != nil {
+		return errors.Wrap(err, "couldn't revoke refresh tokens")
+	}
+
+	if _, err := users.RevokeTokens(ctx, id); err != nil {
+		return errors.Wrap(err, "couldn't revoke access tokens")
+	}
+
+	return nil
+}
+
+// checkOwnAccount refuses the action if an admin makes it on its own account,
+// so admins can't lock themselves out.
+func checkOwnAccount(ctx context.Context, id int, action string) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if ok && principal.UserID == id && principal.Role == dto.ADMIN {
+		return domain.Errorf(domain.ErrForbidden, "admins can't %s their own account", action)
+	}
+
+	return nil
+}
+
+// checkPassword compares the password with the stored one, which may still be in plaintext.
+func (u UserService) checkPassword(stored, password string) (bool, error) {
+	if !hash.IsHashed(stored) {
+		return subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1, nil
+	}
+
+	return u.Verify(stored, password)
+}
+
+// verifyDummy verifies the password against the dummy hash to spend the time of a real check.
+func (u UserService) verifyDummy(password string) {
+	_, _ = u.Verify(u.dummyHash, password)
+}
+
+// issueTokens creates an access token and a refresh token, which continues the family or starts a new one.
+func (u UserService) issueTokens(ctx context.Context, user *model.User, family string) (*model.Tokens, error) {
+	accessToken, err := u.NewJWT(user.ID, user.RoleID, user.TokenVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create a token")
+	}
+
+	refreshToken, expiresAt, err := u.NewRefreshToken()
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create a refresh token")
+	}
+
+	hashed := auth.HashToken(refreshToken)
+	if family == "" {
+		family = hashed
+	}
+
+	_, err = u.refreshTokens.Create(ctx, model.RefreshToken{
+		UserID:    user.ID,
+		Token:     hashed,
+		Family:    family,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't save a refresh token")
+	}
+
+	return &model.Tokens{
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		PasswordResetRequired: user.PasswordResetRequired,
+	}, nil
+}
+
+func temporaryPassword() (string, error) {
+	b := make([]byte, temporaryPasswordLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
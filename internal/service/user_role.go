@@ -1,7 +1,9 @@
 package service
 
 import (
+	"context"
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
+	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
 	"github.com/JesusG2000/hexsatisfaction/internal/repository"
 	"github.com/pkg/errors"
 )
@@ -9,18 +11,56 @@ import (
 // UserRoleService is a user role service.
 type UserRoleService struct {
 	repository.UserRole
+	users         repository.User
+	refreshTokens repository.RefreshToken
 }
 
 // NewUserRoleService is a UserRoleService constructor.
-func NewUserRoleService(userRoleRepo repository.UserRole) *UserRoleService {
-	return &UserRoleService{userRoleRepo}
+func NewUserRoleService(userRoleRepo repository.UserRole, userRepo repository.User, refreshTokenRepo repository.RefreshToken) *UserRoleService {
+	return &UserRoleService{userRoleRepo, userRepo, refreshTokenRepo}
 }
 
 // FindAllUser finds users.
-func (u UserRoleService) FindAllUser() ([]model.User, error) {
-	users, err := u.UserRole.FindAllUser()
+func (u UserRoleService) FindAllUser(ctx context.Context) ([]model.User, error) {
+	users, err := u.UserRole.FindAllUser(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't find users")
 	}
 	return users, nil
 }
+
+// FindPermissions finds names of permissions granted to each role.
+func (u UserRoleService) FindPermissions(ctx context.Context) (map[int][]string, error) {
+	rolePermissions, err := u.UserRole.FindAllPermissions(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't find permissions")
+	}
+
+	permissions := make(map[int][]string)
+	for _, p := range rolePermissions {
+		permissions[p.RoleID] = append(permissions[p.RoleID], p.Permission)
+	}
+
+	return permissions, nil
+}
+
+// UpdateRole changes the role of the user, revokes tokens carrying the old role and returns user id.
+// Admins can't demote themselves and the last admin can't be demoted.
+func (u UserRoleService) UpdateRole(ctx context.Context, req model.UpdateUserRoleRequest) (int, error) {
+	if req.RoleID != dto.ADMIN {
+		if err := checkOwnAccount(ctx, req.ID, "demote"); err != nil {
+			return 0, err
+		}
+	}
+
+	id, err := u.UserRole.UpdateRole(ctx, req.ID, req.RoleID)
+	if err != nil {
+		return 0, errors.Wrap(err, "couldn't update user role")
+	}
+
+	if err := revokeTokens(ctx, u.users, u.refreshTokens, id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
@@ -1,11 +1,15 @@
 package service
 
 import (
+	"context"
+	"github.com/stretchr/testify/mock"
 	"testing"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
 	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
 	m "github.com/JesusG2000/hexsatisfaction/internal/service/mock"
+	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
 	"github.com/pkg/errors"
 	testAssert "github.com/stretchr/testify/assert"
 )
@@ -22,7 +26,7 @@ func TestUserRoleService_FindAllUser(t *testing.T) {
 		{
 			name: "FindAllUser errors",
 			fn: func(userRole *m.UserRole, data test) {
-				userRole.On("FindAllUser").
+				userRole.On("FindAllUser", mock.Anything).
 					Return(data.expRes, errors.New(""))
 			},
 			expErr: errors.Wrap(errors.New(""), "couldn't find users"),
@@ -30,7 +34,7 @@ func TestUserRoleService_FindAllUser(t *testing.T) {
 		{
 			name: "All ok",
 			fn: func(userRole *m.UserRole, data test) {
-				userRole.On("FindAllUser").
+				userRole.On("FindAllUser", mock.Anything).
 					Return(data.expRes, nil)
 			},
 			expRes: []model.User{
@@ -52,11 +56,11 @@ func TestUserRoleService_FindAllUser(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			userRole := new(m.UserRole)
-			service := NewUserRoleService(userRole)
+			service := NewUserRoleService(userRole, nil, nil)
 			if tc.fn != nil {
 				tc.fn(userRole, tc)
 			}
-			users, err := service.FindAllUser()
+			users, err := service.FindAllUser(context.Background())
 			if err != nil {
 				assert.Equal(tc.expErr.Error(), err.Error())
 			}
@@ -64,3 +68,190 @@ func TestUserRoleService_FindAllUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRoleService_FindPermissions(t *testing.T) {
+	assert := testAssert.New(t)
+	type test struct {
+		name        string
+		fn          func(userRole *m.UserRole, data test)
+		permissions []model.RolePermission
+		expRes      map[int][]string
+		expErr      error
+	}
+	tt := []test{
+		{
+			name: "FindAllPermissions errors",
+			fn: func(userRole *m.UserRole, data test) {
+				userRole.On("FindAllPermissions", mock.Anything).
+					Return(data.permissions, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't find permissions"),
+		},
+		{
+			name: "All ok",
+			fn: func(userRole *m.UserRole, data test) {
+				userRole.On("FindAllPermissions", mock.Anything).
+					Return(data.permissions, nil)
+			},
+			permissions: []model.RolePermission{
+				{RoleID: dto.ADMIN, Permission: dto.AuthorWrite},
+				{RoleID: dto.ADMIN, Permission: dto.UserList},
+				{RoleID: dto.USER, Permission: dto.AuthorWrite},
+			},
+			expRes: map[int][]string{
+				dto.ADMIN: {dto.AuthorWrite, dto.UserList},
+				dto.USER:  {dto.AuthorWrite},
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			userRole := new(m.UserRole)
+			service := NewUserRoleService(userRole, nil, nil)
+			if tc.fn != nil {
+				tc.fn(userRole, tc)
+			}
+			permissions, err := service.FindPermissions(context.Background())
+			if err != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
+			}
+			assert.Equal(tc.expRes, permissions)
+		})
+	}
+}
+
+func TestUserRoleService_UpdateRole(t *testing.T) {
+	assert := testAssert.New(t)
+	type test struct {
+		name      string
+		req       model.UpdateUserRoleRequest
+		principal *auth.Principal
+		fn        func(userRole *m.UserRole, user *m.User, refreshToken *m.RefreshToken, data test)
+		expRes    int
+		expErr    error
+	}
+	tt := []test{
+		{
+			name: "demote own account",
+			req: model.UpdateUserRoleRequest{
+				ID:     1,
+				RoleID: dto.USER,
+			},
+			principal: &auth.Principal{UserID: 1, Role: dto.ADMIN},
+			expErr:    domain.Errorf(domain.ErrForbidden, "admins can't demote their own account"),
+		},
+		{
+			name: "UpdateRole errors",
+			req: model.UpdateUserRoleRequest{
+				ID:     1,
+				RoleID: dto.ADMIN,
+			},
+			fn: func(userRole *m.UserRole, user *m.User, refreshToken *m.RefreshToken, data test) {
+				userRole.On("UpdateRole", mock.Anything, data.req.ID, data.req.RoleID).
+					Return(0, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't update user role"),
+		},
+		{
+			name: "demote the last admin",
+			req: model.UpdateUserRoleRequest{
+				ID:     1,
+				RoleID: dto.USER,
+			},
+			principal: &auth.Principal{UserID: 2, Role: dto.ADMIN},
+			fn: func(userRole *m.UserRole, user *m.User, refreshToken *m.RefreshToken, data test) {
+				userRole.On("UpdateRole", mock.Anything, data.req.ID, data.req.RoleID).
+					Return(0, domain.Errorf(domain.ErrConflict, "user is the last admin"))
+			},
+			expErr: errors.Wrap(domain.Errorf(domain.ErrConflict, "user is the last admin"), "couldn't update user role"),
+		},
+		{
+			name: "RevokeByUserID errors",
+			req: model.UpdateUserRoleRequest{
+				ID:     1,
+				RoleID: dto.USER,
+			},
+			fn: func(userRole *m.UserRole, user *m.User, refreshToken *m.RefreshToken, data test) {
+				userRole.On("UpdateRole", mock.Anything, data.req.ID, data.req.RoleID).
+					Return(data.req.ID, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(0, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't revoke refresh tokens"),
+		},
+		{
+			name: "RevokeTokens errors",
+			req: model.UpdateUserRoleRequest{
+				ID:     1,
+				RoleID: dto.USER,
+			},
+			fn: func(userRole *m.UserRole, user *m.User, refreshToken *m.RefreshToken, data test) {
+				userRole.On("UpdateRole", mock.Anything, data.req.ID, data.req.RoleID).
+					Return(data.req.ID, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(1, nil)
+				user.On("RevokeTokens", mock.Anything, data.req.ID).
+					Return(0, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't revoke access tokens"),
+		},
+		{
+			name: "Demote",
+			req: model.UpdateUserRoleRequest{
+				ID:     1,
+				RoleID: dto.USER,
+			},
+			principal: &auth.Principal{UserID: 2, Role: dto.ADMIN},
+			fn: func(userRole *m.UserRole, user *m.User, refreshToken *m.RefreshToken, data test) {
+				userRole.On("UpdateRole", mock.Anything, data.req.ID, data.req.RoleID).
+					Return(data.req.ID, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(2, nil)
+				user.On("RevokeTokens", mock.Anything, data.req.ID).
+					Return(data.req.ID, nil)
+			},
+			expRes: 1,
+		},
+		{
+			name: "Promote own account",
+			req: model.UpdateUserRoleRequest{
+				ID:     1,
+				RoleID: dto.ADMIN,
+			},
+			principal: &auth.Principal{UserID: 1, Role: dto.ADMIN},
+			fn: func(userRole *m.UserRole, user *m.User, refreshToken *m.RefreshToken, data test) {
+				userRole.On("UpdateRole", mock.Anything, data.req.ID, data.req.RoleID).
+					Return(data.req.ID, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(0, nil)
+				user.On("RevokeTokens", mock.Anything, data.req.ID).
+					Return(data.req.ID, nil)
+			},
+			expRes: 1,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			userRole := new(m.UserRole)
+			user := new(m.User)
+			refreshToken := new(m.RefreshToken)
+			service := NewUserRoleService(userRole, user, refreshToken)
+			if tc.fn != nil {
+				tc.fn(userRole, user, refreshToken, tc)
+			}
+			ctx := context.Background()
+			if tc.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tc.principal)
+			}
+			id, err := service.UpdateRole(ctx, tc.req)
+			if err != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
+			}
+			assert.Equal(tc.expErr == nil, err == nil)
+			assert.Equal(tc.expRes, id)
+			userRole.AssertExpectations(t)
+			user.AssertExpectations(t)
+			refreshToken.AssertExpectations(t)
+		})
+	}
+}
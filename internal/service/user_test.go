@@ -1,16 +1,25 @@
 package service
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/model"
 	"github.com/JesusG2000/hexsatisfaction/internal/model/dto"
 	m "github.com/JesusG2000/hexsatisfaction/internal/service/mock"
+	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/JesusG2000/hexsatisfaction/pkg/hash"
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
 	"github.com/pkg/errors"
 	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+var testLockout = Lockout{MaxFailures: 3, Backoff: time.Second, Duration: time.Minute}
+
 func TestUser_FindByLogin(t *testing.T) {
 	assert := testAssert.New(t)
 	api, err := InitTest4Mock()
@@ -27,7 +36,7 @@ func TestUser_FindByLogin(t *testing.T) {
 			name:  "FindByID errors",
 			login: "test",
 			fn: func(user *m.User, data test) {
-				user.On("FindByLogin", data.login).
+				user.On("FindByLogin", mock.Anything, data.login).
 					Return(data.expRes, errors.New(""))
 			},
 			expErr: errors.Wrap(errors.New(""), "couldn't find a user by login"),
@@ -36,7 +45,7 @@ func TestUser_FindByLogin(t *testing.T) {
 			name:  "All ok",
 			login: "test",
 			fn: func(user *m.User, data test) {
-				user.On("FindByLogin", data.login).
+				user.On("FindByLogin", mock.Anything, data.login).
 					Return(data.expRes, nil)
 			},
 			expRes: &model.User{
@@ -50,11 +59,11 @@ func TestUser_FindByLogin(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			user := new(m.User)
-			service := NewUserService(user, api.TokenManager)
+			service := NewUserService(user, new(m.RefreshToken), api.TokenManager, api.PasswordHasher, testLockout, logger.Nop())
 			if tc.fn != nil {
 				tc.fn(user, tc)
 			}
-			u, err := service.FindByLogin(tc.login)
+			u, err := service.FindByLogin(context.Background(), tc.login)
 			if err != nil {
 				assert.Equal(tc.expErr.Error(), err.Error())
 			}
@@ -67,39 +76,180 @@ func TestUser_FindByCredentials(t *testing.T) {
 	assert := testAssert.New(t)
 	api, err := InitTest4Mock()
 	require.NoError(t, err)
+	hashed, err := api.PasswordHasher.Hash("test")
+	require.NoError(t, err)
 	type test struct {
-		name   string
-		req    model.LoginUserRequest
-		fn     func(user *m.User, data test)
-		expRes *model.User
-		expErr error
+		name     string
+		req      model.LoginUserRequest
+		fn       func(user *m.User, refreshToken *m.RefreshToken, data test)
+		expRes   *model.User
+		expToken bool
+		expDummy bool
+		expErr   error
 	}
 	tt := []test{
 		{
-			name: "FindByCredentials errors",
+			name: "FindByLogin errors",
 			req: model.LoginUserRequest{
 				Login:    "test",
 				Password: "test",
 			},
-			fn: func(user *m.User, data test) {
-				user.On("FindByCredentials", model.User{
-					Login:    data.req.Login,
-					Password: data.req.Password,
-				}).Return(data.expRes, errors.New(""))
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByLogin", mock.Anything, data.req.Login).
+					Return(data.expRes, errors.New(""))
 			},
 			expErr: errors.Wrap(errors.New(""), "couldn't find a user by credentials"),
 		},
 		{
-			name: "All ok",
+			name: "Unknown login",
 			req: model.LoginUserRequest{
 				Login:    "test",
 				Password: "test",
 			},
-			fn: func(user *m.User, data test) {
-				user.On("FindByCredentials", model.User{
-					Login:    data.req.Login,
-					Password: data.req.Password,
-				}).Return(data.expRes, nil)
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByLogin", mock.Anything, data.req.Login).
+					Return(data.expRes, domain.ErrNotFound)
+			},
+			expDummy: true,
+		},
+		{
+			name: "Disabled user",
+			req: model.LoginUserRequest{
+				Login:    "test",
+				Password: "test",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByLogin", mock.Anything, data.req.Login).
+					Return(data.expRes, nil)
+			},
+			expRes: &model.User{
+				ID:       15,
+				Login:    "test",
+				Password: hashed,
+				RoleID:   dto.USER,
+				Disabled: true,
+			},
+			expDummy: true,
+		},
+		{
+			name: "Wrong password",
+			req: model.LoginUserRequest{
+				Login:    "test",
+				Password: "wrong",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByLogin", mock.Anything, data.req.Login).
+					Return(data.expRes, nil)
+				user.On("RecordFailedLogin", mock.Anything, data.expRes.ID, testLockout.MaxFailures, testLockout.Backoff, testLockout.Duration).
+					Return(time.Now().Add(time.Second), nil)
+			},
+			expRes: &model.User{
+				ID:       15,
+				Login:    "test",
+				Password: hashed,
+				RoleID:   dto.USER,
+			},
+		},
+		{
+			name: "RecordFailedLogin errors",
+			req: model.LoginUserRequest{
+				Login:    "test",
+				Password: "wrong",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByLogin", mock.Anything, data.req.Login).
+					Return(data.expRes, nil)
+				user.On("RecordFailedLogin", mock.Anything, data.expRes.ID, testLockout.MaxFailures, testLockout.Backoff, testLockout.Duration).
+					Return(time.Time{}, errors.New(""))
+			},
+			expRes: &model.User{
+				ID:       15,
+				Login:    "test",
+				Password: hashed,
+				RoleID:   dto.USER,
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't record a failed login"),
+		},
+		{
+			name: "Locked user",
+			req: model.LoginUserRequest{
+				Login:    "test",
+				Password: "test",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByLogin", mock.Anything, data.req.Login).
+					Return(data.expRes, nil)
+			},
+			expRes: &model.User{
+				ID:           15,
+				Login:        "test",
+				Password:     hashed,
+				RoleID:       dto.USER,
+				FailedLogins: 3,
+				LockedUntil:  timePtr(time.Now().Add(time.Minute)),
+			},
+			expDummy: true,
+		},
+		{
+			name: "Expired lock is reset",
+			req: model.LoginUserRequest{
+				Login:    "test",
+				Password: "test",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByLogin", mock.Anything, data.req.Login).
+					Return(data.expRes, nil)
+				user.On("ResetFailedLogins", mock.Anything, data.expRes.ID).
+					Return(data.expRes.ID, nil)
+				refreshToken.On("Create", mock.Anything, mock.AnythingOfType("model.RefreshToken")).
+					Return(1, nil)
+			},
+			expRes: &model.User{
+				ID:           15,
+				Login:        "test",
+				Password:     hashed,
+				RoleID:       dto.USER,
+				FailedLogins: 3,
+				LockedUntil:  timePtr(time.Now().Add(-time.Minute)),
+			},
+			expToken: true,
+		},
+		{
+			name: "Rehash errors",
+			req: model.LoginUserRequest{
+				Login:    "test",
+				Password: "test",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByLogin", mock.Anything, data.req.Login).
+					Return(data.expRes, nil)
+				user.On("UpdatePassword", mock.Anything, data.expRes.ID, mock.AnythingOfType("string")).
+					Return(0, errors.New(""))
+			},
+			expRes: &model.User{
+				ID:       15,
+				Login:    "test",
+				Password: "test",
+				RoleID:   dto.USER,
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't rehash a password"),
+		},
+		{
+			name: "Plaintext password is rehashed",
+			req: model.LoginUserRequest{
+				Login:    "test",
+				Password: "test",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByLogin", mock.Anything, data.req.Login).
+					Return(data.expRes, nil)
+				user.On("UpdatePassword", mock.Anything, data.expRes.ID, mock.MatchedBy(func(password string) bool {
+					ok, err := api.PasswordHasher.Verify(password, data.req.Password)
+					return err == nil && ok
+				})).Return(data.expRes.ID, nil)
+				refreshToken.On("Create", mock.Anything, mock.MatchedBy(func(token model.RefreshToken) bool {
+					return token.UserID == data.expRes.ID && token.Token == token.Family
+				})).Return(1, nil)
 			},
 			expRes: &model.User{
 				ID:       15,
@@ -107,20 +257,368 @@ func TestUser_FindByCredentials(t *testing.T) {
 				Password: "test",
 				RoleID:   dto.USER,
 			},
+			expToken: true,
+		},
+		{
+			name: "Password reset required",
+			req: model.LoginUserRequest{
+				Login:    "test",
+				Password: "test",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByLogin", mock.Anything, data.req.Login).
+					Return(data.expRes, nil)
+				refreshToken.On("Create", mock.Anything, mock.AnythingOfType("model.RefreshToken")).
+					Return(1, nil)
+			},
+			expRes: &model.User{
+				ID:                    15,
+				Login:                 "test",
+				Password:              hashed,
+				RoleID:                dto.USER,
+				PasswordResetRequired: true,
+			},
+			expToken: true,
+		},
+		{
+			name: "All ok",
+			req: model.LoginUserRequest{
+				Login:    "test",
+				Password: "test",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByLogin", mock.Anything, data.req.Login).
+					Return(data.expRes, nil)
+				refreshToken.On("Create", mock.Anything, mock.AnythingOfType("model.RefreshToken")).
+					Return(1, nil)
+			},
+			expRes: &model.User{
+				ID:       15,
+				Login:    "test",
+				Password: hashed,
+				RoleID:   dto.USER,
+			},
+			expToken: true,
 		},
 	}
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			user := new(m.User)
-			service := NewUserService(user, api.TokenManager)
+			refreshToken := new(m.RefreshToken)
+			hasher := &verifySpy{PasswordHasher: api.PasswordHasher}
+			service := NewUserService(user, refreshToken, api.TokenManager, hasher, testLockout, logger.Nop())
 			if tc.fn != nil {
-				tc.fn(user, tc)
+				tc.fn(user, refreshToken, tc)
+			}
+			tokens, err := service.FindByCredentials(context.Background(), tc.req)
+			if err != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
+			}
+			assert.Equal(tc.expToken, tokens != nil)
+			assert.Equal(tc.expDummy, hasher.verified[service.dummyHash])
+			if tokens != nil {
+				assert.Equal(tc.expRes.PasswordResetRequired, tokens.PasswordResetRequired)
+			}
+			user.AssertExpectations(t)
+			refreshToken.AssertExpectations(t)
+		})
+	}
+}
+
+// warnLogger records warnings.
+type warnLogger struct {
+	logger.Logger
+	warns []string
+}
+
+func (l *warnLogger) Warn(msg string, _ ...interface{}) {
+	l.warns = append(l.warns, msg)
+}
+
+func (l *warnLogger) WithContext(context.Context) logger.Logger {
+	return l
+}
+
+func TestUser_FindByCredentialsLockout(t *testing.T) {
+	assert := testAssert.New(t)
+	api, err := InitTest4Mock()
+	require.NoError(t, err)
+	hashed, err := api.PasswordHasher.Hash("test")
+	require.NoError(t, err)
+	tt := []struct {
+		name         string
+		failedLogins int
+		isLocked     bool
+	}{
+		{
+			name: "first failure",
+		},
+		{
+			name:         "failure before max failures",
+			failedLogins: testLockout.MaxFailures - 2,
+		},
+		{
+			name:         "failure reaching max failures",
+			failedLogins: testLockout.MaxFailures - 1,
+			isLocked:     true,
+		},
+		{
+			name:         "failure after max failures",
+			failedLogins: testLockout.MaxFailures,
+			isLocked:     true,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			user := new(m.User)
+			log := &warnLogger{Logger: logger.Nop()}
+			service := NewUserService(user, new(m.RefreshToken), api.TokenManager, api.PasswordHasher, testLockout, log)
+			user.On("FindByLogin", mock.Anything, "test").
+				Return(&model.User{ID: 15, Login: "test", Password: hashed, FailedLogins: tc.failedLogins}, nil)
+			user.On("RecordFailedLogin", mock.Anything, 15, testLockout.MaxFailures, testLockout.Backoff, testLockout.Duration).
+				Return(time.Now().Add(testLockout.Duration), nil)
+
+			tokens, err := service.FindByCredentials(context.Background(), model.LoginUserRequest{Login: "test", Password: "wrong"})
+			assert.Nil(err)
+			assert.Nil(tokens)
+			assert.Equal(tc.isLocked, len(log.warns) == 1)
+			user.AssertExpectations(t)
+		})
+	}
+}
+func TestUser_Refresh(t *testing.T) {
+	assert := testAssert.New(t)
+	api, err := InitTest4Mock()
+	require.NoError(t, err)
+	type test struct {
+		name     string
+		req      model.RefreshTokenRequest
+		fn       func(user *m.User, refreshToken *m.RefreshToken, data test)
+		token    *model.RefreshToken
+		expToken bool
+		expErr   error
+	}
+	tt := []test{
+		{
+			name: "FindByToken errors",
+			req: model.RefreshTokenRequest{
+				RefreshToken: "test",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				refreshToken.On("FindByToken", mock.Anything, auth.HashToken(data.req.RefreshToken)).
+					Return(data.token, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't find a refresh token"),
+		},
+		{
+			name: "Unknown token",
+			req: model.RefreshTokenRequest{
+				RefreshToken: "test",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				refreshToken.On("FindByToken", mock.Anything, auth.HashToken(data.req.RefreshToken)).
+					Return(data.token, domain.ErrNotFound)
+			},
+			token: &model.RefreshToken{},
+		},
+		{
+			name: "Expired token",
+			req: model.RefreshTokenRequest{
+				RefreshToken: "test",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				refreshToken.On("FindByToken", mock.Anything, auth.HashToken(data.req.RefreshToken)).
+					Return(data.token, nil)
+			},
+			token: &model.RefreshToken{
+				ID:        1,
+				UserID:    15,
+				Family:    "family",
+				ExpiresAt: time.Now().Add(-time.Hour),
+			},
+		},
+		{
+			name: "Reused token revokes family",
+			req: model.RefreshTokenRequest{
+				RefreshToken: "test",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				refreshToken.On("FindByToken", mock.Anything, auth.HashToken(data.req.RefreshToken)).
+					Return(data.token, nil)
+				refreshToken.On("RevokeFamily", mock.Anything, data.token.Family).
+					Return(2, nil)
+			},
+			token: &model.RefreshToken{
+				ID:        1,
+				UserID:    15,
+				Family:    "family",
+				ExpiresAt: time.Now().Add(time.Hour),
+				Revoked:   true,
+			},
+		},
+		{
+			name: "Concurrent rotation revokes family",
+			req: model.RefreshTokenRequest{
+				RefreshToken: "test",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				refreshToken.On("FindByToken", mock.Anything, auth.HashToken(data.req.RefreshToken)).
+					Return(data.token, nil)
+				refreshToken.On("Revoke", mock.Anything, data.token.ID).
+					Return(0, domain.ErrNotFound)
+				refreshToken.On("RevokeFamily", mock.Anything, data.token.Family).
+					Return(2, nil)
+			},
+			token: &model.RefreshToken{
+				ID:        1,
+				UserID:    15,
+				Family:    "family",
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+		},
+		{
+			name: "Deleted user",
+			req: model.RefreshTokenRequest{
+				RefreshToken: "test",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				refreshToken.On("FindByToken", mock.Anything, auth.HashToken(data.req.RefreshToken)).
+					Return(data.token, nil)
+				refreshToken.On("Revoke", mock.Anything, data.token.ID).
+					Return(data.token.ID, nil)
+				user.On("FindByID", mock.Anything, data.token.UserID).
+					Return(nil, domain.ErrNotFound)
+			},
+			token: &model.RefreshToken{
+				ID:        1,
+				UserID:    15,
+				Family:    "family",
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+		},
+		{
+			name: "All ok",
+			req: model.RefreshTokenRequest{
+				RefreshToken: "test",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				refreshToken.On("FindByToken", mock.Anything, auth.HashToken(data.req.RefreshToken)).
+					Return(data.token, nil)
+				refreshToken.On("Revoke", mock.Anything, data.token.ID).
+					Return(data.token.ID, nil)
+				user.On("FindByID", mock.Anything, data.token.UserID).
+					Return(&model.User{ID: data.token.UserID, RoleID: dto.USER}, nil)
+				refreshToken.On("Create", mock.Anything, mock.MatchedBy(func(token model.RefreshToken) bool {
+					return token.UserID == data.token.UserID && token.Family == data.token.Family
+				})).Return(2, nil)
+			},
+			token: &model.RefreshToken{
+				ID:        1,
+				UserID:    15,
+				Family:    "family",
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+			expToken: true,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			user := new(m.User)
+			refreshToken := new(m.RefreshToken)
+			service := NewUserService(user, refreshToken, api.TokenManager, api.PasswordHasher, testLockout, logger.Nop())
+			if tc.fn != nil {
+				tc.fn(user, refreshToken, tc)
 			}
-			token, err := service.FindByCredentials(tc.req)
+			tokens, err := service.Refresh(context.Background(), tc.req)
 			if err != nil {
 				assert.Equal(tc.expErr.Error(), err.Error())
+			}
+			assert.Equal(tc.expToken, tokens != nil)
+			user.AssertExpectations(t)
+			refreshToken.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUser_Logout(t *testing.T) {
+	assert := testAssert.New(t)
+	api, err := InitTest4Mock()
+	require.NoError(t, err)
+	type test struct {
+		name   string
+		req    model.LogoutUserRequest
+		fn     func(refreshToken *m.RefreshToken, data test)
+		token  *model.RefreshToken
+		expErr error
+	}
+	tt := []test{
+		{
+			name: "FindByToken errors",
+			req: model.LogoutUserRequest{
+				RefreshToken: "test",
+			},
+			fn: func(refreshToken *m.RefreshToken, data test) {
+				refreshToken.On("FindByToken", mock.Anything, auth.HashToken(data.req.RefreshToken)).
+					Return(data.token, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't find a refresh token"),
+		},
+		{
+			name: "Unknown token",
+			req: model.LogoutUserRequest{
+				RefreshToken: "test",
+			},
+			fn: func(refreshToken *m.RefreshToken, data test) {
+				refreshToken.On("FindByToken", mock.Anything, auth.HashToken(data.req.RefreshToken)).
+					Return(data.token, domain.ErrNotFound)
+			},
+		},
+		{
+			name: "RevokeFamily errors",
+			req: model.LogoutUserRequest{
+				RefreshToken: "test",
+			},
+			fn: func(refreshToken *m.RefreshToken, data test) {
+				refreshToken.On("FindByToken", mock.Anything, auth.HashToken(data.req.RefreshToken)).
+					Return(data.token, nil)
+				refreshToken.On("RevokeFamily", mock.Anything, data.token.Family).
+					Return(0, errors.New(""))
+			},
+			token: &model.RefreshToken{
+				ID:     1,
+				Family: "family",
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't revoke refresh tokens"),
+		},
+		{
+			name: "All ok",
+			req: model.LogoutUserRequest{
+				RefreshToken: "test",
+			},
+			fn: func(refreshToken *m.RefreshToken, data test) {
+				refreshToken.On("FindByToken", mock.Anything, auth.HashToken(data.req.RefreshToken)).
+					Return(data.token, nil)
+				refreshToken.On("RevokeFamily", mock.Anything, data.token.Family).
+					Return(1, nil)
+			},
+			token: &model.RefreshToken{
+				ID:     1,
+				Family: "family",
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			refreshToken := new(m.RefreshToken)
+			service := NewUserService(new(m.User), refreshToken, api.TokenManager, api.PasswordHasher, testLockout, logger.Nop())
+			if tc.fn != nil {
+				tc.fn(refreshToken, tc)
+			}
+			err := service.Logout(context.Background(), tc.req)
+			if tc.expErr != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
 			} else {
-				assert.NotEmpty(token)
+				assert.Nil(err)
 			}
 		})
 	}
@@ -142,7 +640,7 @@ func TestUser_IsExist(t *testing.T) {
 			name:  "IsExist errors",
 			login: "test",
 			fn: func(user *m.User, data test) {
-				user.On("IsExist", data.login).
+				user.On("IsExist", mock.Anything, data.login).
 					Return(data.expRes, errors.New(""))
 			},
 			expErr: errors.Wrap(errors.New(""), "couldn't check user existence"),
@@ -151,7 +649,7 @@ func TestUser_IsExist(t *testing.T) {
 			name:  "All ok",
 			login: "test",
 			fn: func(user *m.User, data test) {
-				user.On("IsExist", data.login).
+				user.On("IsExist", mock.Anything, data.login).
 					Return(data.expRes, nil)
 			},
 			expRes: true,
@@ -160,11 +658,11 @@ func TestUser_IsExist(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			user := new(m.User)
-			service := NewUserService(user, api.TokenManager)
+			service := NewUserService(user, new(m.RefreshToken), api.TokenManager, api.PasswordHasher, testLockout, logger.Nop())
 			if tc.fn != nil {
 				tc.fn(user, tc)
 			}
-			exist, err := service.IsExist(tc.login)
+			exist, err := service.IsExist(context.Background(), tc.login)
 			if err != nil {
 				assert.Equal(tc.expErr.Error(), err.Error())
 			}
@@ -185,6 +683,30 @@ func TestUser_Create(t *testing.T) {
 		expErr error
 	}
 	tt := []test{
+		{
+			name: "IsExist errors",
+			req: model.RegisterUserRequest{
+				Login:    "test",
+				Password: "test",
+			},
+			fn: func(user *m.User, data test) {
+				user.On("IsExist", mock.Anything, data.req.Login).
+					Return(false, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't check a user existence"),
+		},
+		{
+			name: "user exists",
+			req: model.RegisterUserRequest{
+				Login:    "test",
+				Password: "test",
+			},
+			fn: func(user *m.User, data test) {
+				user.On("IsExist", mock.Anything, data.req.Login).
+					Return(true, nil)
+			},
+			expErr: domain.Errorf(domain.ErrConflict, "user already exists"),
+		},
 		{
 			name: "Create errors",
 			req: model.RegisterUserRequest{
@@ -192,10 +714,12 @@ func TestUser_Create(t *testing.T) {
 				Password: "test",
 			},
 			fn: func(user *m.User, data test) {
-				user.On("Create", model.User{
-					Login:    data.req.Login,
-					Password: data.req.Password,
-				}).
+				user.On("IsExist", mock.Anything, data.req.Login).
+					Return(false, nil)
+				user.On("Create", mock.Anything, mock.MatchedBy(func(u model.User) bool {
+					ok, err := api.PasswordHasher.Verify(u.Password, data.req.Password)
+					return u.Login == data.req.Login && err == nil && ok
+				})).
 					Return(0, errors.New(""))
 			},
 			expErr: errors.Wrap(errors.New(""), "couldn't create a user"),
@@ -207,10 +731,12 @@ func TestUser_Create(t *testing.T) {
 				Password: "test",
 			},
 			fn: func(user *m.User, data test) {
-				user.On("Create", model.User{
-					Login:    data.req.Login,
-					Password: data.req.Password,
-				}).
+				user.On("IsExist", mock.Anything, data.req.Login).
+					Return(false, nil)
+				user.On("Create", mock.Anything, mock.MatchedBy(func(u model.User) bool {
+					ok, err := api.PasswordHasher.Verify(u.Password, data.req.Password)
+					return u.Login == data.req.Login && err == nil && ok
+				})).
 					Return(data.expID, nil)
 			},
 			expID: 15,
@@ -219,11 +745,11 @@ func TestUser_Create(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			user := new(m.User)
-			service := NewUserService(user, api.TokenManager)
+			service := NewUserService(user, new(m.RefreshToken), api.TokenManager, api.PasswordHasher, testLockout, logger.Nop())
 			if tc.fn != nil {
 				tc.fn(user, tc)
 			}
-			id, err := service.Create(tc.req)
+			id, err := service.Create(context.Background(), tc.req)
 			if err != nil {
 				assert.Equal(tc.expErr.Error(), err.Error())
 			}
@@ -231,3 +757,703 @@ func TestUser_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_FindAll(t *testing.T) {
+	assert := testAssert.New(t)
+	api, err := InitTest4Mock()
+	require.NoError(t, err)
+	type test struct {
+		name   string
+		req    model.ListUsersRequest
+		fn     func(user *m.User, data test)
+		users  []model.User
+		expRes *model.UserPage
+		expErr error
+	}
+	tt := []test{
+		{
+			name: "FindAll errors",
+			req: model.ListUsersRequest{
+				Limit: 10,
+			},
+			fn: func(user *m.User, data test) {
+				user.On("FindAll", mock.Anything, data.req.Limit, data.req.Offset).
+					Return(data.users, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't find users"),
+		},
+		{
+			name: "Count errors",
+			req: model.ListUsersRequest{
+				Limit: 10,
+			},
+			fn: func(user *m.User, data test) {
+				user.On("FindAll", mock.Anything, data.req.Limit, data.req.Offset).
+					Return(data.users, nil)
+				user.On("Count", mock.Anything, mock.Anything).
+					Return(0, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't count users"),
+		},
+		{
+			name: "Empty page",
+			req: model.ListUsersRequest{
+				Limit:  10,
+				Offset: 20,
+			},
+			fn: func(user *m.User, data test) {
+				user.On("FindAll", mock.Anything, data.req.Limit, data.req.Offset).
+					Return(data.users, nil)
+				user.On("Count", mock.Anything, mock.Anything).
+					Return(data.expRes.Total, nil)
+			},
+			expRes: &model.UserPage{
+				Items: []model.User{},
+				Total: 2,
+			},
+		},
+		{
+			name: "All ok",
+			req: model.ListUsersRequest{
+				Limit: 10,
+			},
+			fn: func(user *m.User, data test) {
+				user.On("FindAll", mock.Anything, data.req.Limit, data.req.Offset).
+					Return(data.users, nil)
+				user.On("Count", mock.Anything, mock.Anything).
+					Return(data.expRes.Total, nil)
+			},
+			users: []model.User{
+				{ID: 1, Login: "test", RoleID: dto.USER},
+			},
+			expRes: &model.UserPage{
+				Items: []model.User{
+					{ID: 1, Login: "test", RoleID: dto.USER},
+				},
+				Total: 1,
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			user := new(m.User)
+			service := NewUserService(user, new(m.RefreshToken), api.TokenManager, api.PasswordHasher, testLockout, logger.Nop())
+			if tc.fn != nil {
+				tc.fn(user, tc)
+			}
+			page, err := service.FindAll(context.Background(), tc.req)
+			if err != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
+			}
+			assert.Equal(tc.expRes, page)
+		})
+	}
+}
+
+func TestUser_SetDisabled(t *testing.T) {
+	assert := testAssert.New(t)
+	api, err := InitTest4Mock()
+	require.NoError(t, err)
+	type test struct {
+		name      string
+		req       model.DisableUserRequest
+		principal *auth.Principal
+		fn        func(user *m.User, refreshToken *m.RefreshToken, data test)
+		expRes    int
+		expErr    error
+	}
+	tt := []test{
+		{
+			name: "disable own account",
+			req: model.DisableUserRequest{
+				ID:       1,
+				Disabled: true,
+			},
+			principal: &auth.Principal{UserID: 1, Role: dto.ADMIN},
+			expErr:    domain.Errorf(domain.ErrForbidden, "admins can't disable their own account"),
+		},
+		{
+			name: "disable the last admin",
+			req: model.DisableUserRequest{
+				ID:       1,
+				Disabled: true,
+			},
+			principal: &auth.Principal{UserID: 2, Role: dto.ADMIN},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("SetDisabled", mock.Anything, data.req.ID, data.req.Disabled).
+					Return(0, domain.Errorf(domain.ErrConflict, "user is the last admin"))
+			},
+			expErr: errors.Wrap(domain.Errorf(domain.ErrConflict, "user is the last admin"), "couldn't update a user"),
+		},
+		{
+			name: "SetDisabled errors",
+			req: model.DisableUserRequest{
+				ID:       1,
+				Disabled: true,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("SetDisabled", mock.Anything, data.req.ID, data.req.Disabled).
+					Return(0, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't update a user"),
+		},
+		{
+			name: "RevokeByUserID errors",
+			req: model.DisableUserRequest{
+				ID:       1,
+				Disabled: true,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("SetDisabled", mock.Anything, data.req.ID, data.req.Disabled).
+					Return(data.req.ID, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(0, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't revoke refresh tokens"),
+		},
+		{
+			name: "RevokeTokens errors",
+			req: model.DisableUserRequest{
+				ID:       1,
+				Disabled: true,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("SetDisabled", mock.Anything, data.req.ID, data.req.Disabled).
+					Return(data.req.ID, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(1, nil)
+				user.On("RevokeTokens", mock.Anything, data.req.ID).
+					Return(0, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't revoke access tokens"),
+		},
+		{
+			name: "No user",
+			req: model.DisableUserRequest{
+				ID:       1,
+				Disabled: true,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("SetDisabled", mock.Anything, data.req.ID, data.req.Disabled).
+					Return(0, domain.ErrNotFound)
+			},
+			expErr: errors.Wrap(domain.ErrNotFound, "couldn't update a user"),
+		},
+		{
+			name: "Enable",
+			req: model.DisableUserRequest{
+				ID: 1,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("SetDisabled", mock.Anything, data.req.ID, data.req.Disabled).
+					Return(data.expRes, nil)
+			},
+			expRes: 1,
+		},
+		{
+			name: "Disable",
+			req: model.DisableUserRequest{
+				ID:       1,
+				Disabled: true,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("SetDisabled", mock.Anything, data.req.ID, data.req.Disabled).
+					Return(data.expRes, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(2, nil)
+				user.On("RevokeTokens", mock.Anything, data.req.ID).
+					Return(data.req.ID, nil)
+			},
+			expRes: 1,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			user := new(m.User)
+			refreshToken := new(m.RefreshToken)
+			service := NewUserService(user, refreshToken, api.TokenManager, api.PasswordHasher, testLockout, logger.Nop())
+			if tc.fn != nil {
+				tc.fn(user, refreshToken, tc)
+			}
+			ctx := context.Background()
+			if tc.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tc.principal)
+			}
+			id, err := service.SetDisabled(ctx, tc.req)
+			if err != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
+			}
+			assert.Equal(tc.expRes, id)
+			refreshToken.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUser_ResetPassword(t *testing.T) {
+	assert := testAssert.New(t)
+	api, err := InitTest4Mock()
+	require.NoError(t, err)
+	type test struct {
+		name   string
+		req    model.IDUserRequest
+		fn     func(user *m.User, refreshToken *m.RefreshToken, data test)
+		expRes bool
+		expErr error
+	}
+	tt := []test{
+		{
+			name: "ResetPassword errors",
+			req: model.IDUserRequest{
+				ID: 1,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("ResetPassword", mock.Anything, data.req.ID, mock.AnythingOfType("string")).
+					Return(0, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't reset a password"),
+		},
+		{
+			name: "No user",
+			req: model.IDUserRequest{
+				ID: 1,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("ResetPassword", mock.Anything, data.req.ID, mock.AnythingOfType("string")).
+					Return(0, domain.ErrNotFound)
+			},
+			expErr: errors.Wrap(domain.ErrNotFound, "couldn't reset a password"),
+		},
+		{
+			name: "RevokeByUserID errors",
+			req: model.IDUserRequest{
+				ID: 1,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("ResetPassword", mock.Anything, data.req.ID, mock.AnythingOfType("string")).
+					Return(data.req.ID, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(0, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't revoke refresh tokens"),
+		},
+		{
+			name: "RevokeTokens errors",
+			req: model.IDUserRequest{
+				ID: 1,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("ResetPassword", mock.Anything, data.req.ID, mock.AnythingOfType("string")).
+					Return(data.req.ID, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(1, nil)
+				user.On("RevokeTokens", mock.Anything, data.req.ID).
+					Return(0, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't revoke access tokens"),
+		},
+		{
+			name: "All ok",
+			req: model.IDUserRequest{
+				ID: 1,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("ResetPassword", mock.Anything, data.req.ID, mock.AnythingOfType("string")).
+					Return(data.req.ID, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(1, nil)
+				user.On("RevokeTokens", mock.Anything, data.req.ID).
+					Return(data.req.ID, nil)
+			},
+			expRes: true,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			user := new(m.User)
+			refreshToken := new(m.RefreshToken)
+			service := NewUserService(user, refreshToken, api.TokenManager, api.PasswordHasher, testLockout, logger.Nop())
+			if tc.fn != nil {
+				tc.fn(user, refreshToken, tc)
+			}
+			password, err := service.ResetPassword(context.Background(), tc.req)
+			if err != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
+			}
+			if !tc.expRes {
+				assert.Nil(password)
+				return
+			}
+
+			require.NotNil(t, password)
+			assert.NotEmpty(password.Password)
+			hashed := user.Calls[0].Arguments.String(2)
+			ok, err := api.PasswordHasher.Verify(hashed, password.Password)
+			assert.Nil(err)
+			assert.True(ok)
+		})
+	}
+}
+
+func TestUser_Delete(t *testing.T) {
+	assert := testAssert.New(t)
+	api, err := InitTest4Mock()
+	require.NoError(t, err)
+	type test struct {
+		name      string
+		req       model.DeleteUserRequest
+		principal *auth.Principal
+		fn        func(user *m.User, refreshToken *m.RefreshToken, data test)
+		expRes    int
+		expErr    error
+	}
+	tt := []test{
+		{
+			name: "delete own admin account",
+			req: model.DeleteUserRequest{
+				ID: 1,
+			},
+			principal: &auth.Principal{UserID: 1, Role: dto.ADMIN},
+			expErr:    domain.Errorf(domain.ErrForbidden, "admins can't delete their own account"),
+		},
+		{
+			name: "delete the last admin",
+			req: model.DeleteUserRequest{
+				ID: 1,
+			},
+			principal: &auth.Principal{UserID: 2, Role: dto.ADMIN},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("Delete", mock.Anything, data.req.ID, data.req.ReassignTo).
+					Return(0, domain.Errorf(domain.ErrConflict, "user is the last admin"))
+			},
+			expErr: errors.Wrap(domain.Errorf(domain.ErrConflict, "user is the last admin"), "couldn't delete a user"),
+		},
+		{
+			name: "delete own user account",
+			req: model.DeleteUserRequest{
+				ID: 1,
+			},
+			principal: &auth.Principal{UserID: 1, Role: dto.USER},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("Delete", mock.Anything, data.req.ID, data.req.ReassignTo).
+					Return(data.expRes, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.expRes).
+					Return(1, nil)
+				user.On("RevokeTokens", mock.Anything, data.expRes).
+					Return(data.expRes, nil)
+			},
+			expRes: 1,
+		},
+		{
+			name: "Delete errors",
+			req: model.DeleteUserRequest{
+				ID:         1,
+				ReassignTo: 2,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("Delete", mock.Anything, data.req.ID, data.req.ReassignTo).
+					Return(0, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't delete a user"),
+		},
+		{
+			name: "RevokeByUserID errors",
+			req: model.DeleteUserRequest{
+				ID:         1,
+				ReassignTo: 2,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("Delete", mock.Anything, data.req.ID, data.req.ReassignTo).
+					Return(data.req.ID, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(0, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't revoke refresh tokens"),
+		},
+		{
+			name: "RevokeTokens errors",
+			req: model.DeleteUserRequest{
+				ID:         1,
+				ReassignTo: 2,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("Delete", mock.Anything, data.req.ID, data.req.ReassignTo).
+					Return(data.req.ID, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(1, nil)
+				user.On("RevokeTokens", mock.Anything, data.req.ID).
+					Return(0, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't revoke access tokens"),
+		},
+		{
+			name: "All ok",
+			req: model.DeleteUserRequest{
+				ID:         1,
+				ReassignTo: 2,
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("Delete", mock.Anything, data.req.ID, data.req.ReassignTo).
+					Return(data.expRes, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.expRes).
+					Return(1, nil)
+				user.On("RevokeTokens", mock.Anything, data.expRes).
+					Return(data.expRes, nil)
+			},
+			expRes: 1,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			user := new(m.User)
+			refreshToken := new(m.RefreshToken)
+			service := NewUserService(user, refreshToken, api.TokenManager, api.PasswordHasher, testLockout, logger.Nop())
+			if tc.fn != nil {
+				tc.fn(user, refreshToken, tc)
+			}
+			ctx := context.Background()
+			if tc.principal != nil {
+				ctx = auth.WithPrincipal(ctx, tc.principal)
+			}
+			id, err := service.Delete(ctx, tc.req)
+			if err != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
+			}
+			assert.Equal(tc.expRes, id)
+		})
+	}
+}
+
+func TestUser_Restore(t *testing.T) {
+	assert := testAssert.New(t)
+	api, err := InitTest4Mock()
+	require.NoError(t, err)
+	type test struct {
+		name   string
+		req    model.IDUserRequest
+		fn     func(user *m.User, data test)
+		expRes int
+		expErr error
+	}
+	tt := []test{
+		{
+			name: "Restore errors",
+			req: model.IDUserRequest{
+				ID: 1,
+			},
+			fn: func(user *m.User, data test) {
+				user.On("Restore", mock.Anything, data.req.ID).
+					Return(0, domain.ErrNotFound)
+			},
+			expErr: errors.Wrap(domain.ErrNotFound, "couldn't restore a user"),
+		},
+		{
+			name: "All ok",
+			req: model.IDUserRequest{
+				ID: 1,
+			},
+			fn: func(user *m.User, data test) {
+				user.On("Restore", mock.Anything, data.req.ID).
+					Return(data.expRes, nil)
+			},
+			expRes: 1,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			user := new(m.User)
+			service := NewUserService(user, new(m.RefreshToken), api.TokenManager, api.PasswordHasher, testLockout, logger.Nop())
+			if tc.fn != nil {
+				tc.fn(user, tc)
+			}
+			id, err := service.Restore(context.Background(), tc.req)
+			if err != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
+			}
+			assert.Equal(tc.expRes, id)
+		})
+	}
+}
+
+func TestUser_ChangePassword(t *testing.T) {
+	assert := testAssert.New(t)
+	api, err := InitTest4Mock()
+	require.NoError(t, err)
+	hashed, err := api.PasswordHasher.Hash("old")
+	require.NoError(t, err)
+	type test struct {
+		name   string
+		req    model.ChangePasswordRequest
+		fn     func(user *m.User, refreshToken *m.RefreshToken, data test)
+		user   *model.User
+		expErr error
+	}
+	tt := []test{
+		{
+			name: "FindByID errors",
+			req: model.ChangePasswordRequest{
+				ID:          1,
+				OldPassword: "old",
+				NewPassword: "new",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByID", mock.Anything, data.req.ID).
+					Return(data.user, errors.New(""))
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't find a user"),
+		},
+		{
+			name: "No user",
+			req: model.ChangePasswordRequest{
+				ID:          1,
+				OldPassword: "old",
+				NewPassword: "new",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByID", mock.Anything, data.req.ID).
+					Return(data.user, domain.ErrNotFound)
+			},
+			expErr: errors.Wrap(domain.ErrNotFound, "couldn't find a user"),
+		},
+		{
+			name: "Wrong old password",
+			req: model.ChangePasswordRequest{
+				ID:          1,
+				OldPassword: "wrong",
+				NewPassword: "new",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByID", mock.Anything, data.req.ID).
+					Return(data.user, nil)
+			},
+			user: &model.User{
+				ID:       1,
+				Password: hashed,
+			},
+			expErr: domain.Errorf(domain.ErrForbidden, "old password is wrong"),
+		},
+		{
+			name: "UpdatePassword errors",
+			req: model.ChangePasswordRequest{
+				ID:          1,
+				OldPassword: "old",
+				NewPassword: "new",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByID", mock.Anything, data.req.ID).
+					Return(data.user, nil)
+				user.On("UpdatePassword", mock.Anything, data.req.ID, mock.AnythingOfType("string")).
+					Return(0, errors.New(""))
+			},
+			user: &model.User{
+				ID:       1,
+				Password: hashed,
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't update a password"),
+		},
+		{
+			name: "RevokeByUserID errors",
+			req: model.ChangePasswordRequest{
+				ID:          1,
+				OldPassword: "old",
+				NewPassword: "new",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByID", mock.Anything, data.req.ID).
+					Return(data.user, nil)
+				user.On("UpdatePassword", mock.Anything, data.req.ID, mock.AnythingOfType("string")).
+					Return(data.req.ID, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(0, errors.New(""))
+			},
+			user: &model.User{
+				ID:       1,
+				Password: hashed,
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't revoke refresh tokens"),
+		},
+		{
+			name: "RevokeTokens errors",
+			req: model.ChangePasswordRequest{
+				ID:          1,
+				OldPassword: "old",
+				NewPassword: "new",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByID", mock.Anything, data.req.ID).
+					Return(data.user, nil)
+				user.On("UpdatePassword", mock.Anything, data.req.ID, mock.AnythingOfType("string")).
+					Return(data.req.ID, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(1, nil)
+				user.On("RevokeTokens", mock.Anything, data.req.ID).
+					Return(0, errors.New(""))
+			},
+			user: &model.User{
+				ID:       1,
+				Password: hashed,
+			},
+			expErr: errors.Wrap(errors.New(""), "couldn't revoke access tokens"),
+		},
+		{
+			name: "All ok",
+			req: model.ChangePasswordRequest{
+				ID:          1,
+				OldPassword: "old",
+				NewPassword: "new",
+			},
+			fn: func(user *m.User, refreshToken *m.RefreshToken, data test) {
+				user.On("FindByID", mock.Anything, data.req.ID).
+					Return(data.user, nil)
+				user.On("UpdatePassword", mock.Anything, data.req.ID, mock.MatchedBy(func(password string) bool {
+					ok, err := api.PasswordHasher.Verify(password, data.req.NewPassword)
+					return err == nil && ok
+				})).
+					Return(data.req.ID, nil)
+				refreshToken.On("RevokeByUserID", mock.Anything, data.req.ID).
+					Return(1, nil)
+				user.On("RevokeTokens", mock.Anything, data.req.ID).
+					Return(data.req.ID, nil)
+			},
+			user: &model.User{
+				ID:       1,
+				Password: hashed,
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			user := new(m.User)
+			refreshToken := new(m.RefreshToken)
+			service := NewUserService(user, refreshToken, api.TokenManager, api.PasswordHasher, testLockout, logger.Nop())
+			if tc.fn != nil {
+				tc.fn(user, refreshToken, tc)
+			}
+			err := service.ChangePassword(context.Background(), tc.req)
+			if tc.expErr != nil {
+				assert.Equal(tc.expErr.Error(), err.Error())
+			} else {
+				assert.Nil(err)
+			}
+			user.AssertExpectations(t)
+			refreshToken.AssertExpectations(t)
+		})
+	}
+}
+
+// verifySpy records the hashes passwords are verified against.
+type verifySpy struct {
+	hash.PasswordHasher
+	verified map[string]bool
+}
+
+func (s *verifySpy) Verify(hashedPassword, password string) (bool, error) {
+	if s.verified == nil {
+		s.verified = make(map[string]bool)
+	}
+	s.verified[hashedPassword] = true
+	return s.PasswordHasher.Verify(hashedPassword, password)
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/middleware"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// Authorizer checks permissions granted to roles.
+type Authorizer struct {
+	permissions map[int]map[string]struct{}
+}
+
+// NewAuthorizer is an Authorizer constructor, permissions map roles to names of granted permissions.
+func NewAuthorizer(permissions map[int][]string) *Authorizer {
+	a := &Authorizer{permissions: make(map[int]map[string]struct{}, len(permissions))}
+	for role, names := range permissions {
+		granted := make(map[string]struct{}, len(names))
+		for _, name := range names {
+			granted[name] = struct{}{}
+		}
+		a.permissions[role] = granted
+	}
+
+	return a
+}
+
+// HasPermission checks if the role is granted all the permissions.
+func (a *Authorizer) HasPermission(role int, permissions ...string) bool {
+	granted, ok := a.permissions[role]
+	if !ok {
+		return false
+	}
+
+	for _, permission := range permissions {
+		if _, ok := granted[permission]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RequirePermission returns a middleware which lets through only principals granted all the permissions.
+// It must be used after UserIdentity.
+func (a *Authorizer) RequirePermission(permissions ...string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := PrincipalFromContext(r.Context())
+			if !ok {
+				middleware.JSONError(w, errors.New("no authenticated user"), http.StatusUnauthorized)
+				return
+			}
+
+			if !a.HasPermission(principal.Role, permissions...) {
+				middleware.JSONError(w, errors.New("permission denied"), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -1,66 +1,195 @@
 package auth
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
 	"github.com/JesusG2000/hexsatisfaction/pkg/middleware"
 	"github.com/dgrijalva/jwt-go"
+	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 )
 
-const authorizationHeader = "Authorization"
+const (
+	authorizationHeader = "Authorization"
+	refreshTokenLen     = 32
+	tokenIDLen          = 16
+
+	// PasswordChangeRoute is the name of the only route UserIdentity lets through
+	// users who must change a temporary password.
+	PasswordChangeRoute = "password-change"
+)
+
+var (
+	// ErrInvalidToken is returned by Authenticate when the token must be rejected.
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrPasswordResetRequired is returned for users who must change a temporary password first.
+	ErrPasswordResetRequired = errors.New("password reset required")
+)
 
 // TokenManager provides logic for a JWT token generation and parsing.
 type TokenManager interface {
-	NewJWT(userID string) (string, error)
-	Parse(accessToken string) (string, error)
+	NewJWT(userID, role, tokenVersion int) (string, error)
+	NewRefreshToken() (string, time.Time, error)
+	Parse(accessToken string) (*Principal, error)
+	Authenticate(ctx context.Context, accessToken string) (*Principal, error)
 	UserIdentity(next http.Handler) http.Handler
 }
 
+// Account represents the state of the user, which decides if its access tokens are accepted.
+// TokenVersion is increased on revocation, tokens issued with another version are revoked.
+type Account struct {
+	Disabled              bool
+	PasswordResetRequired bool
+	TokenVersion          int
+}
+
+// AccountFinder finds the account of the user, it returns domain.ErrNotFound for deleted users.
+type AccountFinder interface {
+	FindAccount(ctx context.Context, userID int) (*Account, error)
+}
+
+// claims represents claims of the access token.
+type claims struct {
+	jwt.StandardClaims
+	Role    int `json:"role"`
+	Version int `json:"ver"`
+}
+
 // Manager manages a JWT token.
 type Manager struct {
-	signingKey string
+	signingKey      string
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+	accounts        AccountFinder
 }
 
 // NewManager is a Manager constructor.
-func NewManager(signingKey string) (*Manager, error) {
+// Tokens are checked against accounts of their users, nil accounts make Authenticate check only the token itself.
+func NewManager(signingKey string, accessTokenTTL, refreshTokenTTL time.Duration, accounts AccountFinder) (*Manager, error) {
 	if signingKey == "" {
 		return nil, errors.New("empty secret key")
 	}
 
-	return &Manager{signingKey: signingKey}, nil
+	if accessTokenTTL <= 0 || refreshTokenTTL <= 0 {
+		return nil, errors.New("token ttl must be positive")
+	}
+
+	return &Manager{
+		signingKey:      signingKey,
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+		accounts:        accounts,
+	}, nil
 }
 
-// NewJWT creates a new JWT token.
-func (m *Manager) NewJWT(userID string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
-		Subject: userID,
+// NewJWT creates a new short-lived JWT token carrying the current token version of the user.
+func (m *Manager) NewJWT(userID, role, tokenVersion int) (string, error) {
+	id, err := randomString(tokenIDLen)
+	if err != nil {
+		return "", errors.Wrap(err, "couldn't create token id")
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        id,
+			Subject:   strconv.Itoa(userID),
+			IssuedAt:  now.Unix(),
+			NotBefore: now.Unix(),
+			ExpiresAt: now.Add(m.accessTokenTTL).Unix(),
+		},
+		Role:    role,
+		Version: tokenVersion,
 	})
 
 	return token.SignedString([]byte(m.signingKey))
 }
 
-// Parse parses the JWT token.
-func (m *Manager) Parse(accessToken string) (string, error) {
-	token, err := jwt.Parse(accessToken, func(token *jwt.Token) (i interface{}, err error) {
+// NewRefreshToken creates a new opaque refresh token and returns it with its expiration time.
+func (m *Manager) NewRefreshToken() (string, time.Time, error) {
+	token, err := randomString(refreshTokenLen)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "couldn't create refresh token")
+	}
+
+	return token, time.Now().Add(m.refreshTokenTTL), nil
+}
+
+// Parse parses the JWT token and returns its principal.
+func (m *Manager) Parse(accessToken string) (*Principal, error) {
+	var tokenClaims claims
+	_, err := jwt.ParseWithClaims(accessToken, &tokenClaims, func(token *jwt.Token) (i interface{}, err error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.Wrap(err, "unexpected signing method")
+			return nil, errors.New("unexpected signing method")
 		}
 		return []byte(m.signingKey), nil
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "couldn't parse token")
+		return nil, errors.Wrap(err, "couldn't parse token")
+	}
+
+	if tokenClaims.Subject == "" {
+		return nil, errors.New("empty claims")
+	}
+
+	userID, err := strconv.Atoi(tokenClaims.Subject)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid subject")
+	}
+
+	return &Principal{
+		UserID:       userID,
+		Role:         tokenClaims.Role,
+		TokenID:      tokenClaims.Id,
+		IssuedAt:     time.Unix(tokenClaims.IssuedAt, 0),
+		TokenVersion: tokenClaims.Version,
+	}, nil
+}
+
+// Authenticate parses the JWT token and checks the account of its user.
+// Tokens of deleted or disabled users and tokens issued before revocation are rejected with ErrInvalidToken.
+func (m *Manager) Authenticate(ctx context.Context, accessToken string) (*Principal, error) {
+	principal, err := m.Parse(accessToken)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidToken, err.Error())
+	}
+
+	if m.accounts == nil {
+		return principal, nil
+	}
+
+	account, err := m.accounts.FindAccount(ctx, principal.UserID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, errors.Wrap(ErrInvalidToken, "user not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't find an account")
+	}
+
+	if account.Disabled {
+		return nil, errors.Wrap(ErrInvalidToken, "user is disabled")
 	}
 
-	subClaims, ok := token.Claims.(jwt.MapClaims)["sub"]
-	if !ok {
-		return "", errors.New("empty claims")
+	if principal.TokenVersion != account.TokenVersion {
+		return nil, errors.Wrap(ErrInvalidToken, "token is revoked")
 	}
-	return subClaims.(string), nil
+	principal.PasswordResetRequired = account.PasswordResetRequired
+
+	return principal, nil
 }
 
-// UserIdentity checks validation of the token.
+// UserIdentity authenticates the token and puts its principal into the request context,
+// the id of the user is logged with the request.
+// Users who must change a temporary password are let only to the PasswordChangeRoute.
 func (m *Manager) UserIdentity(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		header := r.Header.Get(authorizationHeader)
@@ -74,11 +203,41 @@ func (m *Manager) UserIdentity(next http.Handler) http.Handler {
 			middleware.JSONError(w, errors.New("invalid auth header"), http.StatusUnauthorized)
 			return
 		}
-		_, err := m.Parse(headerParts[1])
-		if err != nil {
+		principal, err := m.Authenticate(r.Context(), headerParts[1])
+		if errors.Is(err, ErrInvalidToken) {
 			middleware.JSONError(w, err, http.StatusUnauthorized)
 			return
 		}
-		next.ServeHTTP(w, r)
+		if err != nil {
+			middleware.JSONError(w, err, http.StatusInternalServerError)
+			return
+		}
+		middleware.SetUserID(r.Context(), principal.UserID)
+
+		if principal.PasswordResetRequired && !isPasswordChange(r) {
+			middleware.JSONError(w, ErrPasswordResetRequired, http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
 	})
 }
+
+func isPasswordChange(r *http.Request) bool {
+	route := mux.CurrentRoute(r)
+	return route != nil && route.GetName() == PasswordChangeRoute
+}
+
+// HashToken returns the value under which an opaque token is stored.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
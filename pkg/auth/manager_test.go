@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type accountFinder struct {
+	account *Account
+	err     error
+}
+
+func (f accountFinder) FindAccount(context.Context, int) (*Account, error) {
+	return f.account, f.err
+}
+
+func TestManager_Authenticate(t *testing.T) {
+	assert := testAssert.New(t)
+	tt := []struct {
+		name      string
+		token     string
+		accounts  AccountFinder
+		isInvalid bool
+		isErr     bool
+		expReset  bool
+	}{
+		{
+			name:      "bad token",
+			token:     "bad",
+			accounts:  accountFinder{account: &Account{}},
+			isInvalid: true,
+		},
+		{
+			name: "no accounts",
+		},
+		{
+			name:      "deleted user",
+			accounts:  accountFinder{err: domain.Errorf(domain.ErrNotFound, "user not found")},
+			isInvalid: true,
+		},
+		{
+			name:     "account errors",
+			accounts: accountFinder{err: errors.New("")},
+			isErr:    true,
+		},
+		{
+			name:      "disabled user",
+			accounts:  accountFinder{account: &Account{Disabled: true}},
+			isInvalid: true,
+		},
+		{
+			name:      "revoked token",
+			accounts:  accountFinder{account: &Account{TokenVersion: 1}},
+			isInvalid: true,
+		},
+		{
+			name:     "password reset required",
+			accounts: accountFinder{account: &Account{PasswordResetRequired: true}},
+			expReset: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			manager, err := NewManager("key", time.Minute, time.Hour, tc.accounts)
+			require.NoError(t, err)
+
+			token := tc.token
+			if token == "" {
+				token, err = manager.NewJWT(1, 2, 0)
+				require.NoError(t, err)
+			}
+
+			principal, err := manager.Authenticate(context.Background(), token)
+			switch {
+			case tc.isInvalid:
+				assert.ErrorIs(err, ErrInvalidToken)
+			case tc.isErr:
+				assert.Error(err)
+				assert.NotErrorIs(err, ErrInvalidToken)
+			default:
+				require.NoError(t, err)
+				assert.Equal(1, principal.UserID)
+				assert.Equal(2, principal.Role)
+				assert.Equal(tc.expReset, principal.PasswordResetRequired)
+			}
+		})
+	}
+}
+
+func TestManager_RevokeAndIssue(t *testing.T) {
+	assert := testAssert.New(t)
+	account := &Account{}
+	manager, err := NewManager("key", time.Minute, time.Hour, accountFinder{account: account})
+	require.NoError(t, err)
+
+	revoked, err := manager.NewJWT(1, 2, account.TokenVersion)
+	require.NoError(t, err)
+	account.TokenVersion++
+	issued, err := manager.NewJWT(1, 2, account.TokenVersion)
+	require.NoError(t, err)
+
+	_, err = manager.Authenticate(context.Background(), revoked)
+	assert.ErrorIs(err, ErrInvalidToken)
+	principal, err := manager.Authenticate(context.Background(), issued)
+	require.NoError(t, err)
+	assert.Equal(account.TokenVersion, principal.TokenVersion)
+}
+
+func TestManager_UserIdentity(t *testing.T) {
+	assert := testAssert.New(t)
+	tt := []struct {
+		name     string
+		path     string
+		header   string
+		accounts AccountFinder
+		expCode  int
+	}{
+		{
+			name:     "empty header",
+			path:     "/me",
+			accounts: accountFinder{account: &Account{}},
+			expCode:  http.StatusUnauthorized,
+		},
+		{
+			name:     "invalid token",
+			path:     "/me",
+			header:   "Bearer bad",
+			accounts: accountFinder{account: &Account{}},
+			expCode:  http.StatusUnauthorized,
+		},
+		{
+			name:     "disabled user",
+			path:     "/me",
+			accounts: accountFinder{account: &Account{Disabled: true}},
+			expCode:  http.StatusUnauthorized,
+		},
+		{
+			name:     "account errors",
+			path:     "/me",
+			accounts: accountFinder{err: errors.New("")},
+			expCode:  http.StatusInternalServerError,
+		},
+		{
+			name:     "password reset required",
+			path:     "/me",
+			accounts: accountFinder{account: &Account{PasswordResetRequired: true}},
+			expCode:  http.StatusForbidden,
+		},
+		{
+			name:     "password change with reset required",
+			path:     "/me/password",
+			accounts: accountFinder{account: &Account{PasswordResetRequired: true}},
+			expCode:  http.StatusNoContent,
+		},
+		{
+			name:     "all ok",
+			path:     "/me",
+			accounts: accountFinder{account: &Account{}},
+			expCode:  http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			manager, err := NewManager("key", time.Minute, time.Hour, tc.accounts)
+			require.NoError(t, err)
+
+			header := tc.header
+			if header == "" && tc.name != "empty header" {
+				token, err := manager.NewJWT(1, 2, 0)
+				require.NoError(t, err)
+				header = "Bearer " + token
+			}
+
+			ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, found := PrincipalFromContext(r.Context())
+				assert.True(found)
+				w.WriteHeader(http.StatusNoContent)
+			})
+			router := mux.NewRouter()
+			router.Use(manager.UserIdentity)
+			router.Path("/me").Handler(ok)
+			router.Path("/me/password").Name(PasswordChangeRoute).Handler(ok)
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if header != "" {
+				req.Header.Set(authorizationHeader, header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tc.expCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+type principalKey struct{}
+
+// Principal represents the authenticated user of a request.
+type Principal struct {
+	UserID                int
+	Role                  int
+	TokenID               string
+	IssuedAt              time.Time
+	TokenVersion          int
+	PasswordResetRequired bool
+}
+
+// WithPrincipal returns a copy of ctx which carries the principal.
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal stored in ctx by UserIdentity.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
@@ -0,0 +1,36 @@
+// Package cursor encodes opaque cursors for keyset pagination.
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalid is returned when the cursor can't be decoded.
+var ErrInvalid = errors.New("invalid cursor")
+
+// Encode encodes the position v into a cursor.
+func Encode(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", errors.Wrap(err, "couldn't encode cursor")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Decode decodes the cursor into v.
+func Decode(cursor string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalid
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return ErrInvalid
+	}
+
+	return nil
+}
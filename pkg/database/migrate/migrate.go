@@ -0,0 +1,305 @@
+// Package migrate applies numbered SQL migrations to a pg database.
+//
+// The applied version is kept in the schema_migrations table, which has the same layout
+// as the one of the migrate CLI, so both can be used on the same database.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// lockID is the key of the advisory lock held while migrating.
+const lockID = 7255001
+
+var fileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration represents a numbered schema change.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status represents the state of the schema.
+type Status struct {
+	Current    uint
+	Latest     uint
+	Dirty      bool
+	Migrations []Migration
+}
+
+// Behind checks if some migrations aren't applied yet.
+func (s Status) Behind() bool {
+	return s.Current < s.Latest
+}
+
+// Migrator applies migrations.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator is a Migrator constructor, it reads migrations from fsys.
+func NewMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	files, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't read migrations")
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, file := range files {
+		parts := fileName.FindStringSubmatch(file.Name())
+		if parts == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil || version == 0 {
+			return nil, errors.Errorf("not correct migration version %s", file.Name())
+		}
+
+		body, err := fs.ReadFile(fsys, file.Name())
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't read migration %s", file.Name())
+		}
+
+		m, ok := byVersion[uint(version)]
+		if !ok {
+			m = &Migration{Version: uint(version), Name: parts[2]}
+			byVersion[m.Version] = m
+		}
+		if m.Name != parts[2] {
+			return nil, errors.Errorf("migration %d has two names", version)
+		}
+
+		if parts[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, errors.Errorf("migration %d must have up and down files", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.latest())
+}
+
+// Down reverts the last applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.migrate(ctx, func(current uint) (uint, error) {
+		if current == 0 {
+			return 0, errors.New("no migrations to revert")
+		}
+
+		target := uint(0)
+		for _, migration := range m.migrations {
+			if migration.Version < current {
+				target = migration.Version
+			}
+		}
+
+		return target, nil
+	})
+}
+
+// To applies or reverts migrations until the schema is at the version.
+// Zero version reverts all migrations.
+func (m *Migrator) To(ctx context.Context, version uint) error {
+	if version != 0 && m.find(version) < 0 {
+		return errors.Errorf("no migration %d", version)
+	}
+
+	return m.migrate(ctx, func(uint) (uint, error) {
+		return version, nil
+	})
+}
+
+// Status finds the applied version of the schema, it doesn't change the database,
+// so a database without schema_migrations is at zero version.
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	var exist bool
+	err := m.db.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exist)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't find schema_migrations")
+	}
+
+	var current uint
+	var dirty bool
+	if exist {
+		current, dirty, err = m.version(ctx, m.db)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Status{
+		Current:    current,
+		Latest:     m.latest(),
+		Dirty:      dirty,
+		Migrations: m.migrations,
+	}, nil
+}
+
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// migrate moves the schema to the target version under the advisory lock,
+// so replicas starting at once apply every migration only once.
+func (m *Migrator) migrate(ctx context.Context, target func(current uint) (uint, error)) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "couldn't get a connection")
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		return errors.Wrap(err, "couldn't lock migrations")
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockID)
+	}()
+
+	if err := m.createTable(ctx, conn); err != nil {
+		return err
+	}
+
+	current, dirty, err := m.version(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		return errors.Errorf("schema is dirty at version %d, fix it manually", current)
+	}
+
+	version, err := target(current)
+	if err != nil {
+		return err
+	}
+
+	for current < version {
+		next := m.migrations[m.next(current)]
+		if err := m.apply(ctx, conn, next.Up, next.Version); err != nil {
+			return errors.Wrapf(err, "couldn't apply migration %d_%s", next.Version, next.Name)
+		}
+		current = next.Version
+	}
+
+	for current > version {
+		i := m.find(current)
+		if i < 0 {
+			return errors.Errorf("no migration %d to revert", current)
+		}
+
+		prev := uint(0)
+		if i > 0 {
+			prev = m.migrations[i-1].Version
+		}
+
+		if err := m.apply(ctx, conn, m.migrations[i].Down, prev); err != nil {
+			return errors.Wrapf(err, "couldn't revert migration %d_%s", current, m.migrations[i].Name)
+		}
+		current = prev
+	}
+
+	return nil
+}
+
+// apply runs the migration and saves the version in one transaction.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, query string, version uint) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if version != 0 {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)", version); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) createTable(ctx context.Context, q querier) error {
+	_, err := q.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)")
+	return errors.Wrap(err, "couldn't create schema_migrations")
+}
+
+func (m *Migrator) version(ctx context.Context, q querier) (uint, bool, error) {
+	var version uint
+	var dirty bool
+	err := q.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, errors.Wrap(err, "couldn't find schema version")
+	}
+
+	return version, dirty, nil
+}
+
+func (m *Migrator) latest() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// find returns the index of the migration or -1.
+func (m *Migrator) find(version uint) int {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// next returns the index of the first migration after the version.
+func (m *Migrator) next(version uint) int {
+	return sort.Search(len(m.migrations), func(i int) bool {
+		return m.migrations[i].Version > version
+	})
+}
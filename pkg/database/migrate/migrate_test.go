@@ -0,0 +1,139 @@
+package migrate
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/migrations"
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func file(body string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(body)}
+}
+
+func TestNewMigrator(t *testing.T) {
+	assert := testAssert.New(t)
+	tt := []struct {
+		name        string
+		fsys        fstest.MapFS
+		expErr      string
+		expVersions []uint
+	}{
+		{
+			name: "zero version",
+			fsys: fstest.MapFS{
+				"000000_init.up.sql":   file("up"),
+				"000000_init.down.sql": file("down"),
+			},
+			expErr: "not correct migration version 000000_init.down.sql",
+		},
+		{
+			name: "two names",
+			fsys: fstest.MapFS{
+				"000001_init.up.sql":  file("up"),
+				"000001_other.up.sql": file("up"),
+			},
+			expErr: "migration 1 has two names",
+		},
+		{
+			name: "no down file",
+			fsys: fstest.MapFS{
+				"000001_init.up.sql": file("up"),
+			},
+			expErr: "migration 1 must have up and down files",
+		},
+		{
+			name: "empty up file",
+			fsys: fstest.MapFS{
+				"000001_init.up.sql":   file(""),
+				"000001_init.down.sql": file("down"),
+			},
+			expErr: "migration 1 must have up and down files",
+		},
+		{
+			name:        "no migrations",
+			fsys:        fstest.MapFS{},
+			expVersions: []uint{},
+		},
+		{
+			name: "all ok",
+			fsys: fstest.MapFS{
+				"10_later.up.sql":      file("up 10"),
+				"10_later.down.sql":    file("down 10"),
+				"000002_init.up.sql":   file("up 2"),
+				"000002_init.down.sql": file("down 2"),
+				"README.md":            file("not a migration"),
+				"000003_init.sql":      file("not a migration"),
+			},
+			expVersions: []uint{2, 10},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewMigrator(nil, tc.fsys)
+			if tc.expErr != "" {
+				assert.EqualError(err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+
+			versions := make([]uint, 0, len(m.migrations))
+			for _, migration := range m.migrations {
+				versions = append(versions, migration.Version)
+			}
+			assert.Equal(tc.expVersions, versions)
+		})
+	}
+}
+
+func TestMigrator_Lookups(t *testing.T) {
+	assert := testAssert.New(t)
+	m, err := NewMigrator(nil, fstest.MapFS{
+		"000002_users.up.sql":     file("CREATE TABLE users ()"),
+		"000002_users.down.sql":   file("DROP TABLE users"),
+		"000005_authors.up.sql":   file("CREATE TABLE author ()"),
+		"000005_authors.down.sql": file("DROP TABLE author"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(uint(5), m.latest())
+	assert.Equal("users", m.migrations[0].Name)
+	assert.Equal("CREATE TABLE users ()", m.migrations[0].Up)
+	assert.Equal("DROP TABLE users", m.migrations[0].Down)
+
+	assert.Equal(1, m.find(5))
+	assert.Equal(-1, m.find(3))
+
+	assert.Equal(0, m.next(0))
+	assert.Equal(1, m.next(2))
+	assert.Equal(1, m.next(3))
+	assert.Equal(2, m.next(5))
+
+	assert.EqualError(m.To(context.Background(), 3), "no migration 3")
+
+	empty, err := NewMigrator(nil, fstest.MapFS{})
+	require.NoError(t, err)
+	assert.Zero(empty.latest())
+}
+
+func TestStatus_Behind(t *testing.T) {
+	assert := testAssert.New(t)
+	assert.True(Status{Current: 1, Latest: 2}.Behind())
+	assert.False(Status{Current: 2, Latest: 2}.Behind())
+	assert.False(Status{}.Behind())
+}
+
+func TestNewMigrator_Embedded(t *testing.T) {
+	assert := testAssert.New(t)
+	m, err := NewMigrator(nil, migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, m.migrations)
+
+	for i, migration := range m.migrations {
+		assert.Equal(uint(i+1), migration.Version, "migrations are numbered without gaps")
+	}
+}
@@ -3,7 +3,6 @@ package pg
 import (
 	"database/sql"
 	"fmt"
-	"log"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/config"
 	// pg driver
@@ -13,7 +12,6 @@ import (
 // NewPg creates new connection to pg database.
 func NewPg(pgConfig config.PgConfig) (*sql.DB, error) {
 	dbURI := fmt.Sprintf("host=%s user=%s dbname=%s sslmode=disable password=%s port=%d", pgConfig.Host, pgConfig.User, pgConfig.DatabaseName, pgConfig.Password, pgConfig.Port)
-	log.Println(dbURI)
 
 	db, err := sql.Open(pgConfig.DatabaseDialect, dbURI)
 	if err != nil {
@@ -0,0 +1,79 @@
+// Package domain defines errors shared by repositories, services and transports,
+// so every layer reports a missing or conflicting record the same way.
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error represents a domain error, its message is safe to show to clients.
+type Error struct {
+	kind *Error
+	msg  string
+}
+
+// Domain errors, wrap them with Errorf to give a more specific message,
+// ErrRateLimited is returned by Throttle to tell when to retry.
+var (
+	ErrNotFound    = &Error{msg: "not found"}
+	ErrConflict    = &Error{msg: "already exists"}
+	ErrValidation  = &Error{msg: "not valid"}
+	ErrForbidden   = &Error{msg: "forbidden"}
+	ErrRateLimited = &Error{msg: "too many requests"}
+	ErrTooLarge    = &Error{msg: "too large"}
+)
+
+// Errorf returns an error of the kind with the formatted message.
+func Errorf(kind *Error, format string, args ...interface{}) error {
+	return &Error{kind: kind, msg: fmt.Sprintf(format, args...)}
+}
+
+// Error returns the message of the error.
+func (e *Error) Error() string {
+	return e.msg
+}
+
+// Is checks if the error is of the target kind.
+func (e *Error) Is(target error) bool {
+	return e.kind != nil && e.kind == target
+}
+
+// Kind returns the kind of the domain error wrapped by err or nil if there is no one.
+func Kind(err error) *Error {
+	var fields FieldErrors
+	if errors.As(err, &fields) {
+		return ErrValidation
+	}
+
+	var t *Throttled
+	if errors.As(err, &t) {
+		return ErrRateLimited
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		return nil
+	}
+
+	if e.kind != nil {
+		return e.kind
+	}
+
+	return e
+}
+
+// Message returns the message of the domain error wrapped by err without the context added by wrapping.
+func Message(err error) string {
+	var t *Throttled
+	if errors.As(err, &t) {
+		return t.msg
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		return err.Error()
+	}
+
+	return e.msg
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// FieldErrors represents validation errors of request fields keyed by the field name.
+// It is a validation error, so it has the status of ErrValidation.
+type FieldErrors map[string]string
+
+// Check adds the message to the field if ok is false, only the first message of a field is kept.
+func (f FieldErrors) Check(ok bool, field, msg string) {
+	if ok {
+		return
+	}
+
+	if _, exists := f[field]; !exists {
+		f[field] = msg
+	}
+}
+
+// Err returns f as an error or nil if no field is wrong.
+func (f FieldErrors) Err() error {
+	if len(f) == 0 {
+		return nil
+	}
+
+	return f
+}
+
+// Error returns messages of all fields ordered by the field name.
+func (f FieldErrors) Error() string {
+	fields := make([]string, 0, len(f))
+	for field := range f {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	msgs := make([]string, 0, len(fields))
+	for _, field := range fields {
+		msgs = append(msgs, f[field])
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Is checks if the target is ErrValidation.
+func (f FieldErrors) Is(target error) bool {
+	return target == ErrValidation
+}
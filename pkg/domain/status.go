@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+type status struct {
+	code string
+	http int
+	grpc codes.Code
+}
+
+var statuses = map[*Error]status{
+	ErrNotFound:    {"not_found", http.StatusNotFound, codes.NotFound},
+	ErrConflict:    {"conflict", http.StatusConflict, codes.AlreadyExists},
+	ErrValidation:  {"validation_failed", http.StatusBadRequest, codes.InvalidArgument},
+	ErrForbidden:   {"forbidden", http.StatusForbidden, codes.PermissionDenied},
+	ErrRateLimited: {"rate_limited", http.StatusTooManyRequests, codes.ResourceExhausted},
+	ErrTooLarge:    {"too_large", http.StatusRequestEntityTooLarge, codes.ResourceExhausted},
+}
+
+// Code returns the machine readable code of err, ok is false if err isn't a domain error.
+func Code(err error) (code string, ok bool) {
+	s, ok := statuses[Kind(err)]
+	return s.code, ok
+}
+
+// HTTPStatus returns the http status for err, ok is false if err isn't a domain error.
+func HTTPStatus(err error) (code int, ok bool) {
+	s, ok := statuses[Kind(err)]
+	return s.http, ok
+}
+
+// GRPCCode returns the grpc code for err, errors which aren't domain ones are internal.
+func GRPCCode(err error) codes.Code {
+	s, ok := statuses[Kind(err)]
+	if !ok {
+		return codes.Internal
+	}
+
+	return s.grpc
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Throttled is an ErrRateLimited error telling when the request can be retried.
+type Throttled struct {
+	msg   string
+	After time.Duration
+}
+
+// Throttle returns an ErrRateLimited error with the formatted message telling to retry after the duration.
+func Throttle(after time.Duration, format string, args ...interface{}) error {
+	return &Throttled{msg: fmt.Sprintf(format, args...), After: after}
+}
+
+// Error returns the message of the error.
+func (t *Throttled) Error() string {
+	return t.msg
+}
+
+// Is checks if the target is ErrRateLimited.
+func (t *Throttled) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// RetryAfter returns the duration after which the request throttled by err can be retried,
+// ok is false if err isn't Throttled.
+func RetryAfter(err error) (after time.Duration, ok bool) {
+	var t *Throttled
+	if !errors.As(err, &t) {
+		return 0, false
+	}
+
+	return t.After, true
+}
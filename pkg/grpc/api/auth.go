@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationKey = "authorization"
+
+	// UserCaller is the caller name of users authenticated by a JWT token.
+	UserCaller = "user"
+)
+
+// publicServices can be called without authentication.
+var publicServices = []string{"/grpc.health.v1.Health/", "/grpc.reflection.v1alpha.ServerReflection/"}
+
+type callerKey struct{}
+
+// CallerFromContext returns the name of the caller authenticated by Guard.
+// Users have the UserCaller name and their principal is put into the context too.
+func CallerFromContext(ctx context.Context) (string, bool) {
+	caller, ok := ctx.Value(callerKey{}).(string)
+	return caller, ok
+}
+
+// Guard authenticates grpc calls by a user JWT token or a service token
+// and lets callers call only the methods allowed to them.
+// Health checking and reflection are public.
+type Guard struct {
+	tokenManager  auth.TokenManager
+	serviceTokens map[string]string
+	allowed       map[string][]string
+}
+
+// NewGuard is a Guard constructor.
+// serviceTokens map names of services to their tokens,
+// allowed maps names of callers to full methods like /grpc.Directory/GetUser separated by spaces,
+// /grpc.Directory/* allows every method of the service and * allows every method of every service.
+func NewGuard(tokenManager auth.TokenManager, serviceTokens, allowed map[string]string) (*Guard, error) {
+	g := &Guard{
+		tokenManager:  tokenManager,
+		serviceTokens: make(map[string]string, len(serviceTokens)),
+		allowed:       make(map[string][]string, len(allowed)),
+	}
+
+	for name, token := range serviceTokens {
+		if token == "" {
+			return nil, errors.Errorf("empty token of %s service", name)
+		}
+		if name == UserCaller {
+			return nil, errors.Errorf("service can't be named %s", UserCaller)
+		}
+		g.serviceTokens[name] = token
+	}
+
+	for caller, methods := range allowed {
+		g.allowed[caller] = strings.Fields(methods)
+	}
+
+	return g, nil
+}
+
+// Unary returns a server interceptor which guards unary calls.
+func (g *Guard) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := g.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns a server interceptor which guards streaming calls.
+func (g *Guard) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := g.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &guardedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize authenticates the caller of the method and checks it may call the method.
+func (g *Guard) authorize(ctx context.Context, method string) (context.Context, error) {
+	for _, service := range publicServices {
+		if strings.HasPrefix(method, service) {
+			return ctx, nil
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationKey)
+	if len(values) == 0 || values[0] == "" {
+		return nil, status.Error(codes.Unauthenticated, "empty auth metadata")
+	}
+
+	parts := strings.Split(values[0], " ")
+	if len(parts) != 2 || parts[0] != "Bearer" || parts[1] == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid auth metadata")
+	}
+
+	caller, ok := g.service(parts[1])
+	if !ok {
+		principal, err := g.tokenManager.Authenticate(ctx, parts[1])
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, "couldn't authenticate")
+		}
+
+		if principal.PasswordResetRequired {
+			return nil, status.Error(codes.PermissionDenied, auth.ErrPasswordResetRequired.Error())
+		}
+
+		caller = UserCaller
+		ctx = auth.WithPrincipal(ctx, principal)
+	}
+
+	if !g.isAllowed(caller, method) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s isn't allowed to call %s", caller, method)
+	}
+
+	return context.WithValue(ctx, callerKey{}, caller), nil
+}
+
+// service finds the service by its token, every token is compared to avoid leaking them by timing.
+func (g *Guard) service(token string) (string, bool) {
+	var found string
+	for name, serviceToken := range g.serviceTokens {
+		if subtle.ConstantTimeCompare([]byte(serviceToken), []byte(token)) == 1 {
+			found = name
+		}
+	}
+
+	return found, found != ""
+}
+
+func (g *Guard) isAllowed(caller, method string) bool {
+	service := method[:strings.LastIndex(method, "/")+1]
+	for _, pattern := range g.allowed[caller] {
+		if pattern == "*" || pattern == method || pattern == service+"*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// guardedStream replaces the context of the stream with the one carrying the caller.
+type guardedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream.
+func (s *guardedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,280 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/pkg/errors"
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type accountFinder struct {
+	account *auth.Account
+	err     error
+}
+
+func (f accountFinder) FindAccount(context.Context, int) (*auth.Account, error) {
+	return f.account, f.err
+}
+
+func newTestGuard(t *testing.T, accounts auth.AccountFinder) (*Guard, *auth.Manager) {
+	tokenManager, err := auth.NewManager("key", time.Minute, time.Hour, accounts)
+	require.NoError(t, err)
+
+	guard, err := NewGuard(tokenManager,
+		map[string]string{"catalog": "catalog-token", "billing": "billing-token"},
+		map[string]string{
+			"catalog":  "/grpc.Existance/*",
+			"billing":  "*",
+			UserCaller: "/grpc.Directory/GetAuthor",
+		})
+	require.NoError(t, err)
+
+	return guard, tokenManager
+}
+
+func TestNewGuard(t *testing.T) {
+	assert := testAssert.New(t)
+	tt := []struct {
+		name   string
+		tokens map[string]string
+		isOk   bool
+	}{
+		{
+			name:   "empty token",
+			tokens: map[string]string{"catalog": ""},
+		},
+		{
+			name:   "service named user",
+			tokens: map[string]string{UserCaller: "token"},
+		},
+		{
+			name:   "all ok",
+			tokens: map[string]string{"catalog": "token"},
+			isOk:   true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewGuard(nil, tc.tokens, nil)
+			assert.Equal(tc.isOk, err == nil)
+		})
+	}
+}
+
+func TestGuard_Unary(t *testing.T) {
+	assert := testAssert.New(t)
+	guard, tokenManager := newTestGuard(t, accountFinder{account: &auth.Account{}})
+	userToken, err := tokenManager.NewJWT(1, 2, 0)
+	require.NoError(t, err)
+
+	tt := []struct {
+		name      string
+		method    string
+		header    string
+		expCode   codes.Code
+		expCaller string
+	}{
+		{
+			name:    "public health",
+			method:  "/grpc.health.v1.Health/Check",
+			expCode: codes.OK,
+		},
+		{
+			name:    "no credentials",
+			method:  "/grpc.Existance/User",
+			expCode: codes.Unauthenticated,
+		},
+		{
+			name:    "not bearer",
+			method:  "/grpc.Existance/User",
+			header:  "Basic catalog-token",
+			expCode: codes.Unauthenticated,
+		},
+		{
+			name:    "bad jwt",
+			method:  "/grpc.Directory/GetAuthor",
+			header:  "Bearer " + userToken + "x",
+			expCode: codes.Unauthenticated,
+		},
+		{
+			name:    "bad service token",
+			method:  "/grpc.Existance/User",
+			header:  "Bearer catalog-tokeN",
+			expCode: codes.Unauthenticated,
+		},
+		{
+			name:      "service wildcard",
+			method:    "/grpc.Existance/UsersExist",
+			header:    "Bearer catalog-token",
+			expCode:   codes.OK,
+			expCaller: "catalog",
+		},
+		{
+			name:    "service outside wildcard",
+			method:  "/grpc.Directory/GetUser",
+			header:  "Bearer catalog-token",
+			expCode: codes.PermissionDenied,
+		},
+		{
+			name:      "service allowed everything",
+			method:    "/grpc.Directory/GetUser",
+			header:    "Bearer billing-token",
+			expCode:   codes.OK,
+			expCaller: "billing",
+		},
+		{
+			name:    "user denied method",
+			method:  "/grpc.Directory/GetUser",
+			header:  "Bearer " + userToken,
+			expCode: codes.PermissionDenied,
+		},
+		{
+			name:      "user allowed method",
+			method:    "/grpc.Directory/GetAuthor",
+			header:    "Bearer " + userToken,
+			expCode:   codes.OK,
+			expCaller: UserCaller,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationKey, tc.header))
+			}
+
+			var caller string
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				caller, _ = CallerFromContext(ctx)
+				if caller == UserCaller {
+					principal, ok := auth.PrincipalFromContext(ctx)
+					assert.True(ok)
+					assert.Equal(1, principal.UserID)
+				}
+				return req, nil
+			}
+
+			_, err := guard.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			assert.Equal(tc.expCode, status.Code(err))
+			assert.Equal(tc.expCaller, caller)
+		})
+	}
+}
+
+func TestGuard_Accounts(t *testing.T) {
+	assert := testAssert.New(t)
+	tt := []struct {
+		name     string
+		accounts auth.AccountFinder
+		expCode  codes.Code
+	}{
+		{
+			name:     "account errors",
+			accounts: accountFinder{err: errors.New("")},
+			expCode:  codes.Internal,
+		},
+		{
+			name:     "disabled user",
+			accounts: accountFinder{account: &auth.Account{Disabled: true}},
+			expCode:  codes.Unauthenticated,
+		},
+		{
+			name:     "password reset required",
+			accounts: accountFinder{account: &auth.Account{PasswordResetRequired: true}},
+			expCode:  codes.PermissionDenied,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			guard, tokenManager := newTestGuard(t, tc.accounts)
+			token, err := tokenManager.NewJWT(1, 2, 0)
+			require.NoError(t, err)
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationKey, "Bearer "+token))
+			_, err = guard.authorize(ctx, "/grpc.Directory/GetAuthor")
+			assert.Equal(tc.expCode, status.Code(err))
+		})
+	}
+}
+
+func TestGuard_Service(t *testing.T) {
+	assert := testAssert.New(t)
+	guard, _ := newTestGuard(t, nil)
+	tt := []struct {
+		name   string
+		token  string
+		expRes string
+	}{
+		{
+			name:   "first token",
+			token:  "catalog-token",
+			expRes: "catalog",
+		},
+		{
+			name:   "second token",
+			token:  "billing-token",
+			expRes: "billing",
+		},
+		{
+			name:  "prefix of a token",
+			token: "catalog-toke",
+		},
+		{
+			name:  "token with a suffix",
+			token: "catalog-token-",
+		},
+		{
+			name:  "empty token",
+			token: "",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			name, ok := guard.service(tc.token)
+			assert.Equal(tc.expRes, name)
+			assert.Equal(tc.expRes != "", ok)
+		})
+	}
+}
+
+func TestGuard_Stream(t *testing.T) {
+	assert := testAssert.New(t)
+	guard, _ := newTestGuard(t, nil)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationKey, "Bearer catalog-token"))
+
+	var caller string
+	err := guard.Stream()(nil, &testStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/grpc.Existance/User"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			caller, _ = CallerFromContext(stream.Context())
+			return nil
+		})
+	assert.Nil(err)
+	assert.Equal("catalog", caller)
+
+	err = guard.Stream()(nil, &testStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/grpc.Existance/User"},
+		func(interface{}, grpc.ServerStream) error {
+			return nil
+		})
+	assert.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
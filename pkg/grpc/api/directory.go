@@ -0,0 +1,185 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/model"
+	"github.com/JesusG2000/hexsatisfaction/internal/service"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// Directory serves users and authors to other services.
+type Directory struct {
+	services *service.Services
+}
+
+// NewDirectory is a Directory constructor.
+func NewDirectory(services *service.Services) *Directory {
+	return &Directory{services: services}
+}
+
+// GetUser finds user by id, the password isn't returned.
+func (d *Directory) GetUser(ctx context.Context, req *GetUserRequest) (*User, error) {
+	errs := make(domain.FieldErrors)
+	errs.Check(req.Id > 0, "id", "id must be at least 1")
+	if err := errs.Err(); err != nil {
+		return nil, statusError(err)
+	}
+
+	user, err := d.services.User.FindByID(ctx, model.IDUserRequest{ID: int(req.Id)})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &User{
+		Id:                    int32(user.ID),
+		Login:                 user.Login,
+		RoleId:                int32(user.RoleID),
+		Disabled:              user.Disabled,
+		PasswordResetRequired: user.PasswordResetRequired,
+	}, nil
+}
+
+// GetAuthor finds author by id.
+func (d *Directory) GetAuthor(ctx context.Context, req *GetAuthorRequest) (*Author, error) {
+	errs := make(domain.FieldErrors)
+	errs.Check(req.Id > 0, "id", "id must be at least 1")
+	if err := errs.Err(); err != nil {
+		return nil, statusError(err)
+	}
+
+	author, err := d.services.Author.FindByID(ctx, model.IDAuthorRequest{ID: int(req.Id)})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return newAuthor(*author), nil
+}
+
+// GetAuthorByUserID finds the primary author of the user.
+func (d *Directory) GetAuthorByUserID(ctx context.Context, req *GetAuthorByUserIDRequest) (*Author, error) {
+	errs := make(domain.FieldErrors)
+	errs.Check(req.UserId > 0, "user_id", "user_id must be at least 1")
+	if err := errs.Err(); err != nil {
+		return nil, statusError(err)
+	}
+
+	author, err := d.services.Author.FindPrimary(ctx, model.PrimaryAuthorRequest{UserID: int(req.UserId)})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return newAuthor(*author), nil
+}
+
+// ListAuthors finds a page of authors.
+func (d *Directory) ListAuthors(ctx context.Context, req *ListAuthorsRequest) (*ListAuthorsResponse, error) {
+	request, err := listAuthorsRequest(req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	page, err := d.services.Author.FindAll(ctx, request)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	res := &ListAuthorsResponse{
+		Items:      make([]*Author, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+		Total:      int32(page.Total),
+	}
+	for _, author := range page.Items {
+		res.Items = append(res.Items, newAuthor(author))
+	}
+
+	return res, nil
+}
+
+// SearchAuthors finds authors by name and description ordered by relevance.
+func (d *Directory) SearchAuthors(ctx context.Context, req *SearchAuthorsRequest) (*SearchAuthorsResponse, error) {
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+
+	errs := make(domain.FieldErrors)
+	errs.Check(req.Query != "", "query", "query is required")
+	errs.Check(limit >= 1 && limit <= maxPageLimit, "limit", fmt.Sprintf("limit must be between 1 and %d", maxPageLimit))
+	if err := errs.Err(); err != nil {
+		return nil, statusError(err)
+	}
+
+	matches, err := d.services.Author.Search(ctx, model.SearchAuthorRequest{Query: req.Query, Limit: limit})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	res := &SearchAuthorsResponse{
+		Items: make([]*AuthorMatch, 0, len(matches)),
+	}
+	for _, match := range matches {
+		res.Items = append(res.Items, &AuthorMatch{
+			Author:               newAuthor(match.Author),
+			Rank:                 match.Rank,
+			NameHighlight:        match.NameHighlight,
+			DescriptionHighlight: match.DescriptionHighlight,
+		})
+	}
+
+	return res, nil
+}
+
+// listAuthorsRequest fills defaults of the paging and sorting options and validates them.
+func listAuthorsRequest(req *ListAuthorsRequest) (model.ListAuthorsRequest, error) {
+	request := model.ListAuthorsRequest{
+		Limit:  int(req.Limit),
+		SortBy: req.SortBy,
+		Order:  model.OrderAsc,
+		MinAge: int(req.MinAge),
+		MaxAge: int(req.MaxAge),
+		UserID: int(req.UserId),
+	}
+	if request.Limit == 0 {
+		request.Limit = defaultPageLimit
+	}
+	if request.SortBy == "" {
+		request.SortBy = model.AuthorSortID
+	}
+	if req.Desc {
+		request.Order = model.OrderDesc
+	}
+
+	errs := make(domain.FieldErrors)
+	if req.After != "" {
+		request.After = &model.AuthorCursor{}
+		errs.Check(request.After.UnmarshalText([]byte(req.After)) == nil, "after", "not correct cursor")
+	}
+
+	errs.Check(request.Limit >= 1 && request.Limit <= maxPageLimit, "limit", fmt.Sprintf("limit must be between 1 and %d", maxPageLimit))
+	errs.Check(request.SortBy == model.AuthorSortID || request.SortBy == model.AuthorSortName || request.SortBy == model.AuthorSortAge, "sort_by", "not correct sort")
+	errs.Check(request.MinAge >= 0, "min_age", "not correct age")
+	errs.Check(request.MaxAge >= 0, "max_age", "not correct age")
+	errs.Check(request.MaxAge == 0 || request.MinAge <= request.MaxAge, "min_age", "min age is greater than max age")
+	errs.Check(request.UserID >= 0, "user_id", "not correct user id")
+	errs.Check(request.After == nil || (request.After.SortBy == request.SortBy && request.After.Desc == request.Descending()), "after", "cursor doesn't match sort order")
+
+	return request, errs.Err()
+}
+
+func newAuthor(author model.Author) *Author {
+	return &Author{
+		Id:          int32(author.ID),
+		Name:        author.Name,
+		Age:         int32(author.Age),
+		Description: author.Description,
+		UserId:      int32(author.UserID),
+		Primary:     author.Primary,
+	}
+}
@@ -0,0 +1,422 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	m "github.com/JesusG2000/hexsatisfaction/internal/handler/mock"
+	"github.com/JesusG2000/hexsatisfaction/internal/model"
+	"github.com/JesusG2000/hexsatisfaction/internal/service"
+	"github.com/JesusG2000/hexsatisfaction/pkg/cursor"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/pkg/errors"
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDirectory_GetUser(t *testing.T) {
+	assert := testAssert.New(t)
+	type test struct {
+		name    string
+		req     *GetUserRequest
+		fn      func(userService *m.User, data test)
+		expRes  *User
+		expCode codes.Code
+		expMsg  string
+	}
+	tt := []test{
+		{
+			name:    "not correct id",
+			req:     &GetUserRequest{},
+			expCode: codes.InvalidArgument,
+			expMsg:  "id must be at least 1",
+		},
+		{
+			name: "no user",
+			req:  &GetUserRequest{Id: 1},
+			fn: func(userService *m.User, data test) {
+				userService.On("FindByID", mock.Anything, model.IDUserRequest{ID: 1}).
+					Return(nil, errors.Wrap(domain.Errorf(domain.ErrNotFound, "user not found"), "couldn't find a user"))
+			},
+			expCode: codes.NotFound,
+			expMsg:  "user not found",
+		},
+		{
+			name: "FindByID errors",
+			req:  &GetUserRequest{Id: 1},
+			fn: func(userService *m.User, data test) {
+				userService.On("FindByID", mock.Anything, model.IDUserRequest{ID: 1}).
+					Return(nil, errors.New("connection refused"))
+			},
+			expCode: codes.Internal,
+			expMsg:  "internal error",
+		},
+		{
+			name: "all ok",
+			req:  &GetUserRequest{Id: 1},
+			fn: func(userService *m.User, data test) {
+				userService.On("FindByID", mock.Anything, model.IDUserRequest{ID: 1}).
+					Return(&model.User{
+						ID:                    1,
+						Login:                 "test",
+						Password:              "secret",
+						RoleID:                2,
+						Disabled:              true,
+						PasswordResetRequired: true,
+					}, nil)
+			},
+			expRes: &User{
+				Id:                    1,
+				Login:                 "test",
+				RoleId:                2,
+				Disabled:              true,
+				PasswordResetRequired: true,
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			userService := new(m.User)
+			directory := NewDirectory(&service.Services{User: userService})
+			if tc.fn != nil {
+				tc.fn(userService, tc)
+			}
+
+			res, err := directory.GetUser(context.Background(), tc.req)
+			assertStatus(assert, err, tc.expCode, tc.expMsg)
+			assert.True(proto.Equal(tc.expRes, res))
+			userService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDirectory_GetAuthor(t *testing.T) {
+	assert := testAssert.New(t)
+	type test struct {
+		name    string
+		req     *GetAuthorRequest
+		fn      func(authorService *m.Author, data test)
+		expRes  *Author
+		expCode codes.Code
+		expMsg  string
+	}
+	tt := []test{
+		{
+			name:    "not correct id",
+			req:     &GetAuthorRequest{Id: -1},
+			expCode: codes.InvalidArgument,
+			expMsg:  "id must be at least 1",
+		},
+		{
+			name: "no author",
+			req:  &GetAuthorRequest{Id: 1},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: 1}).
+					Return(nil, errors.Wrap(domain.Errorf(domain.ErrNotFound, "author not found"), "couldn't find author"))
+			},
+			expCode: codes.NotFound,
+			expMsg:  "author not found",
+		},
+		{
+			name: "all ok",
+			req:  &GetAuthorRequest{Id: 1},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindByID", mock.Anything, model.IDAuthorRequest{ID: 1}).
+					Return(&model.Author{
+						ID:          1,
+						Name:        "test",
+						Age:         30,
+						Description: "test",
+						UserID:      2,
+					}, nil)
+			},
+			expRes: &Author{
+				Id:          1,
+				Name:        "test",
+				Age:         30,
+				Description: "test",
+				UserId:      2,
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			authorService := new(m.Author)
+			directory := NewDirectory(&service.Services{Author: authorService})
+			if tc.fn != nil {
+				tc.fn(authorService, tc)
+			}
+
+			res, err := directory.GetAuthor(context.Background(), tc.req)
+			assertStatus(assert, err, tc.expCode, tc.expMsg)
+			assert.True(proto.Equal(tc.expRes, res))
+			authorService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDirectory_GetAuthorByUserID(t *testing.T) {
+	assert := testAssert.New(t)
+	type test struct {
+		name    string
+		req     *GetAuthorByUserIDRequest
+		fn      func(authorService *m.Author, data test)
+		expRes  *Author
+		expCode codes.Code
+		expMsg  string
+	}
+	tt := []test{
+		{
+			name:    "not correct user id",
+			req:     &GetAuthorByUserIDRequest{},
+			expCode: codes.InvalidArgument,
+			expMsg:  "user_id must be at least 1",
+		},
+		{
+			name: "no author",
+			req:  &GetAuthorByUserIDRequest{UserId: 2},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindPrimary", mock.Anything, model.PrimaryAuthorRequest{UserID: 2}).
+					Return(nil, errors.Wrap(domain.Errorf(domain.ErrNotFound, "author not found"), "couldn't find primary author"))
+			},
+			expCode: codes.NotFound,
+			expMsg:  "author not found",
+		},
+		{
+			name: "all ok",
+			req:  &GetAuthorByUserIDRequest{UserId: 2},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindPrimary", mock.Anything, model.PrimaryAuthorRequest{UserID: 2}).
+					Return(&model.Author{
+						ID:      1,
+						Name:    "test",
+						UserID:  2,
+						Primary: true,
+					}, nil)
+			},
+			expRes: &Author{
+				Id:      1,
+				Name:    "test",
+				UserId:  2,
+				Primary: true,
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			authorService := new(m.Author)
+			directory := NewDirectory(&service.Services{Author: authorService})
+			if tc.fn != nil {
+				tc.fn(authorService, tc)
+			}
+
+			res, err := directory.GetAuthorByUserID(context.Background(), tc.req)
+			assertStatus(assert, err, tc.expCode, tc.expMsg)
+			assert.True(proto.Equal(tc.expRes, res))
+			authorService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDirectory_ListAuthors(t *testing.T) {
+	assert := testAssert.New(t)
+	after, err := cursor.Encode(model.AuthorCursor{ID: 1, SortBy: model.AuthorSortName})
+	require.NoError(t, err)
+	type test struct {
+		name    string
+		req     *ListAuthorsRequest
+		fn      func(authorService *m.Author, data test)
+		expRes  *ListAuthorsResponse
+		expCode codes.Code
+		expMsg  string
+	}
+	tt := []test{
+		{
+			name:    "limit too big",
+			req:     &ListAuthorsRequest{Limit: maxPageLimit + 1},
+			expCode: codes.InvalidArgument,
+			expMsg:  "limit must be between 1 and 100",
+		},
+		{
+			name:    "not correct sort",
+			req:     &ListAuthorsRequest{SortBy: "password"},
+			expCode: codes.InvalidArgument,
+			expMsg:  "not correct sort",
+		},
+		{
+			name:    "not correct cursor",
+			req:     &ListAuthorsRequest{After: "bad"},
+			expCode: codes.InvalidArgument,
+			expMsg:  "not correct cursor",
+		},
+		{
+			name:    "cursor of another sort",
+			req:     &ListAuthorsRequest{After: after},
+			expCode: codes.InvalidArgument,
+			expMsg:  "cursor doesn't match sort order",
+		},
+		{
+			name:    "min age is greater than max age",
+			req:     &ListAuthorsRequest{MinAge: 30, MaxAge: 20},
+			expCode: codes.InvalidArgument,
+			expMsg:  "min age is greater than max age",
+		},
+		{
+			name: "FindAll errors",
+			req:  &ListAuthorsRequest{},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindAll", mock.Anything, mock.Anything).
+					Return(nil, errors.New(""))
+			},
+			expCode: codes.Internal,
+			expMsg:  "internal error",
+		},
+		{
+			name: "defaults",
+			req:  &ListAuthorsRequest{},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindAll", mock.Anything, model.ListAuthorsRequest{
+					Limit:  defaultPageLimit,
+					SortBy: model.AuthorSortID,
+					Order:  model.OrderAsc,
+				}).
+					Return(&model.AuthorPage{Items: []model.Author{}}, nil)
+			},
+			expRes: &ListAuthorsResponse{Items: []*Author{}},
+		},
+		{
+			name: "all ok",
+			req: &ListAuthorsRequest{
+				Limit:  1,
+				SortBy: model.AuthorSortName,
+				After:  after,
+				MinAge: 10,
+				MaxAge: 40,
+				UserId: 2,
+			},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("FindAll", mock.Anything, model.ListAuthorsRequest{
+					Limit:  1,
+					SortBy: model.AuthorSortName,
+					Order:  model.OrderAsc,
+					After:  &model.AuthorCursor{ID: 1, SortBy: model.AuthorSortName},
+					MinAge: 10,
+					MaxAge: 40,
+					UserID: 2,
+				}).
+					Return(&model.AuthorPage{
+						Items:      []model.Author{{ID: 2, Name: "test", Age: 20, UserID: 2}},
+						NextCursor: "next",
+						Total:      3,
+					}, nil)
+			},
+			expRes: &ListAuthorsResponse{
+				Items:      []*Author{{Id: 2, Name: "test", Age: 20, UserId: 2}},
+				NextCursor: "next",
+				Total:      3,
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			authorService := new(m.Author)
+			directory := NewDirectory(&service.Services{Author: authorService})
+			if tc.fn != nil {
+				tc.fn(authorService, tc)
+			}
+
+			res, err := directory.ListAuthors(context.Background(), tc.req)
+			assertStatus(assert, err, tc.expCode, tc.expMsg)
+			assert.True(proto.Equal(tc.expRes, res))
+			authorService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDirectory_SearchAuthors(t *testing.T) {
+	assert := testAssert.New(t)
+	type test struct {
+		name    string
+		req     *SearchAuthorsRequest
+		fn      func(authorService *m.Author, data test)
+		expRes  *SearchAuthorsResponse
+		expCode codes.Code
+		expMsg  string
+	}
+	tt := []test{
+		{
+			name:    "empty query",
+			req:     &SearchAuthorsRequest{},
+			expCode: codes.InvalidArgument,
+			expMsg:  "query is required",
+		},
+		{
+			name:    "limit too big",
+			req:     &SearchAuthorsRequest{Query: "test", Limit: maxPageLimit + 1},
+			expCode: codes.InvalidArgument,
+			expMsg:  "limit must be between 1 and 100",
+		},
+		{
+			name: "Search errors",
+			req:  &SearchAuthorsRequest{Query: "test"},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("Search", mock.Anything, model.SearchAuthorRequest{Query: "test", Limit: defaultPageLimit}).
+					Return(nil, errors.New(""))
+			},
+			expCode: codes.Internal,
+			expMsg:  "internal error",
+		},
+		{
+			name: "all ok",
+			req:  &SearchAuthorsRequest{Query: "test", Limit: 5},
+			fn: func(authorService *m.Author, data test) {
+				authorService.On("Search", mock.Anything, model.SearchAuthorRequest{Query: "test", Limit: 5}).
+					Return([]model.AuthorMatch{{
+						Author:               model.Author{ID: 1, Name: "test", UserID: 2},
+						Rank:                 0.5,
+						NameHighlight:        "<b>test</b>",
+						DescriptionHighlight: "",
+					}}, nil)
+			},
+			expRes: &SearchAuthorsResponse{
+				Items: []*AuthorMatch{{
+					Author:        &Author{Id: 1, Name: "test", UserId: 2},
+					Rank:          0.5,
+					NameHighlight: "<b>test</b>",
+				}},
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			authorService := new(m.Author)
+			directory := NewDirectory(&service.Services{Author: authorService})
+			if tc.fn != nil {
+				tc.fn(authorService, tc)
+			}
+
+			res, err := directory.SearchAuthors(context.Background(), tc.req)
+			assertStatus(assert, err, tc.expCode, tc.expMsg)
+			assert.True(proto.Equal(tc.expRes, res))
+			authorService.AssertExpectations(t)
+		})
+	}
+}
+
+// assertStatus checks the grpc status of err, OK code means there is no error.
+func assertStatus(assert *testAssert.Assertions, err error, code codes.Code, msg string) {
+	if code == codes.OK {
+		assert.Nil(err)
+		return
+	}
+
+	st, ok := status.FromError(err)
+	if assert.True(ok) {
+		assert.Equal(code, st.Code())
+		assert.Contains(st.Message(), msg)
+	}
+}
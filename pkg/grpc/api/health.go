@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// servedServices are names of the services reported by Health.
+var servedServices = []string{"", "grpc.Existance", "grpc.Directory"}
+
+// Health reports the services serving while the database answers pings.
+type Health struct {
+	*health.Server
+	ping     func(context.Context) error
+	interval time.Duration
+	log      logger.Logger
+}
+
+// NewHealth is a Health constructor, services aren't serving until the first ping.
+func NewHealth(ping func(context.Context) error, interval time.Duration, log logger.Logger) *Health {
+	h := &Health{
+		Server:   health.NewServer(),
+		ping:     ping,
+		interval: interval,
+		log:      log,
+	}
+	h.set(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return h
+}
+
+// Monitor pings the database every interval until ctx is done.
+func (h *Health) Monitor(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		h.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *Health) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, h.interval)
+	defer cancel()
+
+	if err := h.ping(ctx); err != nil {
+		h.log.Warn("database ping failed", "error", err)
+		h.set(healthpb.HealthCheckResponse_NOT_SERVING)
+		return
+	}
+
+	h.set(healthpb.HealthCheckResponse_SERVING)
+}
+
+func (h *Health) set(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range servedServices {
+		h.SetServingStatus(service, status)
+	}
+}
+
+// Serving returns an error unless the services are serving.
+func (h *Health) Serving(ctx context.Context) error {
+	res, err := h.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+
+	if res.Status != healthpb.HealthCheckResponse_SERVING {
+		return errors.Errorf("grpc server is %s", res.Status)
+	}
+
+	return nil
+}
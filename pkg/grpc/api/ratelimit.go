@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
+	"github.com/JesusG2000/hexsatisfaction/pkg/ratelimit"
+	"google.golang.org/grpc"
+)
+
+// RateLimit throttles calls by the user or the service authenticated by Guard, so it must run after Guard.
+// Public calls aren't throttled and calls pass if the limiter fails.
+type RateLimit struct {
+	limiter ratelimit.Limiter
+}
+
+// NewRateLimit is a RateLimit constructor.
+func NewRateLimit(limiter ratelimit.Limiter) *RateLimit {
+	return &RateLimit{limiter: limiter}
+}
+
+// Unary returns a server interceptor which throttles unary calls.
+func (l *RateLimit) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.allow(ctx); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns a server interceptor which throttles streaming calls.
+func (l *RateLimit) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.allow(ss.Context()); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func (l *RateLimit) allow(ctx context.Context) error {
+	caller, ok := CallerFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	key := "service:" + caller
+	if principal, ok := auth.PrincipalFromContext(ctx); ok && caller == UserCaller {
+		key = "user:" + strconv.Itoa(principal.UserID)
+	}
+
+	ok, retryAfter, err := l.limiter.Allow(ctx, key)
+	if err != nil {
+		logger.Default().Error("rate limiter failed", "error", err)
+		return nil
+	}
+
+	if !ok {
+		return statusError(domain.Throttle(retryAfter, "too many calls of %s, try again later", caller))
+	}
+
+	return nil
+}
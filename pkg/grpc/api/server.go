@@ -1,27 +1,57 @@
 package api
 
 import (
+	"context"
 	"net"
 
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
-// NewGrpcServer launches new grpc server on a specified address.
-func NewGrpcServer(address string, service ExistanceServer) (server *grpc.Server, errChan <-chan error) {
-	errBuf := make(chan error)
+// Services collects services served by the grpc server.
+type Services struct {
+	Existance ExistanceServer
+	Directory DirectoryServer
+	Health    healthpb.HealthServer
+	// Reflection registers the server reflection service.
+	Reflection bool
+}
+
+// NewGrpcServer creates new grpc server with the services.
+func NewGrpcServer(services Services, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	RegisterExistanceServer(server, services.Existance)
+	RegisterDirectoryServer(server, services.Directory)
+	healthpb.RegisterHealthServer(server, services.Health)
+	if services.Reflection {
+		reflection.Register(server)
+	}
+
+	return server
+}
+
+// Serve serves the server on a specified address until it is stopped.
+func Serve(server *grpc.Server, address string) error {
 	listener, err := net.Listen("tcp", address)
 	if err != nil {
-		errBuf <- err
-		return nil, errBuf
+		return err
 	}
-	server = grpc.NewServer()
-	RegisterExistanceServer(server, service)
 
+	return server.Serve(listener)
+}
+
+// Stop stops the server gracefully, calls still running when ctx is done are cancelled.
+func Stop(ctx context.Context, server *grpc.Server) {
+	done := make(chan struct{})
 	go func() {
-		err = server.Serve(listener)
-		if err != nil {
-			errBuf <- err
-		}
+		server.GracefulStop()
+		close(done)
 	}()
-	return server, errBuf
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		server.Stop()
+	}
 }
@@ -4,8 +4,15 @@ import (
 	"context"
 
 	"github.com/JesusG2000/hexsatisfaction/internal/repository"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// maxBatchIDs limits the number of ids checked by one batch call.
+const maxBatchIDs = 1000
+
 type ExistChecker struct {
 	repository.Repositories
 }
@@ -15,9 +22,9 @@ func NewExistChecker(repositories repository.Repositories) *ExistChecker {
 }
 
 func (e *ExistChecker) User(ctx context.Context, req *IsUserExistRequest) (*IsUserExistResponse, error) {
-	result, err := e.Repositories.User.IsExistByID(int(req.Id))
+	result, err := e.Repositories.User.IsExistByID(ctx, int(req.Id))
 	if err != nil {
-		return nil, err
+		return nil, statusError(err)
 	}
 
 	return &IsUserExistResponse{
@@ -26,12 +33,70 @@ func (e *ExistChecker) User(ctx context.Context, req *IsUserExistRequest) (*IsUs
 }
 
 func (e *ExistChecker) Author(ctx context.Context, req *IsAuthorExistRequest) (*IsAuthorExistResponse, error) {
-	result, err := e.Repositories.Author.IsExistByID(int(req.Id))
+	result, err := e.Repositories.Author.IsExistByID(ctx, int(req.Id))
 	if err != nil {
-		return nil, err
+		return nil, statusError(err)
 	}
 
 	return &IsAuthorExistResponse{
 		Exist: result,
 	}, nil
 }
+
+// UsersExist checks which of the users exist with a single query.
+func (e *ExistChecker) UsersExist(ctx context.Context, req *UsersExistRequest) (*UsersExistResponse, error) {
+	exist, err := existByIDs(ctx, req.Ids, e.Repositories.User.ExistByIDs)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &UsersExistResponse{
+		Exist: exist,
+	}, nil
+}
+
+// AuthorsExist checks which of the authors exist with a single query.
+func (e *ExistChecker) AuthorsExist(ctx context.Context, req *AuthorsExistRequest) (*AuthorsExistResponse, error) {
+	exist, err := existByIDs(ctx, req.Ids, e.Repositories.Author.ExistByIDs)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &AuthorsExistResponse{
+		Exist: exist,
+	}, nil
+}
+
+func existByIDs(ctx context.Context, ids []int32, find func(context.Context, []int) (map[int]bool, error)) (map[int32]bool, error) {
+	if len(ids) > maxBatchIDs {
+		return nil, domain.Errorf(domain.ErrValidation, "no more than %d ids can be checked at once", maxBatchIDs)
+	}
+
+	req := make([]int, 0, len(ids))
+	for _, id := range ids {
+		req = append(req, int(id))
+	}
+
+	found, err := find(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	exist := make(map[int32]bool, len(found))
+	for id, ok := range found {
+		exist[int32(id)] = ok
+	}
+
+	return exist, nil
+}
+
+// statusError turns err into a grpc status with the code of the domain error, the text of internal errors is logged and hidden.
+func statusError(err error) error {
+	code := domain.GRPCCode(err)
+	if code == codes.Internal {
+		logger.Default().Error("grpc call failed", "error", err)
+		return status.Error(code, "internal error")
+	}
+
+	return status.Error(code, domain.Message(err))
+}
@@ -0,0 +1,115 @@
+package hash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2SaltLen = 16
+
+// Argon2Hasher hashes passwords with argon2id.
+type Argon2Hasher struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+	keyLen  uint32
+}
+
+// NewArgon2Hasher is a Argon2Hasher constructor.
+func NewArgon2Hasher(time, memory uint32, threads uint8, keyLen uint32) (*Argon2Hasher, error) {
+	if time == 0 || memory == 0 || threads == 0 || keyLen == 0 {
+		return nil, errors.New("argon2 params must be positive")
+	}
+
+	return &Argon2Hasher{
+		time:    time,
+		memory:  memory,
+		threads: threads,
+		keyLen:  keyLen,
+	}, nil
+}
+
+// Hash hashes the password.
+func (a *Argon2Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.Wrap(err, "couldn't generate salt")
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.time, a.memory, a.threads, a.keyLen)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		Argon2id, argon2.Version, a.memory, a.time, a.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify checks if the password matches the hash.
+func (a *Argon2Hasher) Verify(hashedPassword, password string) (bool, error) {
+	return verify(hashedPassword, password)
+}
+
+// NeedsRehash checks if the hash was produced by another algorithm or with other params.
+func (a *Argon2Hasher) NeedsRehash(hashedPassword string) bool {
+	if !isArgon2id(hashedPassword) {
+		return true
+	}
+
+	params, _, key, err := decodeArgon2id(hashedPassword)
+	if err != nil {
+		return true
+	}
+
+	return params.time != a.time || params.memory != a.memory ||
+		params.threads != a.threads || uint32(len(key)) != a.keyLen
+}
+
+func verifyArgon2id(hashedPassword, password string) (bool, error) {
+	params, salt, key, err := decodeArgon2id(hashedPassword)
+	if err != nil {
+		return false, err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, uint32(len(key)))
+
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+func decodeArgon2id(hashedPassword string) (*Argon2Hasher, []byte, []byte, error) {
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 {
+		return nil, nil, nil, ErrUnknownFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "couldn't parse argon2 version")
+	}
+	if version != argon2.Version {
+		return nil, nil, nil, errors.Errorf("unsupported argon2 version %d", version)
+	}
+
+	var params Argon2Hasher
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "couldn't parse argon2 params")
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "couldn't decode argon2 salt")
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "couldn't decode argon2 key")
+	}
+
+	return &params, salt, key, nil
+}
@@ -0,0 +1,61 @@
+package hash
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHasher hashes passwords with bcrypt.
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher is a BcryptHasher constructor.
+func NewBcryptHasher(cost int) (*BcryptHasher, error) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, errors.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+
+	return &BcryptHasher{cost: cost}, nil
+}
+
+// Hash hashes the password.
+func (b *BcryptHasher) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		return "", errors.Wrap(err, "couldn't hash password")
+	}
+
+	return string(hashed), nil
+}
+
+// Verify checks if the password matches the hash.
+func (b *BcryptHasher) Verify(hashedPassword, password string) (bool, error) {
+	return verify(hashedPassword, password)
+}
+
+// NeedsRehash checks if the hash was produced by another algorithm or with another cost.
+func (b *BcryptHasher) NeedsRehash(hashedPassword string) bool {
+	if !isBcrypt(hashedPassword) {
+		return true
+	}
+
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	if err != nil {
+		return true
+	}
+
+	return cost != b.cost
+}
+
+func verifyBcrypt(hashedPassword, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+		return false, nil
+	default:
+		return false, errors.Wrap(err, "couldn't verify password")
+	}
+}
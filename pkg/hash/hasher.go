@@ -0,0 +1,62 @@
+package hash
+
+import (
+	"strings"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Bcrypt represents bcrypt hashing algorithm.
+	Bcrypt = "bcrypt"
+	// Argon2id represents argon2id hashing algorithm.
+	Argon2id = "argon2id"
+)
+
+// ErrUnknownFormat is returned when a hash was produced by an unsupported algorithm.
+var ErrUnknownFormat = errors.New("unknown hash format")
+
+// PasswordHasher provides logic for password hashing and verification.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(hashedPassword, password string) (bool, error)
+	NeedsRehash(hashedPassword string) bool
+}
+
+// NewHasher creates a PasswordHasher for the configured algorithm.
+func NewHasher(cfg config.HashConfig) (PasswordHasher, error) {
+	switch cfg.Algorithm {
+	case Bcrypt:
+		return NewBcryptHasher(cfg.BcryptCost)
+	case Argon2id:
+		return NewArgon2Hasher(cfg.ArgonTime, cfg.ArgonMemory, cfg.ArgonThreads, cfg.ArgonKeyLen)
+	default:
+		return nil, errors.Errorf("unsupported hash algorithm %q", cfg.Algorithm)
+	}
+}
+
+// IsHashed checks if the value is a hash produced by one of the supported algorithms.
+func IsHashed(value string) bool {
+	return isBcrypt(value) || isArgon2id(value)
+}
+
+// verify checks the password against a hash of any supported algorithm.
+func verify(hashedPassword, password string) (bool, error) {
+	switch {
+	case isBcrypt(hashedPassword):
+		return verifyBcrypt(hashedPassword, password)
+	case isArgon2id(hashedPassword):
+		return verifyArgon2id(hashedPassword, password)
+	default:
+		return false, ErrUnknownFormat
+	}
+}
+
+func isBcrypt(value string) bool {
+	return strings.HasPrefix(value, "$2a$") || strings.HasPrefix(value, "$2b$") || strings.HasPrefix(value, "$2y$")
+}
+
+func isArgon2id(value string) bool {
+	return strings.HasPrefix(value, "$"+Argon2id+"$")
+}
@@ -0,0 +1,163 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/config"
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestNewHasher(t *testing.T) {
+	assert := testAssert.New(t)
+	tt := []struct {
+		name string
+		cfg  config.HashConfig
+		isOk bool
+	}{
+		{
+			name: "unknown algorithm",
+			cfg:  config.HashConfig{Algorithm: "md5"},
+		},
+		{
+			name: "bad bcrypt cost",
+			cfg:  config.HashConfig{Algorithm: Bcrypt, BcryptCost: bcrypt.MaxCost + 1},
+		},
+		{
+			name: "zero argon2 params",
+			cfg:  config.HashConfig{Algorithm: Argon2id},
+		},
+		{
+			name: "bcrypt",
+			cfg:  config.HashConfig{Algorithm: Bcrypt, BcryptCost: bcrypt.MinCost},
+			isOk: true,
+		},
+		{
+			name: "argon2id",
+			cfg:  config.HashConfig{Algorithm: Argon2id, ArgonTime: 1, ArgonMemory: 64, ArgonThreads: 1, ArgonKeyLen: 16},
+			isOk: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewHasher(tc.cfg)
+			assert.Equal(tc.isOk, err == nil)
+		})
+	}
+}
+
+func TestArgon2Hasher_Verify(t *testing.T) {
+	assert := testAssert.New(t)
+	hasher, err := NewArgon2Hasher(1, 64, 1, 16)
+	require.NoError(t, err)
+	hashed, err := hasher.Hash("test")
+	require.NoError(t, err)
+	bcryptHasher, err := NewBcryptHasher(bcrypt.MinCost)
+	require.NoError(t, err)
+	bcryptHashed, err := bcryptHasher.Hash("test")
+	require.NoError(t, err)
+
+	parts := strings.Split(hashed, "$")
+	tt := []struct {
+		name     string
+		hashed   string
+		password string
+		isOk     bool
+		isErr    bool
+	}{
+		{
+			name:     "right password",
+			hashed:   hashed,
+			password: "test",
+			isOk:     true,
+		},
+		{
+			name:     "wrong password",
+			hashed:   hashed,
+			password: "tesT",
+		},
+		{
+			name:     "bcrypt hash",
+			hashed:   bcryptHashed,
+			password: "test",
+			isOk:     true,
+		},
+		{
+			name:     "plain password",
+			hashed:   "test",
+			password: "test",
+			isErr:    true,
+		},
+		{
+			name:     "missing part",
+			hashed:   strings.Join(parts[:5], "$"),
+			password: "test",
+			isErr:    true,
+		},
+		{
+			name:     "other version",
+			hashed:   strings.Join([]string{"", Argon2id, "v=16", parts[3], parts[4], parts[5]}, "$"),
+			password: "test",
+			isErr:    true,
+		},
+		{
+			name:     "bad params",
+			hashed:   strings.Join([]string{"", Argon2id, parts[2], "m=x", parts[4], parts[5]}, "$"),
+			password: "test",
+			isErr:    true,
+		},
+		{
+			name:     "bad salt",
+			hashed:   strings.Join([]string{"", Argon2id, parts[2], parts[3], "!", parts[5]}, "$"),
+			password: "test",
+			isErr:    true,
+		},
+		{
+			name:     "other salt",
+			hashed:   strings.Join([]string{"", Argon2id, parts[2], parts[3], "c2FsdHNhbHRzYWx0c2FsdA", parts[5]}, "$"),
+			password: "test",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := hasher.Verify(tc.hashed, tc.password)
+			assert.Equal(tc.isErr, err != nil)
+			assert.Equal(tc.isOk, ok)
+		})
+	}
+}
+
+func TestArgon2Hasher_NeedsRehash(t *testing.T) {
+	assert := testAssert.New(t)
+	hasher, err := NewArgon2Hasher(1, 64, 1, 16)
+	require.NoError(t, err)
+	hashed, err := hasher.Hash("test")
+	require.NoError(t, err)
+	stronger, err := NewArgon2Hasher(2, 64, 1, 16)
+	require.NoError(t, err)
+	longer, err := NewArgon2Hasher(1, 64, 1, 32)
+	require.NoError(t, err)
+	bcryptHasher, err := NewBcryptHasher(bcrypt.MinCost)
+	require.NoError(t, err)
+	bcryptHashed, err := bcryptHasher.Hash("test")
+	require.NoError(t, err)
+
+	assert.False(hasher.NeedsRehash(hashed))
+	assert.True(stronger.NeedsRehash(hashed))
+	assert.True(longer.NeedsRehash(hashed))
+	assert.True(hasher.NeedsRehash(bcryptHashed))
+	assert.True(hasher.NeedsRehash("$" + Argon2id + "$broken"))
+	assert.True(bcryptHasher.NeedsRehash(hashed))
+	assert.False(bcryptHasher.NeedsRehash(bcryptHashed))
+}
+
+func TestIsHashed(t *testing.T) {
+	assert := testAssert.New(t)
+	assert.True(IsHashed("$2a$10$abc"))
+	assert.True(IsHashed("$" + Argon2id + "$v=19$m=64,t=1,p=1$c2FsdA$a2V5"))
+	assert.False(IsHashed("password"))
+}
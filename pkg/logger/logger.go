@@ -0,0 +1,184 @@
+// Package logger writes leveled structured logs.
+package logger
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/config"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Supported formats of logs.
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// redacted replaces values of secret keys.
+const redacted = "[REDACTED]"
+
+// secretKeys are parts of keys whose values are never logged.
+var secretKeys = []string{"password", "token", "secret", "authorization", "signing_key", "dsn"}
+
+// Logger writes leveled structured logs, args are alternating keys and values like in slog.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+	// With returns a logger adding args to every record.
+	With(args ...interface{}) Logger
+	// WithContext returns a logger adding args put into ctx by ContextWith.
+	WithContext(ctx context.Context) Logger
+}
+
+type zapLogger struct {
+	sugar *zap.SugaredLogger
+}
+
+// New creates a logger writing to stderr at the level debug, info, warn or error in the json or text format.
+func New(cfg config.LogConfig) (Logger, error) {
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, errors.Errorf("unsupported log level %q", cfg.Level)
+	}
+
+	var zapCfg zap.Config
+	switch cfg.Format {
+	case FormatJSON:
+		zapCfg = zap.NewProductionConfig()
+		zapCfg.Sampling = nil
+		zapCfg.EncoderConfig.TimeKey = "time"
+		zapCfg.EncoderConfig.MessageKey = "msg"
+		zapCfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	case FormatText:
+		zapCfg = zap.NewDevelopmentConfig()
+		zapCfg.Development = false
+	default:
+		return nil, errors.Errorf("unsupported log format %q", cfg.Format)
+	}
+	zapCfg.Level = zap.NewAtomicLevelAt(level)
+	zapCfg.DisableStacktrace = true
+
+	l, err := zapCfg.Build(zap.AddCallerSkip(1))
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't build logger")
+	}
+
+	return &zapLogger{sugar: l.Sugar()}, nil
+}
+
+// Nop returns a logger discarding every record.
+func Nop() Logger {
+	return &zapLogger{sugar: zap.NewNop().Sugar()}
+}
+
+// Debug logs msg at the debug level.
+func (l *zapLogger) Debug(msg string, args ...interface{}) {
+	l.sugar.Debugw(msg, redact(args)...)
+}
+
+// Info logs msg at the info level.
+func (l *zapLogger) Info(msg string, args ...interface{}) {
+	l.sugar.Infow(msg, redact(args)...)
+}
+
+// Warn logs msg at the warn level.
+func (l *zapLogger) Warn(msg string, args ...interface{}) {
+	l.sugar.Warnw(msg, redact(args)...)
+}
+
+// Error logs msg at the error level.
+func (l *zapLogger) Error(msg string, args ...interface{}) {
+	l.sugar.Errorw(msg, redact(args)...)
+}
+
+// With returns a logger adding args to every record.
+func (l *zapLogger) With(args ...interface{}) Logger {
+	return &zapLogger{sugar: l.sugar.With(redact(args)...)}
+}
+
+// WithContext returns a logger adding args put into ctx by ContextWith.
+func (l *zapLogger) WithContext(ctx context.Context) Logger {
+	args, _ := ctx.Value(argsKey{}).([]interface{})
+	if len(args) == 0 {
+		return l
+	}
+
+	return l.With(args...)
+}
+
+type argsKey struct{}
+
+// ContextWith puts args into ctx to be added by WithContext to records about the request or the call.
+func ContextWith(ctx context.Context, args ...interface{}) context.Context {
+	prev, _ := ctx.Value(argsKey{}).([]interface{})
+	return context.WithValue(ctx, argsKey{}, append(prev[:len(prev):len(prev)], args...))
+}
+
+// redact replaces values of keys naming secrets.
+func redact(args []interface{}) []interface{} {
+	var res []interface{}
+	for i := 0; i+1 < len(args); i += 2 {
+		key, ok := args[i].(string)
+		if !ok || !isSecret(key) {
+			continue
+		}
+
+		if res == nil {
+			res = append(make([]interface{}, 0, len(args)), args...)
+		}
+		res[i+1] = redacted
+	}
+
+	if res == nil {
+		return args
+	}
+
+	return res
+}
+
+func isSecret(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+
+	return false
+}
+
+var (
+	defaultMu     sync.RWMutex
+	defaultLogger Logger = mustDefault()
+)
+
+func mustDefault() Logger {
+	l, err := New(config.LogConfig{Level: "info", Format: FormatJSON})
+	if err != nil {
+		panic(err)
+	}
+
+	return l
+}
+
+// Default returns the logger used by code without an injected one.
+func Default() Logger {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+
+	return defaultLogger
+}
+
+// SetDefault replaces the logger returned by Default.
+func SetDefault(l Logger) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	defaultLogger = l
+}
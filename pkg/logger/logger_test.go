@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/config"
+	testAssert "github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newObserved returns a logger with the records it writes.
+func newObserved() (Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return &zapLogger{sugar: zap.New(core).Sugar()}, logs
+}
+
+func TestNew(t *testing.T) {
+	assert := testAssert.New(t)
+	tt := []struct {
+		name string
+		cfg  config.LogConfig
+		isOk bool
+	}{
+		{
+			name: "unknown level",
+			cfg:  config.LogConfig{Level: "verbose", Format: FormatJSON},
+		},
+		{
+			name: "unknown format",
+			cfg:  config.LogConfig{Level: "info", Format: "xml"},
+		},
+		{
+			name: "json",
+			cfg:  config.LogConfig{Level: "debug", Format: FormatJSON},
+			isOk: true,
+		},
+		{
+			name: "text",
+			cfg:  config.LogConfig{Level: "warn", Format: FormatText},
+			isOk: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.cfg)
+			assert.Equal(tc.isOk, err == nil)
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	assert := testAssert.New(t)
+	tt := []struct {
+		name   string
+		args   []interface{}
+		expRes []interface{}
+	}{
+		{
+			name:   "no secrets",
+			args:   []interface{}{"user_id", 1, "login", "test"},
+			expRes: []interface{}{"user_id", 1, "login", "test"},
+		},
+		{
+			name:   "secret keys",
+			args:   []interface{}{"password", "test", "refreshToken", "abc", "Authorization", "Bearer abc", "PG_DSN", "postgres://"},
+			expRes: []interface{}{"password", redacted, "refreshToken", redacted, "Authorization", redacted, "PG_DSN", redacted},
+		},
+		{
+			name:   "key without value",
+			args:   []interface{}{"login", "test", "password"},
+			expRes: []interface{}{"login", "test", "password"},
+		},
+		{
+			name:   "not a string key",
+			args:   []interface{}{1, "password"},
+			expRes: []interface{}{1, "password"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			args := append([]interface{}{}, tc.args...)
+			assert.Equal(tc.expRes, redact(args))
+			assert.Equal(tc.args, args, "args of the caller aren't changed")
+		})
+	}
+}
+
+func TestLogger_Redacts(t *testing.T) {
+	assert := testAssert.New(t)
+	log, logs := newObserved()
+
+	log.Info("login", "login", "test", "password", "secret")
+	log.With("signing_key", "key").Warn("with")
+	ctx := ContextWith(context.Background(), "request_id", "1", "token", "abc")
+	log.WithContext(ctx).Error("request", "error", "boom")
+
+	entries := logs.AllUntimed()
+	assert.Len(entries, 3)
+	assert.Equal(map[string]interface{}{"login": "test", "password": redacted}, entries[0].ContextMap())
+	assert.Equal(map[string]interface{}{"signing_key": redacted}, entries[1].ContextMap())
+	assert.Equal(map[string]interface{}{"request_id": "1", "token": redacted, "error": "boom"}, entries[2].ContextMap())
+}
+
+func TestContextWith(t *testing.T) {
+	assert := testAssert.New(t)
+	log, logs := newObserved()
+
+	parent := ContextWith(context.Background(), "request_id", "1")
+	first := ContextWith(parent, "user_id", 1)
+	second := ContextWith(parent, "user_id", 2)
+
+	log.WithContext(first).Info("first")
+	log.WithContext(second).Info("second")
+	log.WithContext(context.Background()).Info("none")
+
+	entries := logs.AllUntimed()
+	assert.Equal(map[string]interface{}{"request_id": "1", "user_id": int64(1)}, entries[0].ContextMap())
+	assert.Equal(map[string]interface{}{"request_id": "1", "user_id": int64(2)}, entries[1].ContextMap())
+	assert.Empty(entries[2].ContextMap())
+}
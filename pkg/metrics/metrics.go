@@ -0,0 +1,51 @@
+// Package metrics exposes prometheus metrics of the service.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "hexsatisfaction"
+
+// Results of logins.
+const (
+	ResultOK     = "ok"
+	ResultFailed = "failed"
+)
+
+var (
+	// Registrations counts registered users.
+	Registrations = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "user_registrations_total",
+		Help:      "Number of registered users.",
+	})
+	// Logins counts logins by result, which is ok or failed.
+	Logins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "user_logins_total",
+		Help:      "Number of logins by result.",
+	}, []string{"result"})
+	// AuthorsCreated counts created authors.
+	AuthorsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "authors_created_total",
+		Help:      "Number of created authors.",
+	})
+)
+
+// Handler serves metrics in the prometheus format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// RegisterDB exposes the statistics of the database pool.
+func RegisterDB(db *sql.DB, name string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, name))
+}
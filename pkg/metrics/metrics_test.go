@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	testAssert "github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTP(t *testing.T) {
+	assert := testAssert.New(t)
+
+	nested := mux.NewRouter()
+	nested.Use(Route)
+	nested.HandleFunc("/author/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}).Methods(http.MethodGet)
+
+	router := mux.NewRouter()
+	router.Use(Route)
+	router.PathPrefix("/author").Handler(nested)
+	router.HandleFunc("/user/{id}", func(w http.ResponseWriter, r *http.Request) {}).Methods(http.MethodGet)
+	handler := HTTP(router)
+
+	tt := []struct {
+		name     string
+		method   string
+		path     string
+		expRoute string
+		expCode  string
+	}{
+		{
+			name:     "route template",
+			method:   http.MethodGet,
+			path:     "/user/15",
+			expRoute: "/user/{id}",
+			expCode:  "200",
+		},
+		{
+			name:     "nested route template",
+			method:   http.MethodGet,
+			path:     "/author/15",
+			expRoute: "/author/{id}",
+			expCode:  "404",
+		},
+		{
+			name:     "unmatched route",
+			method:   http.MethodGet,
+			path:     "/unknown/15",
+			expRoute: unmatchedRoute,
+			expCode:  "404",
+		},
+		{
+			name:     "unmatched method",
+			method:   http.MethodPost,
+			path:     "/user/15",
+			expRoute: unmatchedRoute,
+			expCode:  "405",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			counter := httpRequests.WithLabelValues(tc.expRoute, tc.method, tc.expCode)
+			before := testutil.ToFloat64(counter)
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tc.method, tc.path, nil))
+
+			assert.Equal(before+1, testutil.ToFloat64(counter))
+		})
+	}
+
+	assert.Zero(testutil.ToFloat64(httpRequests.WithLabelValues("/user/15", http.MethodGet, "200")), "raw paths aren't labels")
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	assert := testAssert.New(t)
+	const method = "/grpc.Directory/GetUser"
+	tt := []struct {
+		name    string
+		err     error
+		expCode codes.Code
+	}{
+		{
+			name:    "ok",
+			expCode: codes.OK,
+		},
+		{
+			name:    "status error",
+			err:     status.Error(codes.NotFound, "user not found"),
+			expCode: codes.NotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			counter := grpcHandled.WithLabelValues(method, tc.expCode.String())
+			before := testutil.ToFloat64(counter)
+
+			_, err := UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method},
+				func(context.Context, interface{}) (interface{}, error) {
+					return nil, tc.err
+				})
+
+			assert.Equal(tc.err, err)
+			assert.Equal(before+1, testutil.ToFloat64(counter))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
+	"github.com/gorilla/mux"
+)
+
+type accessKey struct{}
+
+// access collects what is known about the request only inside nested routers.
+type access struct {
+	route  string
+	userID int
+}
+
+// AccessLog logs method, route template, status, latency and user id of every request except ones to skipPaths,
+// the id of the request put by RequestID is added to the record.
+// Routers must use Route to log their own routes.
+func AccessLog(log logger.Logger, skipPaths ...string) mux.MiddlewareFunc {
+	skip := make(map[string]bool, len(skipPaths))
+	for _, path := range skipPaths {
+		skip[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if skip[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			entry := &access{route: routeTemplate(r)}
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), accessKey{}, entry)))
+
+			args := []interface{}{
+				"method", r.Method,
+				"route", entry.route,
+				"status", rec.status,
+				"latency", time.Since(start),
+			}
+			if entry.userID != 0 {
+				args = append(args, "user_id", entry.userID)
+			}
+
+			log := log.WithContext(r.Context())
+			if rec.status >= http.StatusInternalServerError {
+				log.Error("request served", args...)
+				return
+			}
+			log.Info("request served", args...)
+		})
+	}
+}
+
+// Route records the template of the route matched by a router for AccessLog.
+func Route(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if entry, ok := r.Context().Value(accessKey{}).(*access); ok {
+			entry.route = routeTemplate(r)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// SetUserID records the id of the user who made the request for AccessLog.
+func SetUserID(ctx context.Context, userID int) {
+	if entry, ok := ctx.Value(accessKey{}).(*access); ok {
+		entry.userID = userID
+	}
+}
+
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+
+	return "unmatched"
+}
+
+// statusRecorder remembers the status code written to the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code and writes it.
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,242 @@
+package middleware
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
+	"github.com/gorilla/mux"
+)
+
+// maxBodyBytes limits the size of a request body read by Bind.
+const maxBodyBytes = 1 << 20
+
+// errBodyTooLarge is returned by Bind when the body is larger than maxBodyBytes.
+var errBodyTooLarge = domain.Errorf(domain.ErrTooLarge, "request body is too large")
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// Bind fills s, a pointer to a struct, from r.
+// Fields with a json tag are decoded from the JSON body, unknown JSON fields are rejected.
+// Fields with a path or a query tag are read from the path var or the query param of that name,
+// the default tag gives the value if the path var or the query param is missing.
+// Besides strings, integers and booleans such fields may implement encoding.TextUnmarshaler.
+func Bind(r *http.Request, s interface{}) error {
+	v := reflect.ValueOf(s)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("couldn't bind request to %T", s)
+	}
+
+	if err := decodeBody(r, s); err != nil {
+		return err
+	}
+
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+	errs := make(domain.FieldErrors)
+	eachField(v.Elem(), func(field reflect.StructField, value reflect.Value) {
+		var name, raw string
+		var ok bool
+		if name = field.Tag.Get("path"); name != "" {
+			raw, ok = vars[name]
+		} else if name = field.Tag.Get("query"); name != "" {
+			raw = query.Get(name)
+			ok = raw != ""
+		} else {
+			return
+		}
+
+		if !ok {
+			raw, ok = field.Tag.Lookup("default")
+		}
+
+		if ok && setField(value, raw) != nil {
+			errs.Check(false, name, fmt.Sprintf("not correct %s", name))
+		}
+	})
+
+	return errs.Err()
+}
+
+// ValidateTags checks fields of s, a struct or a pointer to it, against their validate tags.
+// Rules are separated by commas: required checks the field isn't empty,
+// min=n and max=n limit a number or the length of a string.
+// Errors are keyed by the path, query or json name of the field.
+func ValidateTags(s interface{}) domain.FieldErrors {
+	errs := make(domain.FieldErrors)
+
+	v := reflect.Indirect(reflect.ValueOf(s))
+	if v.Kind() != reflect.Struct {
+		return errs
+	}
+
+	eachField(v, func(field reflect.StructField, value reflect.Value) {
+		tag := field.Tag.Get("validate")
+		if tag == "" {
+			return
+		}
+
+		name := fieldName(field)
+		for _, rule := range strings.Split(tag, ",") {
+			if msg := checkRule(rule, value); msg != "" {
+				errs.Check(false, name, name+" "+msg)
+			}
+		}
+	})
+
+	return errs
+}
+
+func decodeBody(r *http.Request, s interface{}) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			logger.Default().Warn("couldn't close request body", "error", err)
+		}
+	}(r.Body)
+
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(s)
+	if err == nil || errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return domain.FieldErrors{typeErr.Field: fmt.Sprintf("not correct %s", typeErr.Field)}
+	}
+
+	// encoding/json and net/http have no typed errors for these cases.
+	msg := err.Error()
+	if strings.HasPrefix(msg, "json: unknown field ") {
+		field := strings.Trim(strings.TrimPrefix(msg, "json: unknown field "), `"`)
+		return domain.FieldErrors{field: fmt.Sprintf("unknown field %s", field)}
+	}
+	if msg == "http: request body too large" {
+		return errBodyTooLarge
+	}
+
+	return err
+}
+
+// eachField calls fn for exported fields of the struct v and of the structs embedded into it.
+func eachField(v reflect.Value, fn func(reflect.StructField, reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			eachField(v.Field(i), fn)
+			continue
+		}
+
+		fn(field, v.Field(i))
+	}
+}
+
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"path", "query"} {
+		if name := field.Tag.Get(key); name != "" {
+			return name
+		}
+	}
+
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+		return name
+	}
+
+	return field.Name
+}
+
+func setField(value reflect.Value, raw string) error {
+	if reflect.PtrTo(value.Type()).Implements(textUnmarshalerType) {
+		return value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	if value.Kind() == reflect.Ptr && value.Type().Implements(textUnmarshalerType) {
+		value.Set(reflect.New(value.Type().Elem()))
+		return value.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	default:
+		return fmt.Errorf("couldn't set field of %s kind", value.Kind())
+	}
+
+	return nil
+}
+
+// checkRule returns the message of the broken rule or an empty string.
+// It panics on unknown rules as they are mistakes in the code.
+func checkRule(rule string, value reflect.Value) string {
+	name, arg := rule, ""
+	if i := strings.Index(rule, "="); i >= 0 {
+		name, arg = rule[:i], rule[i+1:]
+	}
+
+	switch name {
+	case "required":
+		if value.IsZero() {
+			return "is required"
+		}
+		return ""
+	case "min", "max":
+		limit, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("not correct validate rule %q", rule))
+		}
+
+		var n int64
+		var unit string
+		switch value.Kind() {
+		case reflect.String:
+			n, unit = int64(utf8.RuneCountInString(value.String())), " characters long"
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = value.Int()
+		default:
+			panic(fmt.Sprintf("validate rule %q can't be used for %s", rule, value.Kind()))
+		}
+
+		if name == "min" && n < limit {
+			return fmt.Sprintf("must be at least %d%s", limit, unit)
+		}
+		if name == "max" && n > limit {
+			return fmt.Sprintf("must be at most %d%s", limit, unit)
+		}
+		return ""
+	default:
+		panic(fmt.Sprintf("unknown validate rule %q", rule))
+	}
+}
@@ -2,18 +2,38 @@ package middleware
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
 )
 
-type request interface {
+type builder interface {
 	Build(*http.Request) error
+}
+
+type validator interface {
 	Validate() error
 }
 
+// ErrorResponse represents a problem+json body of an error response.
+type ErrorResponse struct {
+	Code      string            `json:"code"`
+	Message   string            `json:"message"`
+	RequestID string            `json:"requestId,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
 // SwagError represents a struct for swagger errors.
 type SwagError struct {
-	Message string `json:"message"`
+	Code      string            `json:"code" example:"validation_failed"`
+	Message   string            `json:"message" example:"name is required"`
+	RequestID string            `json:"requestId,omitempty" example:"4bf92f3577b34da6a3ce929d0e0e4736"`
+	Fields    map[string]string `json:"fields,omitempty"`
 }
 
 // SwagEmptyError represents a struct for swagger errors without message.
@@ -21,13 +41,35 @@ type SwagEmptyError struct {
 }
 
 // ParseRequest parses request from http Request, stores it in the value pointed to by s and validates it.
+// The value is filled by its Build method or by Bind if it has no one,
+// then it is checked by its validate tags and by its Validate method if it has one.
 // You must close r.Body in the Build method if you used it.
-func ParseRequest(r *http.Request, s request) error {
-	err := s.Build(r)
+func ParseRequest(r *http.Request, s interface{}) error {
+	var err error
+	if b, ok := s.(builder); ok {
+		err = b.Build(r)
+	} else {
+		err = Bind(r, s)
+	}
 	if err != nil {
 		return err
 	}
-	return s.Validate()
+
+	errs := ValidateTags(s)
+	if v, ok := s.(validator); ok {
+		err := v.Validate()
+
+		var fields domain.FieldErrors
+		if !errors.As(err, &fields) && err != nil {
+			return err
+		}
+
+		for field, msg := range fields {
+			errs.Check(false, field, msg)
+		}
+	}
+
+	return errs.Err()
 }
 
 // JSONReturn returns server response in JSON format.
@@ -36,7 +78,7 @@ func JSONReturn(w http.ResponseWriter, statusCode int, jsonObject interface{}) {
 	w.WriteHeader(statusCode)
 	err := json.NewEncoder(w).Encode(jsonObject)
 	if err != nil {
-		fmt.Printf("could not encode json :%v", err.Error())
+		logger.Default().Error("couldn't encode json", "request_id", w.Header().Get(RequestIDHeader), "error", err)
 	}
 }
 
@@ -46,7 +88,49 @@ func Empty(w http.ResponseWriter, statusCode int) {
 	w.WriteHeader(statusCode)
 }
 
-// JSONError returns error from server in JSON format.
+// JSONError returns error from server as a problem+json body.
+// Domain errors get their own status and code instead of httpStatus,
+// the text of server errors is logged and replaced with the status text.
+// Validation errors of request fields are returned in the fields map,
+// throttled requests get the Retry-After header.
 func JSONError(w http.ResponseWriter, err error, httpStatus int) {
-	JSONReturn(w, httpStatus, err.Error())
+	if status, ok := domain.HTTPStatus(err); ok {
+		httpStatus = status
+	}
+
+	res := ErrorResponse{
+		Code:      errorCode(err, httpStatus),
+		Message:   domain.Message(err),
+		RequestID: w.Header().Get(RequestIDHeader),
+	}
+
+	if after, ok := domain.RetryAfter(err); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(after.Seconds()))))
+	}
+
+	if httpStatus >= http.StatusInternalServerError {
+		logger.Default().Error("request failed", "request_id", res.RequestID, "error", err)
+		res.Message = http.StatusText(httpStatus)
+	}
+
+	var fields domain.FieldErrors
+	if errors.As(err, &fields) {
+		res.Fields = fields
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+	w.WriteHeader(httpStatus)
+	err = json.NewEncoder(w).Encode(res)
+	if err != nil {
+		logger.Default().Error("couldn't encode json", "request_id", res.RequestID, "error", err)
+	}
+}
+
+// errorCode returns the code of a domain error or the snake cased status text.
+func errorCode(err error, httpStatus int) string {
+	if code, ok := domain.Code(err); ok {
+		return code
+	}
+
+	return strings.ToLower(strings.ReplaceAll(http.StatusText(httpStatus), " ", "_"))
 }
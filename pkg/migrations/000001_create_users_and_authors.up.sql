@@ -12,14 +12,14 @@ CREATE TABLE IF NOT EXISTS users
     roleID   integer NOT NULL REFERENCES user_role (id)
 );
 
-
 INSERT INTO user_role (role)
-values ('ADMIN'),
-       ('USER');
-
+SELECT role
+FROM (values ('ADMIN'), ('USER')) AS roles (role)
+WHERE NOT EXISTS(SELECT 1 FROM user_role);
 
 INSERT INTO users (login, password, roleID)
-values ('ADMIN', 'ADMIN', 1);
+SELECT 'ADMIN', '$2a$10$tGqpVfFKojCPKJGI2tuByucxC6TEVBAhkCqgfBtHn5ukWUFCFCPRG', 1
+WHERE NOT EXISTS(SELECT 1 FROM users WHERE login = 'ADMIN');
 
 CREATE TABLE IF NOT EXISTS author
 (
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens
+(
+    id         integer PRIMARY KEY GENERATED ALWAYS AS IDENTITY ( INCREMENT 1 START 1 MINVALUE 1 MAXVALUE 2147483647 CACHE 1 ),
+    userID     integer     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token      text        NOT NULL UNIQUE,
+    family     text        NOT NULL,
+    expires_at timestamptz NOT NULL,
+    revoked    boolean     NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_family_idx ON refresh_tokens (family);
+CREATE INDEX IF NOT EXISTS refresh_tokens_user_idx ON refresh_tokens (userID);
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS permission
+(
+    id   integer PRIMARY KEY GENERATED ALWAYS AS IDENTITY ( INCREMENT 1 START 1 MINVALUE 1 MAXVALUE 2147483647 CACHE 1 ),
+    name text NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS role_permission
+(
+    roleID       integer NOT NULL REFERENCES user_role (id) ON DELETE CASCADE,
+    permissionID integer NOT NULL REFERENCES permission (id) ON DELETE CASCADE,
+    PRIMARY KEY (roleID, permissionID)
+);
+
+INSERT INTO permission (name)
+values ('author:write'),
+       ('author:write:any'),
+       ('user:list')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permission (roleID, permissionID)
+SELECT 1, id
+FROM permission
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permission (roleID, permissionID)
+SELECT 2, id
+FROM permission
+WHERE name IN ('author:write')
+ON CONFLICT DO NOTHING;
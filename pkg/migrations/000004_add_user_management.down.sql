@@ -0,0 +1,5 @@
+DELETE FROM permission WHERE name = 'user:manage';
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS password_reset_required,
+    DROP COLUMN IF EXISTS disabled;
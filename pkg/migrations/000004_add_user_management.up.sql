@@ -0,0 +1,13 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS disabled                boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS password_reset_required boolean NOT NULL DEFAULT false;
+
+INSERT INTO permission (name)
+values ('user:manage')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permission (roleID, permissionID)
+SELECT 1, id
+FROM permission
+WHERE name = 'user:manage'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS author_user_idx;
+DROP INDEX IF EXISTS author_age_idx;
+DROP INDEX IF EXISTS author_name_idx;
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS author_name_idx ON author (name, id);
+CREATE INDEX IF NOT EXISTS author_age_idx ON author (age, id);
+CREATE INDEX IF NOT EXISTS author_user_idx ON author (userID);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS author_name_trgm_idx;
+DROP INDEX IF EXISTS author_search_idx;
+
+ALTER TABLE author
+    DROP COLUMN IF EXISTS search;
@@ -0,0 +1,10 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE author
+    ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+            setweight(to_tsvector('simple', name), 'A') ||
+            setweight(to_tsvector('simple', description), 'B')
+        ) STORED;
+
+CREATE INDEX IF NOT EXISTS author_search_idx ON author USING gin (search);
+CREATE INDEX IF NOT EXISTS author_name_trgm_idx ON author USING gin (name gin_trgm_ops);
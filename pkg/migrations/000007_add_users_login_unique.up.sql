@@ -0,0 +1,11 @@
+DO
+$$
+    BEGIN
+        IF EXISTS(SELECT 1 FROM users GROUP BY login HAVING count(*) > 1) THEN
+            RAISE EXCEPTION 'users have duplicate logins, rename or remove them before migrating'
+                USING HINT = 'SELECT login, count(*) FROM users GROUP BY login HAVING count(*) > 1';
+        END IF;
+    END
+$$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_login_idx ON users (login);
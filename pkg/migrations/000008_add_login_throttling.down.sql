@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS rate_limit_bucket;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS locked_until,
+    DROP COLUMN IF EXISTS failed_logins;
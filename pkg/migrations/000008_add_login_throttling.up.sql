@@ -0,0 +1,15 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS failed_logins integer NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS locked_until  timestamptz;
+
+CREATE TABLE IF NOT EXISTS rate_limit_bucket
+(
+    limiter    text             NOT NULL,
+    key        text             NOT NULL,
+    tokens     double precision NOT NULL,
+    allowed    boolean          NOT NULL,
+    updated_at timestamptz      NOT NULL,
+    PRIMARY KEY (limiter, key)
+);
+
+CREATE INDEX IF NOT EXISTS rate_limit_bucket_updated_at_idx ON rate_limit_bucket (limiter, updated_at);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS author_primary_idx;
+
+ALTER TABLE author
+    DROP COLUMN IF EXISTS is_primary;
@@ -0,0 +1,9 @@
+ALTER TABLE author
+    ADD COLUMN IF NOT EXISTS is_primary boolean NOT NULL DEFAULT false;
+
+UPDATE author
+SET is_primary = true
+WHERE id IN (SELECT min(id) FROM author GROUP BY userID)
+  AND NOT EXISTS(SELECT 1 FROM author WHERE is_primary);
+
+CREATE UNIQUE INDEX IF NOT EXISTS author_primary_idx ON author (userID) WHERE is_primary;
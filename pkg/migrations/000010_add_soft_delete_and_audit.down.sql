@@ -0,0 +1,25 @@
+DROP INDEX IF EXISTS author_deleted_at_idx;
+DROP INDEX IF EXISTS users_deleted_at_idx;
+
+DELETE FROM author
+WHERE deleted_at IS NOT NULL;
+
+DELETE FROM users
+WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS users_login_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS users_login_idx ON users (login);
+
+ALTER TABLE author
+    DROP COLUMN IF EXISTS updated_by,
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS updated_by,
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at;
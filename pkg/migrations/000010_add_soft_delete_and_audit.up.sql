@@ -0,0 +1,18 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS created_at timestamptz NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS created_by integer REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS updated_by integer REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE author
+    ADD COLUMN IF NOT EXISTS created_at timestamptz NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS created_by integer REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS updated_by integer REFERENCES users (id) ON DELETE SET NULL;
+
+DROP INDEX IF EXISTS users_login_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS users_login_idx ON users (login) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS author_deleted_at_idx ON author (deleted_at) WHERE deleted_at IS NOT NULL;
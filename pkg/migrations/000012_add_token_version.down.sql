@@ -0,0 +1,9 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS tokens_valid_after timestamptz;
+
+UPDATE users
+SET tokens_valid_after = now()
+WHERE token_version > 0;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS token_version;
@@ -0,0 +1,160 @@
+// Package probe serves liveness and readiness probes of the service.
+package probe
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/middleware"
+)
+
+// Statuses of the service and its components.
+const (
+	StatusOK       = "ok"
+	StatusFailing  = "failing"
+	StatusDraining = "draining"
+)
+
+// Check returns an error if the component doesn't work.
+type Check func(ctx context.Context) error
+
+// Component represents the status of a component checked by a readiness probe.
+type Component struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Report represents the status of the service.
+type Report struct {
+	Status     string      `json:"status"`
+	Components []Component `json:"components,omitempty"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Prober checks if the service is alive and ready to serve.
+type Prober struct {
+	checks   []namedCheck
+	timeout  time.Duration
+	draining int32
+}
+
+// NewProber is a Prober constructor, every check is bounded by the timeout.
+func NewProber(timeout time.Duration) *Prober {
+	return &Prober{timeout: timeout}
+}
+
+// Add adds the check of a component to the readiness probe.
+func (p *Prober) Add(name string, check Check) {
+	p.checks = append(p.checks, namedCheck{name: name, check: check})
+}
+
+// Drain makes the readiness probe fail, so the service stops getting new traffic before the shutdown.
+func (p *Prober) Drain() {
+	atomic.StoreInt32(&p.draining, 1)
+}
+
+// Live responds that the process is alive.
+// @Summary Liveness
+// @Tags probe
+// @Description Process is alive
+// @Produce  json
+// @Success 200 {object} probe.Report
+// @Router /healthz [get]
+func (p *Prober) Live(w http.ResponseWriter, r *http.Request) {
+	middleware.JSONReturn(w, http.StatusOK, Report{Status: StatusOK})
+}
+
+// Ready responds if the service is ready to serve, it fails while draining.
+// @Summary Readiness
+// @Tags probe
+// @Description Database answers, migrations are current and grpc server is serving
+// @Produce  json
+// @Success 200 {object} probe.Report
+// @Failure 503 {object} probe.Report
+// @Router /readyz [get]
+func (p *Prober) Ready(w http.ResponseWriter, r *http.Request) {
+	report := p.Report(r.Context())
+	report.Components = nil
+	p.respond(w, report)
+}
+
+// Details responds with the statuses, latencies and errors of all components,
+// it is served on the admin port as errors may reveal internals.
+// @Summary Health details
+// @Tags probe
+// @Description Statuses and latencies of components checked by readiness, served on the admin port
+// @Produce  json
+// @Success 200 {object} probe.Report
+// @Failure 503 {object} probe.Report
+// @Router /health [get]
+func (p *Prober) Details(w http.ResponseWriter, r *http.Request) {
+	p.respond(w, p.Report(r.Context()))
+}
+
+// Report runs all checks at once and reports statuses of the service and its components.
+func (p *Prober) Report(ctx context.Context) Report {
+	report := Report{
+		Status:     StatusOK,
+		Components: make([]Component, len(p.checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, c := range p.checks {
+		wg.Add(1)
+		go func(i int, c namedCheck) {
+			defer wg.Done()
+			report.Components[i] = p.run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	for _, component := range report.Components {
+		if component.Status != StatusOK {
+			report.Status = StatusFailing
+		}
+	}
+
+	if atomic.LoadInt32(&p.draining) == 1 {
+		report.Status = StatusDraining
+	}
+
+	return report
+}
+
+func (p *Prober) run(ctx context.Context, c namedCheck) Component {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.check(ctx)
+	component := Component{
+		Name:    c.name,
+		Status:  StatusOK,
+		Latency: time.Since(start).String(),
+	}
+
+	if err != nil {
+		component.Status = StatusFailing
+		component.Error = err.Error()
+	}
+
+	return component
+}
+
+func (p *Prober) respond(w http.ResponseWriter, report Report) {
+	statusCode := http.StatusOK
+	if report.Status != StatusOK {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	middleware.JSONReturn(w, statusCode, report)
+}
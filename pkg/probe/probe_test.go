@@ -0,0 +1,161 @@
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ok(context.Context) error {
+	return nil
+}
+
+func failing(context.Context) error {
+	return errors.New("connection refused")
+}
+
+func hanging(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestProber_Report(t *testing.T) {
+	assert := testAssert.New(t)
+	tt := []struct {
+		name      string
+		checks    map[string]Check
+		drain     bool
+		expStatus string
+		expFailed map[string]string
+	}{
+		{
+			name:      "no checks",
+			expStatus: StatusOK,
+		},
+		{
+			name:      "all ok",
+			checks:    map[string]Check{"database": ok, "grpc": ok},
+			expStatus: StatusOK,
+		},
+		{
+			name:      "failing check",
+			checks:    map[string]Check{"database": failing, "grpc": ok},
+			expStatus: StatusFailing,
+			expFailed: map[string]string{"database": "connection refused"},
+		},
+		{
+			name:      "check over timeout",
+			checks:    map[string]Check{"database": hanging},
+			expStatus: StatusFailing,
+			expFailed: map[string]string{"database": context.DeadlineExceeded.Error()},
+		},
+		{
+			name:      "draining",
+			checks:    map[string]Check{"database": ok},
+			drain:     true,
+			expStatus: StatusDraining,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			prober := NewProber(10 * time.Millisecond)
+			for name, check := range tc.checks {
+				prober.Add(name, check)
+			}
+			if tc.drain {
+				prober.Drain()
+			}
+
+			report := prober.Report(context.Background())
+			assert.Equal(tc.expStatus, report.Status)
+			assert.Len(report.Components, len(tc.checks))
+			for _, component := range report.Components {
+				msg, failed := tc.expFailed[component.Name]
+				assert.Equal(msg, component.Error)
+				if failed {
+					assert.Equal(StatusFailing, component.Status)
+				} else {
+					assert.Equal(StatusOK, component.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestProber_Handlers(t *testing.T) {
+	assert := testAssert.New(t)
+	tt := []struct {
+		name          string
+		check         Check
+		drain         bool
+		handler       func(p *Prober) http.HandlerFunc
+		expCode       int
+		expStatus     string
+		expComponents int
+	}{
+		{
+			name:      "live while failing",
+			check:     failing,
+			handler:   func(p *Prober) http.HandlerFunc { return p.Live },
+			expCode:   http.StatusOK,
+			expStatus: StatusOK,
+		},
+		{
+			name:      "ready",
+			check:     ok,
+			handler:   func(p *Prober) http.HandlerFunc { return p.Ready },
+			expCode:   http.StatusOK,
+			expStatus: StatusOK,
+		},
+		{
+			name:      "not ready hides components",
+			check:     failing,
+			handler:   func(p *Prober) http.HandlerFunc { return p.Ready },
+			expCode:   http.StatusServiceUnavailable,
+			expStatus: StatusFailing,
+		},
+		{
+			name:      "not ready while draining",
+			check:     ok,
+			drain:     true,
+			handler:   func(p *Prober) http.HandlerFunc { return p.Ready },
+			expCode:   http.StatusServiceUnavailable,
+			expStatus: StatusDraining,
+		},
+		{
+			name:          "details",
+			check:         failing,
+			handler:       func(p *Prober) http.HandlerFunc { return p.Details },
+			expCode:       http.StatusServiceUnavailable,
+			expStatus:     StatusFailing,
+			expComponents: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			prober := NewProber(time.Second)
+			prober.Add("database", tc.check)
+			if tc.drain {
+				prober.Drain()
+			}
+
+			w := httptest.NewRecorder()
+			tc.handler(prober)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(tc.expCode, w.Code)
+
+			var report Report
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+			assert.Equal(tc.expStatus, report.Status)
+			assert.Len(report.Components, tc.expComponents)
+		})
+	}
+}
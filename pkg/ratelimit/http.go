@@ -0,0 +1,129 @@
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	"github.com/JesusG2000/hexsatisfaction/pkg/domain"
+	"github.com/JesusG2000/hexsatisfaction/pkg/logger"
+	"github.com/JesusG2000/hexsatisfaction/pkg/middleware"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+// KeyFunc returns the key of the client who made the request.
+type KeyFunc func(r *http.Request) string
+
+// Keys builds keys of clients. The address of a client is taken from X-Forwarded-For
+// only if the request comes from a trusted proxy, otherwise anyone could pick a key by the header.
+type Keys struct {
+	trustedProxies []*net.IPNet
+}
+
+// NewKeys is a Keys constructor, trustedProxies are CIDRs like 10.0.0.0/8 or single addresses.
+func NewKeys(trustedProxies []string) (*Keys, error) {
+	keys := &Keys{trustedProxies: make([]*net.IPNet, 0, len(trustedProxies))}
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, errors.Errorf("invalid trusted proxy %q", proxy)
+			}
+			keys.trustedProxies = append(keys.trustedProxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid trusted proxy %q", proxy)
+		}
+		keys.trustedProxies = append(keys.trustedProxies, network)
+	}
+
+	return keys, nil
+}
+
+// ByIP keys requests by the address of the client.
+func (k *Keys) ByIP(r *http.Request) string {
+	return "ip:" + k.clientIP(r)
+}
+
+// ByUser keys requests by the authenticated user or by the address of the client if there is no one.
+func (k *Keys) ByUser(r *http.Request) string {
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		return k.ByIP(r)
+	}
+
+	return "user:" + strconv.Itoa(principal.UserID)
+}
+
+// clientIP returns the remote address or, behind trusted proxies, the last address
+// of X-Forwarded-For which isn't a trusted proxy. Proxies append addresses they got requests from,
+// so addresses before the first untrusted one may be forged by the client.
+func (k *Keys) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	if !k.trusted(host) {
+		return host
+	}
+
+	forwarded := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addr := strings.TrimSpace(forwarded[i])
+		if net.ParseIP(addr) == nil {
+			break
+		}
+
+		host = addr
+		if !k.trusted(addr) {
+			break
+		}
+	}
+
+	return host
+}
+
+// trusted checks the address belongs to a trusted proxy.
+func (k *Keys) trusted(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range k.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Middleware throttles requests by their keys, throttled requests get 429 with the Retry-After header.
+// Requests pass if the limiter fails, so a broken backend doesn't take the service down.
+func Middleware(limiter Limiter, key KeyFunc) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ok, retryAfter, err := limiter.Allow(r.Context(), key(r))
+			if err != nil {
+				logger.Default().WithContext(r.Context()).Error("rate limiter failed", "error", err)
+				ok = true
+			}
+
+			if !ok {
+				middleware.JSONError(w, domain.Throttle(retryAfter, "too many requests, try again later"), http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
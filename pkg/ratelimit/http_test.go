@@ -0,0 +1,129 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JesusG2000/hexsatisfaction/pkg/auth"
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeys(t *testing.T) {
+	assert := testAssert.New(t)
+	tt := []struct {
+		name    string
+		proxies []string
+		isOk    bool
+	}{
+		{
+			name:    "bad cidr",
+			proxies: []string{"10.0.0.0/33"},
+		},
+		{
+			name:    "bad address",
+			proxies: []string{"proxy"},
+		},
+		{
+			name: "no proxies",
+			isOk: true,
+		},
+		{
+			name:    "all ok",
+			proxies: []string{"10.0.0.0/8", " 192.168.1.1", "::1"},
+			isOk:    true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewKeys(tc.proxies)
+			assert.Equal(tc.isOk, err == nil)
+		})
+	}
+}
+
+func TestKeys_ByIP(t *testing.T) {
+	assert := testAssert.New(t)
+	keys, err := NewKeys([]string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+	tt := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		expRes     string
+	}{
+		{
+			name:       "direct client",
+			remoteAddr: "203.0.113.1:1234",
+			expRes:     "ip:203.0.113.1",
+		},
+		{
+			name:       "untrusted proxy",
+			remoteAddr: "203.0.113.1:1234",
+			forwarded:  []string{"198.51.100.1"},
+			expRes:     "ip:203.0.113.1",
+		},
+		{
+			name:       "trusted proxy without header",
+			remoteAddr: "10.0.0.1:1234",
+			expRes:     "ip:10.0.0.1",
+		},
+		{
+			name:       "trusted proxy",
+			remoteAddr: "10.0.0.1:1234",
+			forwarded:  []string{"198.51.100.1"},
+			expRes:     "ip:198.51.100.1",
+		},
+		{
+			name:       "forged addresses before the client",
+			remoteAddr: "10.0.0.1:1234",
+			forwarded:  []string{"1.1.1.1, 198.51.100.1"},
+			expRes:     "ip:198.51.100.1",
+		},
+		{
+			name:       "chain of trusted proxies",
+			remoteAddr: "10.0.0.1:1234",
+			forwarded:  []string{"198.51.100.1, 192.168.1.1", "10.0.0.2"},
+			expRes:     "ip:198.51.100.1",
+		},
+		{
+			name:       "only trusted proxies",
+			remoteAddr: "10.0.0.1:1234",
+			forwarded:  []string{"10.0.0.3, 10.0.0.2"},
+			expRes:     "ip:10.0.0.3",
+		},
+		{
+			name:       "garbage in header",
+			remoteAddr: "10.0.0.1:1234",
+			forwarded:  []string{"198.51.100.1, unknown"},
+			expRes:     "ip:10.0.0.1",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tc.remoteAddr
+			for _, forwarded := range tc.forwarded {
+				r.Header.Add("X-Forwarded-For", forwarded)
+			}
+
+			assert.Equal(tc.expRes, keys.ByIP(r))
+		})
+	}
+}
+
+func TestKeys_ByUser(t *testing.T) {
+	assert := testAssert.New(t)
+	keys, err := NewKeys(nil)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "203.0.113.1:1234"
+	assert.Equal("ip:203.0.113.1", keys.ByUser(r))
+
+	r = r.WithContext(auth.WithPrincipal(r.Context(), &auth.Principal{UserID: 1}))
+	assert.Equal("user:1", keys.ByUser(r))
+}
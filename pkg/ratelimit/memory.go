@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// Memory keeps buckets in memory, so every replica of the service limits clients on its own.
+type Memory struct {
+	policy  Policy
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// NewMemory is a Memory constructor.
+func NewMemory(policy Policy) *Memory {
+	return &Memory{
+		policy:  policy,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token of the key, if the bucket is empty it returns false and the time to wait for a token.
+func (m *Memory) Allow(_ context.Context, key string) (bool, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(m.policy.Burst), updatedAt: now}
+		m.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(m.policy.Burst), b.tokens+now.Sub(b.updatedAt).Seconds()*m.policy.Rate)
+	b.updatedAt = now
+
+	if b.tokens < 1 {
+		return false, m.policy.wait(b.tokens), nil
+	}
+
+	b.tokens--
+	return true, 0, nil
+}
+
+// Sweep removes buckets which are full again.
+func (m *Memory) Sweep(context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	full := m.now().Add(-m.policy.refillTime())
+	for key, b := range m.buckets {
+		if b.updatedAt.Before(full) {
+			delete(m.buckets, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMemory returns a limiter with the clock moved by the returned func.
+func newTestMemory(policy Policy) (*Memory, func(time.Duration)) {
+	now := time.Unix(0, 0)
+	memory := NewMemory(policy)
+	memory.now = func() time.Time {
+		return now
+	}
+
+	return memory, func(d time.Duration) {
+		now = now.Add(d)
+	}
+}
+
+func TestMemory_Allow(t *testing.T) {
+	assert := testAssert.New(t)
+	memory, advance := newTestMemory(PerMinute(60, 3))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		ok, _, err := memory.Allow(ctx, "a")
+		require.NoError(t, err)
+		assert.True(ok, "burst token %d", i)
+	}
+
+	ok, retryAfter, err := memory.Allow(ctx, "a")
+	require.NoError(t, err)
+	assert.False(ok)
+	assert.Equal(time.Second, retryAfter)
+
+	ok, _, err = memory.Allow(ctx, "b")
+	require.NoError(t, err)
+	assert.True(ok, "keys have own buckets")
+
+	advance(500 * time.Millisecond)
+	ok, retryAfter, err = memory.Allow(ctx, "a")
+	require.NoError(t, err)
+	assert.False(ok)
+	assert.Equal(500*time.Millisecond, retryAfter)
+
+	advance(500 * time.Millisecond)
+	ok, _, err = memory.Allow(ctx, "a")
+	require.NoError(t, err)
+	assert.True(ok, "refilled token")
+
+	advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		ok, _, err = memory.Allow(ctx, "a")
+		require.NoError(t, err)
+		assert.True(ok, "refilled token %d", i)
+	}
+	ok, _, err = memory.Allow(ctx, "a")
+	require.NoError(t, err)
+	assert.False(ok, "bucket doesn't overflow burst")
+}
+
+func TestMemory_Sweep(t *testing.T) {
+	assert := testAssert.New(t)
+	memory, advance := newTestMemory(PerMinute(60, 3))
+	ctx := context.Background()
+
+	_, _, err := memory.Allow(ctx, "a")
+	require.NoError(t, err)
+	advance(2 * time.Second)
+	_, _, err = memory.Allow(ctx, "b")
+	require.NoError(t, err)
+
+	advance(2 * time.Second)
+	require.NoError(t, memory.Sweep(ctx))
+	assert.NotContains(memory.buckets, "a")
+	assert.Contains(memory.buckets, "b")
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	assert := testAssert.New(t)
+	assert.Error(PerMinute(0, 1).Validate())
+	assert.Error(PerMinute(1, 0).Validate())
+	assert.NoError(PerMinute(1, 1).Validate())
+}
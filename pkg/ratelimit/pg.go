@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Execer runs queries, it is implemented by sql.DB.
+type Execer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Pg keeps buckets in the rate_limit_bucket table, so limits hold across replicas of the service.
+// Limiters sharing the table are told apart by their names.
+type Pg struct {
+	db     Execer
+	name   string
+	policy Policy
+}
+
+// NewPg is a Pg constructor.
+func NewPg(db Execer, name string, policy Policy) *Pg {
+	return &Pg{db: db, name: name, policy: policy}
+}
+
+// allowQuery refills the bucket up to $3 tokens at $4 tokens per second and takes a token if there is a whole one,
+// expressions of the update see the bucket as it was before.
+const allowQuery = `
+INSERT INTO rate_limit_bucket AS b (limiter, key, tokens, allowed, updated_at)
+VALUES ($1, $2, $3::float8 - 1, true, now())
+ON CONFLICT (limiter, key) DO UPDATE SET
+    tokens     = LEAST($3::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $4::float8)
+        - CASE WHEN LEAST($3::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $4::float8) >= 1 THEN 1 ELSE 0 END,
+    allowed    = LEAST($3::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $4::float8) >= 1,
+    updated_at = now()
+RETURNING tokens, allowed`
+
+// Allow takes a token of the key, if the bucket is empty it returns false and the time to wait for a token.
+func (p *Pg) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	var (
+		tokens  float64
+		allowed bool
+	)
+	err := p.db.QueryRowContext(ctx, allowQuery, p.name, key, p.policy.Burst, p.policy.Rate).Scan(&tokens, &allowed)
+	if err != nil {
+		return false, 0, errors.Wrap(err, "couldn't take a token")
+	}
+
+	if !allowed {
+		return false, p.policy.wait(tokens), nil
+	}
+
+	return true, 0, nil
+}
+
+// Sweep removes buckets which are full again.
+func (p *Pg) Sweep(ctx context.Context) error {
+	_, err := p.db.ExecContext(ctx, "DELETE FROM rate_limit_bucket WHERE limiter = $1 AND updated_at < now() - $2::float8 * interval '1 second'",
+		p.name, p.policy.refillTime().Seconds())
+	return errors.Wrap(err, "couldn't sweep buckets")
+}
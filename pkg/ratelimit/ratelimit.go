@@ -0,0 +1,74 @@
+// Package ratelimit throttles clients by token buckets kept in memory or in Postgres.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/config"
+	"github.com/pkg/errors"
+)
+
+// Supported backends keeping buckets.
+const (
+	BackendMemory = "memory"
+	BackendPg     = "pg"
+)
+
+// Limiter takes a token from the bucket of a key, every bucket holds up to burst tokens
+// and gets rate tokens per second back.
+type Limiter interface {
+	// Allow takes a token of the key, if the bucket is empty it returns false and the time to wait for a token.
+	Allow(ctx context.Context, key string) (ok bool, retryAfter time.Duration, err error)
+	// Sweep removes buckets which are full again, so unused keys don't pile up.
+	Sweep(ctx context.Context) error
+}
+
+// Policy is the rate and the burst of a limiter.
+type Policy struct {
+	// Rate is the number of tokens per second.
+	Rate float64
+	// Burst is the size of the bucket.
+	Burst int
+}
+
+// PerMinute returns the policy giving n tokens per minute with the burst.
+func PerMinute(n, burst int) Policy {
+	return Policy{Rate: float64(n) / 60, Burst: burst}
+}
+
+// Validate checks the policy can ever allow a request.
+func (p Policy) Validate() error {
+	if p.Rate <= 0 || p.Burst < 1 {
+		return errors.Errorf("rate %v and burst %d must be positive", p.Rate, p.Burst)
+	}
+
+	return nil
+}
+
+// refillTime returns how long an empty bucket takes to be full again.
+func (p Policy) refillTime() time.Duration {
+	return time.Duration(float64(p.Burst) / p.Rate * float64(time.Second))
+}
+
+// wait returns how long to wait until the bucket with tokens gets a whole token.
+func (p Policy) wait(tokens float64) time.Duration {
+	return time.Duration(math.Ceil((1 - tokens) / p.Rate * float64(time.Second)))
+}
+
+// New creates a limiter of the configured backend, the pg backend keeps buckets of the named limiter in db.
+func New(cfg config.RateLimitConfig, db Execer, name string, policy Policy) (Limiter, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch cfg.Backend {
+	case BackendMemory:
+		return NewMemory(policy), nil
+	case BackendPg:
+		return NewPg(db, name, policy), nil
+	default:
+		return nil, errors.Errorf("unsupported rate limit backend %q", cfg.Backend)
+	}
+}
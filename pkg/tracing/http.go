@@ -0,0 +1,31 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HTTP starts a span of every request continuing the trace from the traceparent header.
+// Routers must use Route to name spans by their own routes.
+func HTTP() mux.MiddlewareFunc {
+	return otelmux.Middleware(ServiceName)
+}
+
+// Route names the span started by HTTP after the template of the route matched by a router.
+func Route(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				span := trace.SpanFromContext(r.Context())
+				span.SetName(template)
+				span.SetAttributes(semconv.HTTPRouteKey.String(template))
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JesusG2000/hexsatisfaction/internal/config"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	testAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// recordSpans installs a provider recording spans until the test ends.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+	})
+
+	return recorder
+}
+
+func TestInit(t *testing.T) {
+	assert := testAssert.New(t)
+	_, err := Init(context.Background(), config.TraceConfig{Exporter: "jaeger"})
+	assert.Error(err)
+
+	shutdown, err := Init(context.Background(), config.TraceConfig{Exporter: ExporterNone})
+	require.NoError(t, err)
+	assert.Nil(shutdown(context.Background()))
+}
+
+func TestHTTP(t *testing.T) {
+	assert := testAssert.New(t)
+	recorder := recordSpans(t)
+
+	nested := mux.NewRouter()
+	nested.Use(Route)
+	nested.HandleFunc("/author/{id}", func(http.ResponseWriter, *http.Request) {}).Methods(http.MethodGet)
+
+	router := mux.NewRouter()
+	router.Use(HTTP())
+	router.PathPrefix("/author").Handler(nested)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	r := httptest.NewRequest(http.MethodGet, "/author/15", nil)
+	r.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal("/author/{id}", spans[0].Name())
+	assert.Equal(traceID, spans[0].SpanContext().TraceID().String(), "trace continues from traceparent")
+	assert.Contains(spans[0].Attributes(), semconv.HTTPRouteKey.String("/author/{id}"))
+}
+
+func TestEnd(t *testing.T) {
+	assert := testAssert.New(t)
+	recorder := recordSpans(t)
+	tracer := otel.Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "ok")
+	End(span, nil)
+	_, span = tracer.Start(context.Background(), "failed")
+	End(span, errors.New("boom"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(codes.Unset, spans[0].Status().Code)
+	assert.Equal(codes.Error, spans[1].Status().Code)
+	assert.Equal("boom", spans[1].Status().Description)
+	assert.Len(spans[1].Events(), 1, "error is recorded as an event")
+}